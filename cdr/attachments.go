@@ -0,0 +1,168 @@
+package cdr
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// AttachmentStore keeps attachment content outside of CDR, e.g. in S3 or the
+// HSDP Blob Repository, so DocumentReferences only carry a URL
+type AttachmentStore interface {
+	// Upload stores the content read from r and returns the URL to reference it by
+	Upload(ctx context.Context, name, contentType string, r io.Reader) (string, error)
+	// Open returns the content stored at url
+	Open(ctx context.Context, url string) (io.ReadCloser, error)
+}
+
+// Coding is a FHIR Coding
+type Coding struct {
+	System  string `json:"system,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+// DocumentAttachment describes a DocumentReference and the content of its attachment
+type DocumentAttachment struct {
+	// Name identifies the content in the AttachmentStore
+	Name        string
+	ContentType string
+	Title       string
+	// Subject is a reference to the subject of the document, e.g. "Patient/123"
+	Subject string
+	// Status defaults to current
+	Status string
+	// Type is the kind of document and is required for STU3
+	Type    []Coding
+	Content io.Reader
+	// InlineLimit is the size in bytes up to which content is inlined as base64
+	// instead of being uploaded. The default of 0 always uploads
+	InlineLimit int64
+}
+
+type documentReferenceContent struct {
+	Content []struct {
+		Attachment struct {
+			ContentType string `json:"contentType"`
+			Data        string `json:"data"`
+			URL         string `json:"url"`
+		} `json:"attachment"`
+	} `json:"content"`
+}
+
+// OffloadAttachment uploads the attachment content to store and creates a
+// DocumentReference referencing it by URL, with the size and SHA-1 hash of the
+// content. The created DocumentReference is returned
+func (c *Client) OffloadAttachment(ctx context.Context, store AttachmentStore, document DocumentAttachment, options ...OptionFunc) (json.RawMessage, *Response, error) {
+	if document.Content == nil {
+		return nil, nil, ErrMissingAttachment
+	}
+	if document.ContentType == "" {
+		return nil, nil, ErrMissingContentType
+	}
+	if c.fhirVersion == FHIRVersionSTU3 && len(document.Type) == 0 {
+		return nil, nil, ErrMissingDocumentType
+	}
+	hash := sha1.New()
+	var size int64
+	attachment := map[string]interface{}{
+		"contentType": document.ContentType,
+	}
+	if document.Title != "" {
+		attachment["title"] = document.Title
+	}
+	// Read one byte past the limit to know whether the content fits
+	head, err := io.ReadAll(io.LimitReader(document.Content, document.InlineLimit+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	if document.InlineLimit > 0 && int64(len(head)) <= document.InlineLimit {
+		_, _ = hash.Write(head)
+		size = int64(len(head))
+		attachment["data"] = base64.StdEncoding.EncodeToString(head)
+	} else {
+		counter := &countingWriter{w: hash}
+		content := io.TeeReader(io.MultiReader(bytes.NewReader(head), document.Content), counter)
+		url, err := store.Upload(ctx, document.Name, document.ContentType, content)
+		if err != nil {
+			return nil, nil, fmt.Errorf("upload attachment: %w", err)
+		}
+		size = counter.n
+		attachment["url"] = url
+	}
+	attachment["size"] = size
+	attachment["hash"] = base64.StdEncoding.EncodeToString(hash.Sum(nil))
+
+	body, err := json.Marshal(c.documentReference(document, attachment))
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.OperationsRaw.Create("DocumentReference", body, options...)
+}
+
+// ResolveAttachment returns the content of the attachment at index of the
+// DocumentReference, reading inlined data directly and offloaded content from store
+func (c *Client) ResolveAttachment(ctx context.Context, store AttachmentStore, documentReference json.RawMessage, index int) (io.ReadCloser, string, error) {
+	var document documentReferenceContent
+	if err := json.Unmarshal(documentReference, &document); err != nil {
+		return nil, "", fmt.Errorf("JSON unmarshal: %w", err)
+	}
+	if index < 0 || index >= len(document.Content) {
+		return nil, "", ErrMissingAttachment
+	}
+	attachment := document.Content[index].Attachment
+	switch {
+	case attachment.Data != "":
+		reader := base64.NewDecoder(base64.StdEncoding, strings.NewReader(attachment.Data))
+		return io.NopCloser(reader), attachment.ContentType, nil
+	case attachment.URL != "":
+		content, err := store.Open(ctx, attachment.URL)
+		if err != nil {
+			return nil, "", fmt.Errorf("open attachment: %w", err)
+		}
+		return content, attachment.ContentType, nil
+	}
+	return nil, "", ErrMissingAttachment
+}
+
+func (c *Client) documentReference(document DocumentAttachment, attachment map[string]interface{}) map[string]interface{} {
+	status := document.Status
+	if status == "" {
+		status = "current"
+	}
+	resource := map[string]interface{}{
+		"resourceType": "DocumentReference",
+		"status":       status,
+		"content":      []interface{}{map[string]interface{}{"attachment": attachment}},
+	}
+	if len(document.Type) > 0 {
+		resource["type"] = map[string]interface{}{"coding": document.Type}
+	}
+	if document.Subject != "" {
+		resource["subject"] = map[string]interface{}{"reference": document.Subject}
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	if c.fhirVersion == FHIRVersionR4 {
+		resource["date"] = now
+	} else {
+		resource["indexed"] = now
+	}
+	return resource
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,109 @@
+package cdr_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/fhir/go/jsonformat"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryStore struct {
+	blobs map[string][]byte
+}
+
+func (m *memoryStore) Upload(_ context.Context, name, _ string, r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	m.blobs[name] = data
+	return "https://blobs.example.com/" + name, nil
+}
+
+func (m *memoryStore) Open(_ context.Context, url string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(m.blobs[strings.TrimPrefix(url, "https://blobs.example.com/")])), nil
+}
+
+func TestOffloadAttachment(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	store := &memoryStore{blobs: map[string][]byte{}}
+	var received []byte
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/DocumentReference", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, http.MethodPost, r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		received, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/fhir+json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(received)
+	})
+
+	_, _, err := cdrClient.OffloadAttachment(context.Background(), store, cdr.DocumentAttachment{
+		ContentType: "application/pdf",
+		Content:     strings.NewReader("%PDF"),
+	})
+	assert.Equal(t, cdr.ErrMissingDocumentType, err)
+
+	content := strings.Repeat("x", 1024)
+	document, resp, err := cdrClient.OffloadAttachment(context.Background(), store, cdr.DocumentAttachment{
+		Name:        "report.pdf",
+		ContentType: "application/pdf",
+		Subject:     "Patient/123",
+		Type:        []cdr.Coding{{System: "http://loinc.org", Code: "11488-4"}},
+		Content:     strings.NewReader(content),
+		InlineLimit: 16,
+	})
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Equal(t, content, string(store.blobs["report.pdf"]))
+	var sent map[string]interface{}
+	_ = json.Unmarshal(received, &sent)
+	attachment := sent["content"].([]interface{})[0].(map[string]interface{})["attachment"].(map[string]interface{})
+	assert.Equal(t, "https://blobs.example.com/report.pdf", attachment["url"])
+	assert.Equal(t, float64(1024), attachment["size"])
+	assert.NotContains(t, attachment, "data")
+	assert.NotEmpty(t, sent["indexed"])
+
+	reader, contentType, err := cdrClient.ResolveAttachment(context.Background(), store, document, 0)
+	if !assert.Nil(t, err) {
+		return
+	}
+	resolved, _ := io.ReadAll(reader)
+	assert.Equal(t, content, string(resolved))
+	assert.Equal(t, "application/pdf", contentType)
+
+	document, _, err = cdrClient.OffloadAttachment(context.Background(), store, cdr.DocumentAttachment{
+		Name:        "note.txt",
+		ContentType: "text/plain",
+		Type:        []cdr.Coding{{System: "http://loinc.org", Code: "34109-9"}},
+		Content:     strings.NewReader("small"),
+		InlineLimit: 16,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotContains(t, store.blobs, "note.txt")
+	reader, _, err = cdrClient.ResolveAttachment(context.Background(), store, document, 0)
+	if !assert.Nil(t, err) {
+		return
+	}
+	resolved, _ = io.ReadAll(reader)
+	assert.Equal(t, "small", string(resolved))
+
+	_, _, err = cdrClient.ResolveAttachment(context.Background(), store, document, 1)
+	assert.Equal(t, cdr.ErrMissingAttachment, err)
+}
@@ -0,0 +1,114 @@
+package cdr
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// BinaryService streams Binary resources in their native content type,
+// so payloads do not have to be held in memory as FHIR JSON
+type BinaryService struct {
+	client *Client
+}
+
+// BinaryReference identifies a stored Binary resource
+type BinaryReference struct {
+	ID        string
+	VersionID string
+	Location  string
+}
+
+// Create stores the content read from r as a new Binary resource of contentType, e.g. "application/pdf"
+func (b *BinaryService) Create(contentType string, r io.Reader, options ...OptionFunc) (*BinaryReference, *Response, error) {
+	return b.upload(http.MethodPost, "Binary", contentType, r, options...)
+}
+
+// Update replaces the content of the Binary resource with the given id
+func (b *BinaryService) Update(id, contentType string, r io.Reader, options ...OptionFunc) (*BinaryReference, *Response, error) {
+	if id == "" {
+		return nil, nil, ErrMissingResourceID
+	}
+	ref, resp, err := b.upload(http.MethodPut, "Binary/"+id, contentType, r, options...)
+	if ref != nil && ref.ID == "" {
+		ref.ID = id
+	}
+	return ref, resp, err
+}
+
+// Download writes the content of the Binary resource with the given id to w.
+// accept can be used to request a specific content type and defaults to any.
+// The content type of the returned content is returned
+func (b *BinaryService) Download(id, accept string, w io.Writer, options ...OptionFunc) (string, *Response, error) {
+	if id == "" {
+		return "", nil, ErrMissingResourceID
+	}
+	if accept == "" {
+		accept = "*/*"
+	}
+	req, err := b.client.newCDRRequest(http.MethodGet, "Binary/"+id, nil, options)
+	if err != nil {
+		return "", nil, err
+	}
+	req.Header.Set("Accept", accept)
+	resp, err := b.client.do(req, w)
+	if err != nil || resp == nil {
+		if resp == nil && err == nil {
+			err = fmt.Errorf("BinaryService.Download: %w", ErrEmptyResult)
+		}
+		return "", resp, err
+	}
+	return resp.Header.Get("Content-Type"), resp, nil
+}
+
+func (b *BinaryService) upload(method, path, contentType string, r io.Reader, options ...OptionFunc) (*BinaryReference, *Response, error) {
+	if contentType == "" {
+		return nil, nil, ErrMissingContentType
+	}
+	req, err := b.client.newCDRRequest(method, path, nil, append([]OptionFunc{
+		func(req *http.Request) error {
+			req.Header.Set("Content-Type", contentType)
+			return nil
+		},
+	}, options...))
+	if err != nil {
+		return nil, nil, err
+	}
+	// Stream the body, its length is unknown up front
+	req.Body = io.NopCloser(r)
+	req.ContentLength = -1
+	req.Header.Set("Accept", b.client.MediaType())
+	resp, err := b.client.do(req, io.Discard)
+	if err != nil || resp == nil {
+		if resp == nil && err == nil {
+			err = fmt.Errorf("BinaryService %s: %w", method, ErrEmptyResult)
+		}
+		return nil, resp, err
+	}
+	ref := &BinaryReference{Location: resp.Header.Get("Location")}
+	if ref.Location == "" {
+		ref.Location = resp.Header.Get("Content-Location")
+	}
+	ref.ID, ref.VersionID = parseBinaryLocation(ref.Location)
+	if ref.VersionID == "" {
+		ref.VersionID = strings.TrimSuffix(strings.TrimPrefix(resp.Header.Get("ETag"), `W/"`), `"`)
+	}
+	return ref, resp, nil
+}
+
+// parseBinaryLocation extracts the id and version from e.g. ".../Binary/123/_history/2"
+func parseBinaryLocation(location string) (string, string) {
+	parts := strings.Split(strings.Trim(location, "/"), "/")
+	for i := len(parts) - 2; i >= 0; i-- {
+		if parts[i] != "Binary" {
+			continue
+		}
+		id := parts[i+1]
+		if i+3 < len(parts) && parts[i+2] == "_history" {
+			return id, parts[i+3]
+		}
+		return id, ""
+	}
+	return "", ""
+}
@@ -0,0 +1,75 @@
+package cdr_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/fhir/go/jsonformat"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinaryService(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	binaryID := "b8c2d8a4-1f2e-4f4a-8b5a-0c9d6e3f2a1b"
+	content := "%PDF-1.4 some document"
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Binary", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, http.MethodPost, r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		assert.Equal(t, "application/pdf", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, content, string(body))
+		w.Header().Set("Location", serverCDR.URL+"/store/fhir/"+cdrOrgID+"/Binary/"+binaryID+"/_history/1")
+		w.WriteHeader(http.StatusCreated)
+	})
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Binary/"+binaryID, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "application/pdf", r.Header.Get("Accept"))
+			w.Header().Set("Content-Type", "application/pdf")
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, content)
+		case http.MethodPut:
+			w.Header().Set("ETag", `W/"2"`)
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	_, _, err := cdrClient.Binary.Create("", strings.NewReader(content))
+	assert.ErrorIs(t, err, cdr.ErrMissingContentType)
+
+	ref, resp, err := cdrClient.Binary.Create("application/pdf", strings.NewReader(content))
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) || !assert.NotNil(t, ref) {
+		return
+	}
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, binaryID, ref.ID)
+	assert.Equal(t, "1", ref.VersionID)
+
+	ref, _, err = cdrClient.Binary.Update(binaryID, "application/pdf", strings.NewReader(content))
+	if !assert.Nil(t, err) || !assert.NotNil(t, ref) {
+		return
+	}
+	assert.Equal(t, "2", ref.VersionID)
+	assert.Equal(t, binaryID, ref.ID)
+
+	var buf bytes.Buffer
+	contentType, _, err := cdrClient.Binary.Download(binaryID, "application/pdf", &buf)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "application/pdf", contentType)
+	assert.Equal(t, content, buf.String())
+}
@@ -0,0 +1,233 @@
+package cdr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	protov1 "github.com/golang/protobuf/proto"
+)
+
+// Bundle types supported by BundleBuilder
+const (
+	BundleTypeTransaction = "transaction"
+	BundleTypeBatch       = "batch"
+)
+
+// BundleBuilder collects creates, updates and deletes and submits them to the
+// FHIR store in a single transaction or batch bundle
+type BundleBuilder struct {
+	client     *Client
+	bundleType string
+	entries    []bundleEntry
+}
+
+type bundleEntry struct {
+	FullURL  string          `json:"fullUrl,omitempty"`
+	Resource json.RawMessage `json:"resource,omitempty"`
+	Request  bundleRequest   `json:"request"`
+}
+
+type bundleRequest struct {
+	Method  string `json:"method"`
+	URL     string `json:"url"`
+	IfMatch string `json:"ifMatch,omitempty"`
+}
+
+type bundleResponse struct {
+	ResourceType string `json:"resourceType"`
+	Type         string `json:"type"`
+	Entry        []struct {
+		Resource json.RawMessage `json:"resource,omitempty"`
+		Response struct {
+			Status       string          `json:"status"`
+			Location     string          `json:"location,omitempty"`
+			Etag         string          `json:"etag,omitempty"`
+			LastModified string          `json:"lastModified,omitempty"`
+			Outcome      json.RawMessage `json:"outcome,omitempty"`
+		} `json:"response"`
+	} `json:"entry"`
+}
+
+// BundleEntryResult holds the outcome of a single entry of a submitted bundle
+type BundleEntryResult struct {
+	// Method and URL of the entry request
+	Method string
+	URL    string
+	// StatusCode is parsed from the entry response status, e.g. "201 Created"
+	StatusCode   int
+	Status       string
+	Location     string
+	ETag         string
+	LastModified string
+	// Resource is the returned resource as a ContainedResource of the configured FHIR version, if any
+	Resource protov1.Message
+	// Outcome is the raw OperationOutcome of the entry, if any
+	Outcome json.RawMessage
+	Err     error
+}
+
+// NewBundleBuilder returns a builder for a bundle of the given type,
+// either BundleTypeTransaction or BundleTypeBatch
+func (c *Client) NewBundleBuilder(bundleType string) (*BundleBuilder, error) {
+	if bundleType != BundleTypeTransaction && bundleType != BundleTypeBatch {
+		return nil, fmt.Errorf("bundle type [%s]: %w", bundleType, ErrInvalidBundleType)
+	}
+	return &BundleBuilder{client: c, bundleType: bundleType}, nil
+}
+
+// Len returns the number of entries collected so far
+func (b *BundleBuilder) Len() int {
+	return len(b.entries)
+}
+
+// Create adds the creation of the FHIR resource in jsonBody. fullURL is optional
+// and can be used to reference the new resource from other entries of a transaction,
+// e.g. "urn:uuid:..."
+func (b *BundleBuilder) Create(jsonBody []byte, fullURL string) error {
+	resourceType, _, err := resourceTypeAndID(jsonBody)
+	if err != nil {
+		return err
+	}
+	b.entries = append(b.entries, bundleEntry{
+		FullURL:  fullURL,
+		Resource: jsonBody,
+		Request:  bundleRequest{Method: http.MethodPost, URL: resourceType},
+	})
+	return nil
+}
+
+// Update adds the update of the FHIR resource in jsonBody. The resource must have an id.
+// When versionID is not empty it is used as the If-Match precondition of the entry
+func (b *BundleBuilder) Update(jsonBody []byte, versionID string) error {
+	resourceType, id, err := resourceTypeAndID(jsonBody)
+	if err != nil {
+		return err
+	}
+	if id == "" {
+		return ErrMissingResourceID
+	}
+	entry := bundleEntry{
+		Resource: jsonBody,
+		Request:  bundleRequest{Method: http.MethodPut, URL: resourceType + "/" + id},
+	}
+	if versionID != "" {
+		entry.Request.IfMatch = `W/"` + versionID + `"`
+	}
+	b.entries = append(b.entries, entry)
+	return nil
+}
+
+// Delete adds the removal of the FHIR resource, e.g. "Patient/123"
+func (b *BundleBuilder) Delete(resourceID string) error {
+	if resourceID == "" {
+		return ErrMissingResourceID
+	}
+	b.entries = append(b.entries, bundleEntry{
+		Request: bundleRequest{Method: http.MethodDelete, URL: resourceID},
+	})
+	return nil
+}
+
+// Bundle returns the JSON representation of the collected entries
+func (b *BundleBuilder) Bundle() ([]byte, error) {
+	if len(b.entries) == 0 {
+		return nil, ErrEmptyBundle
+	}
+	return json.Marshal(struct {
+		ResourceType string        `json:"resourceType"`
+		Type         string        `json:"type"`
+		Entry        []bundleEntry `json:"entry"`
+	}{
+		ResourceType: "Bundle",
+		Type:         b.bundleType,
+		Entry:        b.entries,
+	})
+}
+
+// Post submits the bundle to the FHIR store. The results are returned in the order the
+// entries were added. For batches failing entries have their Err set while the
+// other entries are still processed. A failing transaction returns an error instead
+func (b *BundleBuilder) Post(options ...OptionFunc) ([]BundleEntryResult, *Response, error) {
+	body, err := b.Bundle()
+	if err != nil {
+		return nil, nil, err
+	}
+	mediaType := b.client.MediaType()
+	req, err := b.client.newCDRRequest(http.MethodPost, "", body, append([]OptionFunc{
+		func(req *http.Request) error {
+			req.Header.Set("Content-Type", mediaType)
+			return nil
+		},
+	}, options...))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", mediaType)
+	var operationResponse bytes.Buffer
+	resp, err := b.client.do(req, &operationResponse)
+	if (err != nil && err != io.EOF) || resp == nil {
+		if resp == nil && err != nil {
+			err = fmt.Errorf("BundleBuilder.Post: %w", ErrEmptyResult)
+		}
+		return nil, resp, err
+	}
+	var response bundleResponse
+	if err := json.Unmarshal(operationResponse.Bytes(), &response); err != nil {
+		return nil, resp, fmt.Errorf("bundle response: %w", err)
+	}
+	if len(response.Entry) != len(b.entries) {
+		return nil, resp, fmt.Errorf("bundle response has %d entries, expected %d: %w", len(response.Entry), len(b.entries), ErrBundleEntryMismatch)
+	}
+	results := make([]BundleEntryResult, len(b.entries))
+	for i, entry := range response.Entry {
+		result := BundleEntryResult{
+			Method:       b.entries[i].Request.Method,
+			URL:          b.entries[i].Request.URL,
+			Status:       entry.Response.Status,
+			Location:     entry.Response.Location,
+			ETag:         entry.Response.Etag,
+			LastModified: entry.Response.LastModified,
+			Outcome:      entry.Response.Outcome,
+		}
+		result.StatusCode, result.Err = entryStatusCode(entry.Response.Status)
+		if result.Err == nil && result.StatusCode >= http.StatusBadRequest {
+			result.Err = fmt.Errorf("%s %s: %s", result.Method, result.URL, result.Status)
+		}
+		if len(entry.Resource) > 0 {
+			resource, err := b.client.Unmarshaller().Unmarshal(entry.Resource)
+			if err != nil && result.Err == nil {
+				result.Err = fmt.Errorf("FHIR unmarshal: %w", err)
+			}
+			result.Resource = resource
+		}
+		results[i] = result
+	}
+	return results, resp, nil
+}
+
+func resourceTypeAndID(jsonBody []byte) (string, string, error) {
+	var resource struct {
+		ResourceType string `json:"resourceType"`
+		ID           string `json:"id"`
+	}
+	if err := json.Unmarshal(jsonBody, &resource); err != nil {
+		return "", "", fmt.Errorf("resource: %w", err)
+	}
+	if resource.ResourceType == "" {
+		return "", "", ErrMissingResourceType
+	}
+	return resource.ResourceType, resource.ID, nil
+}
+
+func entryStatusCode(status string) (int, error) {
+	code, err := strconv.Atoi(strings.SplitN(strings.TrimSpace(status), " ", 2)[0])
+	if err != nil {
+		return 0, fmt.Errorf("entry status [%s]: %w", status, err)
+	}
+	return code, nil
+}
@@ -0,0 +1,128 @@
+package cdr_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/fhir/go/jsonformat"
+	r4pb "github.com/google/fhir/go/proto/google/fhir/proto/r4/core/resources/bundle_and_contained_resource_go_proto"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBundleBuilder(t *testing.T) {
+	teardown := setup(t, jsonformat.R4)
+	defer teardown()
+
+	r4Client, err := cdr.NewClient(iamClient, &cdr.Config{
+		CDRURL:      serverCDR.URL + "/store/fhir",
+		RootOrgID:   cdrOrgID,
+		FHIRVersion: cdr.FHIRVersionR4,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	orgID := "f5fe538f-c3b5-4454-8774-cd3789f59b9f"
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, http.MethodPost, r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		assert.Equal(t, "application/fhir+json;fhirVersion=4.0", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		var bundle struct {
+			ResourceType string `json:"resourceType"`
+			Type         string `json:"type"`
+			Entry        []struct {
+				FullURL string `json:"fullUrl"`
+				Request struct {
+					Method  string `json:"method"`
+					URL     string `json:"url"`
+					IfMatch string `json:"ifMatch"`
+				} `json:"request"`
+			} `json:"entry"`
+		}
+		if !assert.Nil(t, json.Unmarshal(body, &bundle)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		assert.Equal(t, "Bundle", bundle.ResourceType)
+		assert.Equal(t, cdr.BundleTypeBatch, bundle.Type)
+		if !assert.Len(t, bundle.Entry, 3) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		assert.Equal(t, "POST", bundle.Entry[0].Request.Method)
+		assert.Equal(t, "Organization", bundle.Entry[0].Request.URL)
+		assert.Equal(t, "urn:uuid:1", bundle.Entry[0].FullURL)
+		assert.Equal(t, "PUT", bundle.Entry[1].Request.Method)
+		assert.Equal(t, "Organization/"+orgID, bundle.Entry[1].Request.URL)
+		assert.Equal(t, `W/"2"`, bundle.Entry[1].Request.IfMatch)
+		assert.Equal(t, "DELETE", bundle.Entry[2].Request.Method)
+
+		w.Header().Set("Content-Type", "application/fhir+json;fhirVersion=4.0")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{
+  "resourceType": "Bundle",
+  "type": "batch-response",
+  "entry": [
+    {
+      "resource": {"resourceType": "Organization", "id": "new", "name": "Hospital1"},
+      "response": {"status": "201 Created", "location": "Organization/new/_history/1", "etag": "W/\"1\""}
+    },
+    {
+      "response": {"status": "200 OK", "etag": "W/\"3\""}
+    },
+    {
+      "response": {
+        "status": "404 Not Found",
+        "outcome": {"resourceType": "OperationOutcome", "issue": [{"severity": "error", "code": "not-found"}]}
+      }
+    }
+  ]
+}`)
+	})
+
+	_, err = r4Client.NewBundleBuilder("collection")
+	assert.ErrorIs(t, err, cdr.ErrInvalidBundleType)
+
+	builder, err := r4Client.NewBundleBuilder(cdr.BundleTypeBatch)
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, _, err = builder.Post()
+	assert.ErrorIs(t, err, cdr.ErrEmptyBundle)
+	assert.ErrorIs(t, builder.Create([]byte(`{"name":"NoType"}`), ""), cdr.ErrMissingResourceType)
+	assert.ErrorIs(t, builder.Update([]byte(`{"resourceType":"Organization"}`), ""), cdr.ErrMissingResourceID)
+
+	assert.Nil(t, builder.Create([]byte(`{"resourceType":"Organization","name":"Hospital1"}`), "urn:uuid:1"))
+	assert.Nil(t, builder.Update([]byte(`{"resourceType":"Organization","id":"`+orgID+`","name":"Hospital2"}`), "2"))
+	assert.Nil(t, builder.Delete("Organization/missing"))
+	assert.Equal(t, 3, builder.Len())
+
+	results, resp, err := builder.Post()
+	if !assert.Nil(t, err) {
+		return
+	}
+	if !assert.NotNil(t, resp) || !assert.Len(t, results, 3) {
+		return
+	}
+	assert.Equal(t, http.StatusCreated, results[0].StatusCode)
+	assert.Equal(t, "Organization/new/_history/1", results[0].Location)
+	assert.Nil(t, results[0].Err)
+	contained, ok := results[0].Resource.(*r4pb.ContainedResource)
+	if assert.True(t, ok) {
+		assert.Equal(t, "new", contained.GetOrganization().GetId().GetValue())
+	}
+	assert.Equal(t, http.StatusOK, results[1].StatusCode)
+	assert.Nil(t, results[1].Err)
+	assert.Nil(t, results[1].Resource)
+	assert.Equal(t, http.StatusNotFound, results[2].StatusCode)
+	assert.NotNil(t, results[2].Err)
+	assert.NotEmpty(t, results[2].Outcome)
+}
@@ -0,0 +1,128 @@
+package cdr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// CapabilityStatement describes the features of the FHIR store. Only the
+// parts needed for feature detection are decoded
+type CapabilityStatement struct {
+	ResourceType string           `json:"resourceType"`
+	FHIRVersion  string           `json:"fhirVersion"`
+	Format       []string         `json:"format"`
+	Rest         []CapabilityRest `json:"rest"`
+}
+
+// CapabilityRest describes the RESTful capabilities of a client or server
+type CapabilityRest struct {
+	Mode      string                `json:"mode"`
+	Resource  []CapabilityResource  `json:"resource"`
+	Operation []CapabilityOperation `json:"operation"`
+}
+
+// CapabilityResource describes the capabilities for a resource type
+type CapabilityResource struct {
+	Type        string `json:"type"`
+	Interaction []struct {
+		Code string `json:"code"`
+	} `json:"interaction"`
+	SearchParam []struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"searchParam"`
+	Operation []CapabilityOperation `json:"operation"`
+}
+
+// CapabilityOperation is an operation declared in a CapabilityStatement
+type CapabilityOperation struct {
+	Name       string          `json:"name"`
+	Definition json.RawMessage `json:"definition,omitempty"`
+}
+
+// GetCapabilityStatement retrieves the CapabilityStatement of the FHIR store (metadata)
+func (c *Client) GetCapabilityStatement(options ...OptionFunc) (*CapabilityStatement, *Response, error) {
+	req, err := c.newCDRRequest(http.MethodGet, "metadata", nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", c.MediaType())
+	var metadataResponse bytes.Buffer
+	resp, err := c.do(req, &metadataResponse)
+	if (err != nil && err != io.EOF) || resp == nil {
+		if resp == nil && err != nil {
+			err = fmt.Errorf("GetCapabilityStatement: %w", ErrEmptyResult)
+		}
+		return nil, resp, err
+	}
+	var statement CapabilityStatement
+	if err := json.Unmarshal(metadataResponse.Bytes(), &statement); err != nil {
+		return nil, resp, fmt.Errorf("capability statement: %w", err)
+	}
+	return &statement, resp, nil
+}
+
+// SupportsResource reports whether the server supports the resource type, e.g. "QuestionnaireResponse"
+func (s CapabilityStatement) SupportsResource(resourceType string) bool {
+	for _, rest := range s.server() {
+		for _, resource := range rest.Resource {
+			if resource.Type == resourceType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SupportsInteraction reports whether the server supports the interaction, e.g. "update", on the resource type
+func (s CapabilityStatement) SupportsInteraction(resourceType, interaction string) bool {
+	for _, rest := range s.server() {
+		for _, resource := range rest.Resource {
+			if resource.Type != resourceType {
+				continue
+			}
+			for _, i := range resource.Interaction {
+				if i.Code == interaction {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// SupportsOperation reports whether the server supports the operation, e.g. "$export",
+// either system wide or on any resource type. The leading $ is optional
+func (s CapabilityStatement) SupportsOperation(operation string) bool {
+	name := strings.TrimPrefix(operation, "$")
+	for _, rest := range s.server() {
+		for _, op := range rest.Operation {
+			if op.Name == name {
+				return true
+			}
+		}
+		for _, resource := range rest.Resource {
+			for _, op := range resource.Operation {
+				if op.Name == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// server returns the server mode capabilities
+func (s CapabilityStatement) server() []CapabilityRest {
+	var rests []CapabilityRest
+	for _, rest := range s.Rest {
+		if rest.Mode == "" || rest.Mode == "server" {
+			rests = append(rests, rest)
+		}
+	}
+	return rests
+}
@@ -0,0 +1,58 @@
+package cdr_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/fhir/go/jsonformat"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapabilityStatement(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/metadata", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, http.MethodGet, r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/fhir+json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{
+  "resourceType": "CapabilityStatement",
+  "fhirVersion": "3.0.2",
+  "format": ["application/fhir+json"],
+  "rest": [{
+    "mode": "server",
+    "resource": [
+      {
+        "type": "Patient",
+        "interaction": [{"code": "read"}, {"code": "update"}],
+        "operation": [{"name": "purge"}]
+      },
+      {"type": "Observation", "interaction": [{"code": "read"}]}
+    ],
+    "operation": [{"name": "export"}]
+  }, {
+    "mode": "client",
+    "resource": [{"type": "QuestionnaireResponse"}]
+  }]
+}`)
+	})
+
+	statement, resp, err := cdrClient.GetCapabilityStatement()
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) || !assert.NotNil(t, statement) {
+		return
+	}
+	assert.Equal(t, "3.0.2", statement.FHIRVersion)
+	assert.True(t, statement.SupportsResource("Patient"))
+	assert.False(t, statement.SupportsResource("QuestionnaireResponse"))
+	assert.True(t, statement.SupportsInteraction("Patient", "update"))
+	assert.False(t, statement.SupportsInteraction("Observation", "update"))
+	assert.True(t, statement.SupportsOperation("$export"))
+	assert.True(t, statement.SupportsOperation("purge"))
+	assert.False(t, statement.SupportsOperation("$validate"))
+}
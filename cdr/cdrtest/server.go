@@ -0,0 +1,309 @@
+// Package cdrtest provides an in-memory mock CDR FHIR store for testing code which
+// uses the cdr package, without needing a live CDR tenant.
+package cdrtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/philips-software/go-hsdp-api/cdr"
+	"github.com/philips-software/go-hsdp-api/iam/iamtest"
+)
+
+// Server is an httptest server mocking a CDR FHIR store, together with the mock IAM
+// server issuing its tokens. Resources are kept in memory and support create, read,
+// update, delete and search by _id. Additional handlers can be registered on Mux
+type Server struct {
+	CDR *httptest.Server
+	IAM *iamtest.Server
+	Mux *http.ServeMux
+
+	OrgID       string
+	FHIRVersion string
+
+	mu        sync.Mutex
+	resources map[string]map[string]storedResource
+}
+
+type storedResource struct {
+	versionID int
+	body      map[string]interface{}
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithOrganization sets the tenant organization of the store. Defaults to iamtest.DefaultOrgID
+func WithOrganization(orgID string) Option {
+	return func(s *Server) {
+		s.OrgID = orgID
+	}
+}
+
+// WithFHIRVersion sets the FHIR version of the store, cdr.FHIRVersionSTU3 or cdr.FHIRVersionR4
+func WithFHIRVersion(version string) Option {
+	return func(s *Server) {
+		s.FHIRVersion = version
+	}
+}
+
+// NewServer starts a mock CDR server. Call Close when done
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		Mux:         http.NewServeMux(),
+		OrgID:       iamtest.DefaultOrgID,
+		FHIRVersion: cdr.FHIRVersionSTU3,
+		resources:   make(map[string]map[string]storedResource),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.IAM = iamtest.NewServer(iamtest.WithOrganization(s.OrgID))
+	s.CDR = httptest.NewServer(s.Mux)
+	s.Mux.HandleFunc("/store/fhir/"+s.OrgID+"/", s.handleFHIR)
+	return s
+}
+
+// Close shuts down the servers
+func (s *Server) Close() {
+	s.CDR.Close()
+	s.IAM.Close()
+}
+
+// Config returns a client configuration pointing to the mock store
+func (s *Server) Config() *cdr.Config {
+	return &cdr.Config{
+		CDRURL:      s.CDR.URL + "/store/fhir",
+		RootOrgID:   s.OrgID,
+		FHIRVersion: s.FHIRVersion,
+	}
+}
+
+// NewClient returns a client of the mock store, logged in to the mock IAM server
+func (s *Server) NewClient() (*cdr.Client, error) {
+	iamClient, err := s.IAM.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	return cdr.NewClient(iamClient, s.Config())
+}
+
+// AddResource seeds the store with the JSON resource, which must have a resourceType and id
+func (s *Server) AddResource(jsonBody []byte) error {
+	var body map[string]interface{}
+	if err := json.Unmarshal(jsonBody, &body); err != nil {
+		return err
+	}
+	resourceType, _ := body["resourceType"].(string)
+	id, _ := body["id"].(string)
+	if resourceType == "" || id == "" {
+		return fmt.Errorf("cdrtest: resource needs a resourceType and id")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store(resourceType, id, body)
+	return nil
+}
+
+// Resource returns the stored JSON of the resource, if present
+func (s *Server) Resource(resourceType, id string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.resources[resourceType][id]
+	if !ok {
+		return nil, false
+	}
+	body, _ := json.Marshal(stored.body)
+	return body, true
+}
+
+// Count returns the number of stored resources of the given type
+func (s *Server) Count(resourceType string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.resources[resourceType])
+}
+
+func (s *Server) mediaType() string {
+	if s.FHIRVersion == cdr.FHIRVersionR4 {
+		return "application/fhir+json;fhirVersion=4.0"
+	}
+	return "application/fhir+json"
+}
+
+// store saves body as the next version of the resource, s.mu must be held
+func (s *Server) store(resourceType, id string, body map[string]interface{}) storedResource {
+	if s.resources[resourceType] == nil {
+		s.resources[resourceType] = make(map[string]storedResource)
+	}
+	versionID := s.resources[resourceType][id].versionID + 1
+	body["id"] = id
+	body["resourceType"] = resourceType
+	body["meta"] = map[string]interface{}{
+		"versionId":   strconv.Itoa(versionID),
+		"lastUpdated": time.Now().UTC().Format(time.RFC3339),
+	}
+	stored := storedResource{versionID: versionID, body: body}
+	s.resources[resourceType][id] = stored
+	return stored
+}
+
+func (s *Server) handleFHIR(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+s.IAM.AccessToken {
+		s.writeOutcome(w, http.StatusUnauthorized, "security", "invalid or missing token")
+		return
+	}
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/store/fhir/"+s.OrgID+"/"), "/")
+	parts := strings.Split(path, "/")
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		s.search(w, r, parts[0])
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		s.create(w, r, parts[0])
+	case len(parts) == 2 && r.Method == http.MethodGet:
+		s.read(w, parts[0], parts[1])
+	case len(parts) == 2 && r.Method == http.MethodPut:
+		s.update(w, r, parts[0], parts[1])
+	case len(parts) == 2 && r.Method == http.MethodDelete:
+		s.delete(w, parts[0], parts[1])
+	default:
+		s.writeOutcome(w, http.StatusNotImplemented, "not-supported", r.Method+" "+path+" is not supported by cdrtest")
+	}
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request, resourceType string) {
+	body, ok := s.readBody(w, r, resourceType)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	stored := s.store(resourceType, uuid.New().String(), body)
+	s.mu.Unlock()
+	s.writeResource(w, http.StatusCreated, resourceType, stored)
+}
+
+func (s *Server) read(w http.ResponseWriter, resourceType, id string) {
+	s.mu.Lock()
+	stored, ok := s.resources[resourceType][id]
+	s.mu.Unlock()
+	if !ok {
+		s.writeOutcome(w, http.StatusNotFound, "not-found", resourceType+"/"+id+" not found")
+		return
+	}
+	s.writeResource(w, http.StatusOK, resourceType, stored)
+}
+
+func (s *Server) update(w http.ResponseWriter, r *http.Request, resourceType, id string) {
+	body, ok := s.readBody(w, r, resourceType)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	current, exists := s.resources[resourceType][id]
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && (!exists || ifMatch != `W/"`+strconv.Itoa(current.versionID)+`"`) {
+		s.mu.Unlock()
+		s.writeOutcome(w, http.StatusPreconditionFailed, "conflict", "version conflict on "+resourceType+"/"+id)
+		return
+	}
+	stored := s.store(resourceType, id, body)
+	s.mu.Unlock()
+	status := http.StatusOK
+	if !exists {
+		status = http.StatusCreated
+	}
+	s.writeResource(w, status, resourceType, stored)
+}
+
+func (s *Server) delete(w http.ResponseWriter, resourceType, id string) {
+	s.mu.Lock()
+	delete(s.resources[resourceType], id)
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request, resourceType string) {
+	var ids map[string]bool
+	if value := r.URL.Query().Get("_id"); value != "" {
+		ids = make(map[string]bool)
+		for _, id := range strings.Split(value, ",") {
+			ids[id] = true
+		}
+	}
+	s.mu.Lock()
+	matches := make([]storedResource, 0)
+	for id, stored := range s.resources[resourceType] {
+		if ids == nil || ids[id] {
+			matches = append(matches, stored)
+		}
+	}
+	s.mu.Unlock()
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].body["id"].(string) < matches[j].body["id"].(string)
+	})
+	entries := make([]map[string]interface{}, 0, len(matches))
+	for _, stored := range matches {
+		entries = append(entries, map[string]interface{}{
+			"fullUrl":  s.CDR.URL + "/store/fhir/" + s.OrgID + "/" + resourceType + "/" + stored.body["id"].(string),
+			"resource": stored.body,
+			"search":   map[string]string{"mode": "match"},
+		})
+	}
+	s.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"resourceType": "Bundle",
+		"type":         "searchset",
+		"total":        len(entries),
+		"entry":        entries,
+	})
+}
+
+func (s *Server) readBody(w http.ResponseWriter, r *http.Request, resourceType string) (map[string]interface{}, bool) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.writeOutcome(w, http.StatusBadRequest, "structure", err.Error())
+		return nil, false
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		s.writeOutcome(w, http.StatusBadRequest, "structure", "invalid JSON: "+err.Error())
+		return nil, false
+	}
+	if body["resourceType"] != resourceType {
+		s.writeOutcome(w, http.StatusBadRequest, "invalid", "resourceType does not match "+resourceType)
+		return nil, false
+	}
+	return body, true
+}
+
+func (s *Server) writeResource(w http.ResponseWriter, status int, resourceType string, stored storedResource) {
+	id := stored.body["id"].(string)
+	version := strconv.Itoa(stored.versionID)
+	w.Header().Set("ETag", `W/"`+version+`"`)
+	if status == http.StatusCreated {
+		w.Header().Set("Location", s.CDR.URL+"/store/fhir/"+s.OrgID+"/"+resourceType+"/"+id+"/_history/"+version)
+	}
+	s.writeJSON(w, status, stored.body)
+}
+
+func (s *Server) writeOutcome(w http.ResponseWriter, status int, code, diagnostics string) {
+	s.writeJSON(w, status, map[string]interface{}{
+		"resourceType": "OperationOutcome",
+		"issue": []map[string]string{
+			{"severity": "error", "code": code, "diagnostics": diagnostics},
+		},
+	})
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", s.mediaType())
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
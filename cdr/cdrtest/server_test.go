@@ -0,0 +1,99 @@
+package cdrtest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	r4dt "github.com/google/fhir/go/proto/google/fhir/proto/r4/core/datatypes_go_proto"
+	r4patientpb "github.com/google/fhir/go/proto/google/fhir/proto/r4/core/resources/patient_go_proto"
+	"github.com/philips-software/go-hsdp-api/cdr"
+	"github.com/philips-software/go-hsdp-api/cdr/cdrtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer(t *testing.T) {
+	server := cdrtest.NewServer()
+	defer server.Close()
+
+	assert.Nil(t, server.AddResource([]byte(`{"resourceType":"Patient","id":"p1","active":true}`)))
+	assert.NotNil(t, server.AddResource([]byte(`{"resourceType":"Patient"}`)))
+
+	client, err := server.NewClient()
+	if !assert.Nil(t, err) {
+		return
+	}
+	contained, resp, err := client.OperationsSTU3.Get("Patient/p1")
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Equal(t, "p1", contained.GetPatient().GetId().GetValue())
+	assert.Equal(t, "1", resp.VersionID())
+
+	created, resp, err := client.OperationsRaw.Create("Patient", []byte(`{"resourceType":"Patient","active":false}`))
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	_, id, _ := cdrResourceID(created)
+	assert.NotEmpty(t, id)
+	assert.Equal(t, 2, server.Count("Patient"))
+
+	updated, _, err := client.OperationsRaw.Update("Patient/"+id, created)
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, _, err = client.OperationsRaw.Update("Patient/"+id, created)
+	assert.True(t, errors.Is(err, cdr.ErrConflict))
+	stored, ok := server.Resource("Patient", id)
+	assert.True(t, ok)
+	assert.JSONEq(t, string(updated), string(stored))
+
+	results, err := client.SearchIterator(context.Background(), "Patient", url.Values{"_id": {"p1"}}).All()
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, results, 1)
+
+	ok, _, err = client.OperationsRaw.Delete("Patient/" + id)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	_, resp, err = client.OperationsRaw.Get("Patient/" + id)
+	assert.NotNil(t, err)
+	if assert.NotNil(t, resp) {
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+}
+
+func TestServerR4(t *testing.T) {
+	server := cdrtest.NewServer(cdrtest.WithFHIRVersion(cdr.FHIRVersionR4), cdrtest.WithOrganization("dae89cf0-888d-4a26-8c1d-578e97365efc"))
+	defer server.Close()
+
+	client, err := server.NewClient()
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "dae89cf0-888d-4a26-8c1d-578e97365efc", client.RootOrgID())
+
+	created, _, err := cdr.Create(client, &r4patientpb.Patient{Active: &r4dt.Boolean{Value: true}})
+	if !assert.Nil(t, err) || !assert.NotNil(t, created) {
+		return
+	}
+	patient, _, err := cdr.Get[*r4patientpb.Patient](client, created.GetId().GetValue())
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.True(t, patient.GetActive().GetValue())
+}
+
+func cdrResourceID(body []byte) (string, string, error) {
+	var resource struct {
+		ResourceType string `json:"resourceType"`
+		ID           string `json:"id"`
+	}
+	err := json.Unmarshal(body, &resource)
+	return resource.ResourceType, resource.ID, err
+}
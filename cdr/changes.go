@@ -0,0 +1,144 @@
+package cdr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	ChangeCreated = "created"
+	ChangeUpdated = "updated"
+	ChangeDeleted = "deleted"
+)
+
+// ChangeEvent is a single change of a resource as recorded in the history of the store
+type ChangeEvent struct {
+	// Type is one of ChangeCreated, ChangeUpdated or ChangeDeleted
+	Type         string
+	ResourceType string
+	ID           string
+	VersionID    string
+	LastModified time.Time
+	// Resource is the resource version after the change and nil for deletions
+	Resource json.RawMessage
+}
+
+// ChangesSince returns the changes recorded since the given time, oldest first.
+// resourceType limits the changes to one type, when empty the system level history
+// is used. _since is inclusive, so consumers polling with the LastModified of the last
+// event they processed should skip events with an ID and VersionID they already saw
+func (c *Client) ChangesSince(ctx context.Context, since time.Time, resourceType string, options ...OptionFunc) ([]ChangeEvent, error) {
+	path := "_history"
+	if resourceType != "" {
+		path = resourceType + "/_history"
+	}
+	query := url.Values{}
+	if !since.IsZero() {
+		query.Set("_since", since.UTC().Format(time.RFC3339))
+	}
+	u := *c.fhirStoreURL
+	u.Opaque = c.fhirStoreURL.Path + c.config.RootOrgID + "/" + path
+	u.RawQuery = query.Encode()
+
+	var events []ChangeEvent
+	for next := &u; next != nil; {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		bundle, nextURL, _, err := c.fetchBundle(ctx, next, next != &u, c.MediaType(), options)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range bundle.Entry {
+			if event, ok := changeEvent(entry); ok {
+				events = append(events, event)
+			}
+		}
+		next = nextURL
+	}
+	// History is returned newest first
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LastModified.Before(events[j].LastModified)
+	})
+	return events, nil
+}
+
+func changeEvent(entry searchBundleEntry) (ChangeEvent, bool) {
+	var resource struct {
+		ResourceType string `json:"resourceType"`
+		ID           string `json:"id"`
+		Meta         struct {
+			VersionID   string `json:"versionId"`
+			LastUpdated string `json:"lastUpdated"`
+		} `json:"meta"`
+	}
+	event := ChangeEvent{}
+	if len(entry.Resource) > 0 {
+		if err := json.Unmarshal(entry.Resource, &resource); err != nil {
+			return event, false
+		}
+		event.Resource = entry.Resource
+		event.ResourceType = resource.ResourceType
+		event.ID = resource.ID
+		event.VersionID = resource.Meta.VersionID
+		event.LastModified, _ = time.Parse(time.RFC3339, resource.Meta.LastUpdated)
+	}
+	method := ""
+	if entry.Request != nil {
+		method = entry.Request.Method
+	}
+	for _, reference := range []string{entry.FullURL, requestURL(entry)} {
+		if event.ID != "" {
+			break
+		}
+		event.ResourceType, event.ID = splitReference(reference)
+	}
+	if entry.Response != nil {
+		if event.VersionID == "" {
+			event.VersionID = strings.TrimSuffix(strings.TrimPrefix(entry.Response.ETag, `W/"`), `"`)
+		}
+		if lastModified, err := http.ParseTime(entry.Response.LastModified); err == nil {
+			event.LastModified = lastModified
+		} else if lastModified, err := time.Parse(time.RFC3339, entry.Response.LastModified); err == nil {
+			event.LastModified = lastModified
+		}
+	}
+	switch {
+	case method == http.MethodDelete || (method == "" && event.Resource == nil):
+		event.Type = ChangeDeleted
+		event.Resource = nil
+	case method == http.MethodPost || event.VersionID == "1":
+		event.Type = ChangeCreated
+	default:
+		event.Type = ChangeUpdated
+	}
+	return event, event.ID != ""
+}
+
+func requestURL(entry searchBundleEntry) string {
+	if entry.Request == nil {
+		return ""
+	}
+	return entry.Request.URL
+}
+
+// splitReference splits "Patient/123" or an absolute URL of a resource, possibly
+// including a _history suffix, into its type and id
+func splitReference(reference string) (string, string) {
+	if i := strings.Index(reference, "/_history"); i >= 0 {
+		reference = reference[:i]
+	}
+	parts := strings.Split(strings.Trim(reference, "/"), "/")
+	if len(parts) < 2 {
+		return "", ""
+	}
+	return parts[len(parts)-2], parts[len(parts)-1]
+}
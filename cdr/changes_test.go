@@ -0,0 +1,69 @@
+package cdr_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/fhir/go/jsonformat"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangesSince(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	historyURL := serverCDR.URL + "/store/fhir/" + cdrOrgID + "/Patient/_history"
+	since := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient/_history", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, http.MethodGet, r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		assert.Equal(t, "2022-01-01T00:00:00Z", r.URL.Query().Get("_since"))
+		w.Header().Set("Content-Type", "application/fhir+json")
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = io.WriteString(w, `{"resourceType":"Bundle","type":"history","entry":[
+  {"fullUrl":"`+serverCDR.URL+`/store/fhir/`+cdrOrgID+`/Patient/1","resource":{"resourceType":"Patient","id":"1","meta":{"versionId":"1","lastUpdated":"2022-01-02T10:00:00Z"}},
+   "request":{"method":"POST","url":"Patient"},"response":{"status":"201 Created"}}
+]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"resourceType":"Bundle","type":"history",
+  "link":[{"relation":"next","url":"`+historyURL+`?_since=2022-01-01T00:00:00Z&page=2"}],
+  "entry":[
+  {"request":{"method":"DELETE","url":"Patient/2"},"response":{"status":"204","etag":"W/\"3\"","lastModified":"2022-01-02T12:00:00Z"}},
+  {"resource":{"resourceType":"Patient","id":"1","meta":{"versionId":"2","lastUpdated":"2022-01-02T11:00:00Z"}},
+   "request":{"method":"PUT","url":"Patient/1"},"response":{"status":"200 OK"}}
+]}`)
+	})
+
+	events, err := cdrClient.ChangesSince(context.Background(), since, "Patient")
+	if !assert.Nil(t, err) || !assert.Len(t, events, 3) {
+		return
+	}
+	assert.Equal(t, cdr.ChangeCreated, events[0].Type)
+	assert.Equal(t, "1", events[0].ID)
+	assert.Equal(t, "1", events[0].VersionID)
+	assert.NotNil(t, events[0].Resource)
+	assert.Equal(t, cdr.ChangeUpdated, events[1].Type)
+	assert.Equal(t, "2", events[1].VersionID)
+	assert.Equal(t, cdr.ChangeDeleted, events[2].Type)
+	assert.Equal(t, "Patient", events[2].ResourceType)
+	assert.Equal(t, "2", events[2].ID)
+	assert.Equal(t, "3", events[2].VersionID)
+	assert.Nil(t, events[2].Resource)
+	assert.Equal(t, time.Date(2022, 1, 2, 12, 0, 0, 0, time.UTC), events[2].LastModified.UTC())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = cdrClient.ChangesSince(ctx, since, "Patient")
+	assert.Equal(t, context.Canceled, err)
+}
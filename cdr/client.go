@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/philips-software/go-hsdp-api/internal"
 
@@ -22,9 +23,88 @@ const (
 	APIVersion = "1"
 )
 
+// FHIR versions known to FHIRVersion of Config. FHIRVersionR4B is reserved,
+// NewClient rejects it with ErrUnsupportedFHIRVersion until jsonformat supports it
+const (
+	FHIRVersionSTU3 = "STU3"
+	FHIRVersionR4   = "R4"
+	FHIRVersionR4B  = "R4B"
+)
+
 // OptionFunc is the function signature function for options
 type OptionFunc func(*http.Request) error
 
+// WithIfMatch makes the request conditional on the current version of the resource
+// so concurrent modifications are detected, e.g. on Delete, see DeleteVersion. versionID is
+// the meta.versionId of the resource. Updates of resources with a meta.versionId get this
+// header automatically
+func WithIfMatch(versionID string) OptionFunc {
+	return func(req *http.Request) error {
+		if versionID == "" {
+			return ErrMissingVersionID
+		}
+		req.Header.Set("If-Match", `W/"`+versionID+`"`)
+		return nil
+	}
+}
+
+// WithoutIfMatch removes the If-Match header, disabling the optimistic concurrency
+// check updates get automatically when the resource carries a meta.versionId
+func WithoutIfMatch() OptionFunc {
+	return func(req *http.Request) error {
+		req.Header.Del("If-Match")
+		return nil
+	}
+}
+
+// Preferences of the Prefer request header
+const (
+	PreferReturnMinimal          = "return=minimal"
+	PreferReturnRepresentation   = "return=representation"
+	PreferReturnOperationOutcome = "return=OperationOutcome"
+	PreferHandlingStrict         = "handling=strict"
+	PreferHandlingLenient        = "handling=lenient"
+)
+
+// WithPrefer adds preferences to the Prefer header of the request, e.g. PreferReturnMinimal
+// to skip the response body of creates and updates
+func WithPrefer(preferences ...string) OptionFunc {
+	return func(req *http.Request) error {
+		values := make([]string, 0, len(preferences)+1)
+		if current := req.Header.Get("Prefer"); current != "" {
+			values = append(values, current)
+		}
+		values = append(values, preferences...)
+		req.Header.Set("Prefer", strings.Join(values, ", "))
+		return nil
+	}
+}
+
+// WithIfNoneExist turns a create into a conditional create: the resource is only
+// created when no resource matches the search criteria, e.g. identifier=system|value
+func WithIfNoneExist(criteria url.Values) OptionFunc {
+	return func(req *http.Request) error {
+		if len(criteria) == 0 {
+			return ErrMissingSearchCriteria
+		}
+		req.Header.Set("If-None-Exist", criteria.Encode())
+		return nil
+	}
+}
+
+// WithConditionalUpdate turns an update into a conditional update: the resource
+// matching the search criteria is updated, or created when there is no match.
+// The resourceID of the update should then be the resource type only, e.g. "Patient"
+func WithConditionalUpdate(criteria url.Values) OptionFunc {
+	return func(req *http.Request) error {
+		if len(criteria) == 0 {
+			return ErrMissingSearchCriteria
+		}
+		req.URL.RawQuery = criteria.Encode()
+		return nil
+	}
+}
+
 // Config contains the configuration of a client
 type Config struct {
 	Region      string
@@ -36,6 +116,20 @@ type Config struct {
 	Type      string
 	TimeZone  string
 	DebugLog  string
+	// FHIRVersion selects the FHIR version of the store, FHIRVersionSTU3 or
+	// FHIRVersionR4. Defaults to STU3. FHIRVersionR4B is not supported yet and
+	// makes NewClient fail with ErrUnsupportedFHIRVersion
+	FHIRVersion string
+	// Retry is the number of times idempotent requests are retried when CDR
+	// throttles with a 429 or 503 response. Retries are disabled when zero
+	Retry int
+	// RetryMaxDelay caps the delay between retries, including Retry-After. Defaults to 30 seconds
+	RetryMaxDelay time.Duration
+	// Compression gzips request bodies of at least CompressionMinSize bytes
+	// and asks CDR for gzip compressed responses
+	Compression bool
+	// CompressionMinSize defaults to 1024 bytes
+	CompressionMinSize int
 }
 
 // A Client manages communication with HSDP CDR API
@@ -47,14 +141,24 @@ type Client struct {
 
 	fhirStoreURL *url.URL
 
+	fhirVersion string
+	ma          *jsonformat.Marshaller
+	um          *jsonformat.Unmarshaller
+
 	// User agent used when communicating with the HSDP CDR API
 	UserAgent string
 
 	TenantSTU3     *TenantSTU3Service
 	OperationsSTU3 *OperationsSTU3Service
 
-	TenantR4     *TenantR4Service
-	OperationsR4 *OperationsR4Service
+	TenantR4        *TenantR4Service
+	OperationsR4    *OperationsR4Service
+	PatientsR4      *PatientsR4Service
+	PractitionersR4 *PractitionersR4Service
+
+	OperationsRaw    *OperationsRawService
+	Binary           *BinaryService
+	SearchParameters *SearchParametersService
 }
 
 // NewClient returns a new HSDP CDR API client. Configured console and IAM clients
@@ -65,6 +169,14 @@ func NewClient(iamClient *iam.Client, config *Config) (*Client, error) {
 
 func newClient(iamClient *iam.Client, config *Config) (*Client, error) {
 	c := &Client{iamClient: iamClient, config: config, UserAgent: userAgent}
+	switch config.FHIRVersion {
+	case "", FHIRVersionSTU3:
+		c.fhirVersion = FHIRVersionSTU3
+	case FHIRVersionR4:
+		c.fhirVersion = FHIRVersionR4
+	default: // R4B is not supported by jsonformat yet
+		return nil, fmt.Errorf("cdr.NewClient FHIR version [%s]: %w", config.FHIRVersion, ErrUnsupportedFHIRVersion)
+	}
 	fhirStore := config.FHIRStore
 	if fhirStore == "" {
 		fhirStore = config.CDRURL
@@ -82,17 +194,26 @@ func newClient(iamClient *iam.Client, config *Config) (*Client, error) {
 	}
 	maR4, err := jsonformat.NewMarshaller(false, "", "", jsonformat.R4)
 	if err != nil {
-		return nil, fmt.Errorf("cdr.NewClient create FHIR STU3 marshaller: %w", err)
+		return nil, fmt.Errorf("cdr.NewClient create FHIR R4 marshaller: %w", err)
 	}
 	umR4, err := jsonformat.NewUnmarshaller(config.TimeZone, jsonformat.R4)
 	if err != nil {
-		return nil, fmt.Errorf("cdr.NewClient create FHIR STU3 unmarshaller (timezone=[%s]): %w", config.TimeZone, err)
+		return nil, fmt.Errorf("cdr.NewClient create FHIR R4 unmarshaller (timezone=[%s]): %w", config.TimeZone, err)
+	}
+	c.ma, c.um = maSTU3, umSTU3
+	if c.fhirVersion == FHIRVersionR4 {
+		c.ma, c.um = maR4, umR4
 	}
 
 	c.TenantSTU3 = &TenantSTU3Service{timeZone: config.TimeZone, client: c, ma: maSTU3, um: umSTU3}
 	c.OperationsSTU3 = &OperationsSTU3Service{timeZone: config.TimeZone, client: c, ma: maSTU3, um: umSTU3}
 	c.TenantR4 = &TenantR4Service{timeZone: config.TimeZone, client: c, ma: maR4, um: umR4}
 	c.OperationsR4 = &OperationsR4Service{timeZone: config.TimeZone, client: c, ma: maR4, um: umR4}
+	c.PatientsR4 = &PatientsR4Service{timeZone: config.TimeZone, client: c, ma: maR4, um: umR4}
+	c.PractitionersR4 = &PractitionersR4Service{timeZone: config.TimeZone, client: c, ma: maR4, um: umR4}
+	c.OperationsRaw = &OperationsRawService{client: c}
+	c.Binary = &BinaryService{client: c}
+	c.SearchParameters = &SearchParametersService{client: c}
 
 	return c, nil
 }
@@ -101,6 +222,75 @@ func newClient(iamClient *iam.Client, config *Config) (*Client, error) {
 func (c *Client) Close() {
 }
 
+// ForOrganization returns a client operating on the FHIR endpoint of another root
+// organization. The returned client shares the IAM client, FHIR store URL and the
+// FHIR marshallers, so it is cheap to create per request in multi-tenant backends
+func (c *Client) ForOrganization(rootOrgID string) *Client {
+	config := *c.config
+	config.RootOrgID = rootOrgID
+	sub := *c
+	sub.config = &config
+
+	tenantSTU3 := *c.TenantSTU3
+	tenantSTU3.client = &sub
+	sub.TenantSTU3 = &tenantSTU3
+	operationsSTU3 := *c.OperationsSTU3
+	operationsSTU3.client = &sub
+	sub.OperationsSTU3 = &operationsSTU3
+	tenantR4 := *c.TenantR4
+	tenantR4.client = &sub
+	sub.TenantR4 = &tenantR4
+	operationsR4 := *c.OperationsR4
+	operationsR4.client = &sub
+	sub.OperationsR4 = &operationsR4
+	patientsR4 := *c.PatientsR4
+	patientsR4.client = &sub
+	sub.PatientsR4 = &patientsR4
+	practitionersR4 := *c.PractitionersR4
+	practitionersR4.client = &sub
+	sub.PractitionersR4 = &practitionersR4
+	sub.OperationsRaw = &OperationsRawService{client: &sub}
+	sub.Binary = &BinaryService{client: &sub}
+	sub.SearchParameters = &SearchParametersService{client: &sub}
+	return &sub
+}
+
+// RootOrgID returns the root organization the client operates on
+func (c *Client) RootOrgID() string {
+	return c.config.RootOrgID
+}
+
+// FHIRVersion returns the FHIR version the client is configured for
+func (c *Client) FHIRVersion() string {
+	return c.fhirVersion
+}
+
+// Marshaller returns the FHIR JSON marshaller of the configured FHIR version
+func (c *Client) Marshaller() *jsonformat.Marshaller {
+	return c.ma
+}
+
+// Unmarshaller returns the FHIR JSON unmarshaller of the configured FHIR version
+func (c *Client) Unmarshaller() *jsonformat.Unmarshaller {
+	return c.um
+}
+
+// MediaType returns the FHIR JSON media type of the configured FHIR version
+// to use in Accept and Content-Type headers
+func (c *Client) MediaType() string {
+	return mediaType(c.fhirVersion)
+}
+
+// r4MediaType is the FHIR JSON media type of FHIR R4
+const r4MediaType = "application/fhir+json;fhirVersion=4.0"
+
+func mediaType(fhirVersion string) string {
+	if fhirVersion == FHIRVersionR4 {
+		return r4MediaType
+	}
+	return "application/fhir+json"
+}
+
 // GetFHIRStoreURL returns the base FHIR Store base URL as configured
 func (c *Client) GetFHIRStoreURL() string {
 	if c.fhirStoreURL == nil {
@@ -175,9 +365,30 @@ func (c *Client) newCDRRequest(method, path string, bodyBytes []byte, options []
 	}
 
 	if (method == "POST" || method == "PUT" || method == "PATCH") && bodyBytes != nil {
-		bodyReader := bytes.NewReader(bodyBytes)
+		sendBytes := bodyBytes
+		if c.config.Compression && len(bodyBytes) >= c.compressionMinSize() {
+			compressed, err := gzipBytes(bodyBytes)
+			if err != nil {
+				return nil, err
+			}
+			sendBytes = compressed
+			req.Header.Set("Content-Encoding", "gzip")
+		}
+		bodyReader := bytes.NewReader(sendBytes)
 		req.Body = io.NopCloser(bodyReader)
 		req.ContentLength = int64(bodyReader.Len())
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(sendBytes)), nil
+		}
+	}
+	if c.config.Compression {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	// Updates only succeed when the resource was not modified in the meantime
+	if method == "PUT" {
+		if versionID := versionIDFromBody(bodyBytes); versionID != "" {
+			req.Header.Set("If-Match", `W/"`+versionID+`"`)
+		}
 	}
 	token, err := c.iamClient.Token()
 	if err != nil {
@@ -201,12 +412,41 @@ func (c *Client) newCDRRequest(method, path string, bodyBytes []byte, options []
 	return req, nil
 }
 
+func versionIDFromBody(bodyBytes []byte) string {
+	if len(bodyBytes) == 0 {
+		return ""
+	}
+	var resource struct {
+		Meta struct {
+			VersionID string `json:"versionId"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(bodyBytes, &resource); err != nil {
+		return ""
+	}
+	return resource.Meta.VersionID
+}
+
 // Response is a HSDP IAM API response. This wraps the standard http.Response
 // returned from HSDP IAM and provides convenient access to things like errors
 type Response struct {
 	*http.Response
 }
 
+// ETag returns the ETag header of the response, e.g. W/"2"
+func (r *Response) ETag() string {
+	if r == nil || r.Response == nil {
+		return ""
+	}
+	return r.Header.Get("ETag")
+}
+
+// VersionID returns the resource version of the ETag header of the response
+func (r *Response) VersionID() string {
+	etag := strings.TrimPrefix(r.ETag(), "W/")
+	return strings.Trim(etag, `"`)
+}
+
 // newResponse creates a new Response for the provided http.Response.
 func newResponse(r *http.Response) *Response {
 	response := &Response{Response: r}
@@ -229,14 +469,18 @@ func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
 		return nil, ErrMissingAcceptHeader
 	}
 
-	resp, err := c.iamClient.HttpClient().Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, err
 	}
 
+	decompressResponse(resp)
 	response := newResponse(resp)
 
 	err = internal.CheckResponse(resp)
+	if err != nil && resp.StatusCode == http.StatusPreconditionFailed {
+		err = fmt.Errorf("%w: %v", ErrConflict, err)
+	}
 	if err != nil {
 		// even though there was an error, we still return the response
 		// in case the caller wants to inspect it further
@@ -256,3 +500,17 @@ func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
 
 	return response, err
 }
+
+// send executes the request, retrying idempotent requests which CDR throttled
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if !internal.IsIdempotent(req) {
+		return c.iamClient.HttpClient().Do(req)
+	}
+	return internal.DoWithRetry(c.iamClient.HttpClient(), req, c.config.Retry, c.config.RetryMaxDelay, func(resp *http.Response) bool {
+		return isThrottled(resp.StatusCode)
+	})
+}
+
+func isThrottled(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
@@ -1,10 +1,13 @@
 package cdr_test
 
 import (
+	"compress/gzip"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/google/fhir/go/jsonformat"
@@ -229,3 +232,189 @@ func TestEndpoints(t *testing.T) {
 	assert.Equal(t, serverCDR.URL+"/store/fhir/"+rootOrgID, cdrClient.GetEndpointURL())
 
 }
+
+func TestFHIRVersion(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	assert.Equal(t, cdr.FHIRVersionSTU3, cdrClient.FHIRVersion())
+	assert.Equal(t, "application/fhir+json", cdrClient.MediaType())
+
+	r4Client, err := cdr.NewClient(iamClient, &cdr.Config{
+		CDRURL:      serverCDR.URL + "/store/fhir",
+		RootOrgID:   cdrOrgID,
+		FHIRVersion: cdr.FHIRVersionR4,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, cdr.FHIRVersionR4, r4Client.FHIRVersion())
+	assert.Equal(t, "application/fhir+json;fhirVersion=4.0", r4Client.MediaType())
+	if !assert.NotNil(t, r4Client.Unmarshaller()) {
+		return
+	}
+	res, err := r4Client.Unmarshaller().Unmarshal([]byte(`{"resourceType":"Organization","id":"foo","name":"Bar"}`))
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = r4Client.Marshaller().Marshal(res)
+	assert.Nil(t, err)
+
+	for _, version := range []string{cdr.FHIRVersionR4B, "DSTU2"} {
+		_, err = cdr.NewClient(iamClient, &cdr.Config{
+			CDRURL:      serverCDR.URL + "/store/fhir",
+			FHIRVersion: version,
+		})
+		assert.ErrorIs(t, err, cdr.ErrUnsupportedFHIRVersion)
+	}
+}
+
+func TestForOrganization(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	otherOrgID := "2d6c1a9e-3b1f-4f7e-8a2c-6d5e4f3a2b1c"
+	muxCDR.HandleFunc("/store/fhir/"+otherOrgID+"/Patient/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/fhir+json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"resourceType":"Patient","id":"123"}`)
+	})
+
+	other := cdrClient.ForOrganization(otherOrgID)
+	assert.Equal(t, otherOrgID, other.RootOrgID())
+	assert.Equal(t, cdrOrgID, cdrClient.RootOrgID())
+	assert.Equal(t, serverCDR.URL+"/store/fhir/"+otherOrgID, other.GetEndpointURL())
+	assert.Equal(t, serverCDR.URL+"/store/fhir/"+cdrOrgID, cdrClient.GetEndpointURL())
+
+	patient, _, err := other.OperationsSTU3.Get("Patient/123")
+	if !assert.Nil(t, err) || !assert.NotNil(t, patient) {
+		return
+	}
+	assert.Equal(t, "123", patient.GetPatient().GetId().GetValue())
+	_, _, err = other.OperationsRaw.Get("Patient/123")
+	assert.Nil(t, err)
+	_, _, err = cdrClient.OperationsRaw.Get("Patient/123")
+	assert.NotNil(t, err)
+}
+
+func TestRetryThrottled(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	var gets, posts, puts int32
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/fhir+json")
+		switch r.Method {
+		case http.MethodGet:
+			if atomic.AddInt32(&gets, 1) < 3 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, `{"resourceType":"Patient","id":"123"}`, string(body))
+			if atomic.AddInt32(&puts, 1) < 2 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+		case http.MethodPost:
+			atomic.AddInt32(&posts, 1)
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"resourceType":"Patient","id":"123"}`)
+	})
+
+	retryClient, err := cdr.NewClient(iamClient, &cdr.Config{
+		CDRURL:    serverCDR.URL + "/store/fhir",
+		RootOrgID: cdrOrgID,
+		Retry:     3,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, resp, err := retryClient.OperationsRaw.Get("Patient/123")
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&gets))
+
+	_, _, err = retryClient.OperationsRaw.Update("Patient/123", []byte(`{"resourceType":"Patient","id":"123"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&puts))
+
+	// POST is not idempotent so it is never retried
+	_, resp, err = retryClient.OperationsRaw.Create("Patient/123", []byte(`{"resourceType":"Patient"}`))
+	assert.NotNil(t, err)
+	if assert.NotNil(t, resp) {
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&posts))
+
+	atomic.StoreInt32(&gets, 0)
+	_, _, err = cdrClient.OperationsRaw.Get("Patient/123")
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&gets))
+}
+
+func TestCompression(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	gzClient, err := cdr.NewClient(iamClient, &cdr.Config{
+		CDRURL:             serverCDR.URL + "/store/fhir",
+		RootOrgID:          cdrOrgID,
+		Compression:        true,
+		CompressionMinSize: 64,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	patient := `{"resourceType":"Patient","id":"123","name":[{"text":"` + strings.Repeat("a", 100) + `"}]}`
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+		body := r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if !assert.Nil(t, err) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			body = zr
+		}
+		received, _ := io.ReadAll(body)
+		assert.Equal(t, len(received) >= 64, r.Header.Get("Content-Encoding") == "gzip")
+		w.Header().Set("Content-Type", "application/fhir+json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusCreated)
+		zw := gzip.NewWriter(w)
+		_, _ = zw.Write(received)
+		_ = zw.Close()
+	})
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	created, _, err := gzClient.OperationsRaw.Create("Patient", []byte(patient))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.JSONEq(t, patient, string(created))
+
+	small := `{"resourceType":"Patient"}`
+	created, _, err = gzClient.OperationsRaw.Create("Patient", []byte(small))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.JSONEq(t, small, string(created))
+
+	ok, _, err := gzClient.OperationsRaw.Delete("Patient/123")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
@@ -0,0 +1,66 @@
+package cdr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Compartment types which can be searched
+const (
+	CompartmentPatient       = "Patient"
+	CompartmentEncounter     = "Encounter"
+	CompartmentRelatedPerson = "RelatedPerson"
+	CompartmentPractitioner  = "Practitioner"
+	CompartmentDevice        = "Device"
+)
+
+// CompartmentIterator returns an iterator over the resources of resourceType in the
+// compartment, e.g. all Observations of Patient/123. An empty resourceType searches
+// all resource types of the compartment. opts is optional
+func (c *Client) CompartmentIterator(ctx context.Context, compartment, id, resourceType string, opts *SearchOptions, options ...OptionFunc) *SearchIterator {
+	path, query, err := compartmentSearch(compartment, id, resourceType, opts)
+	it := c.bundleIterator(ctx, path, query, options...)
+	if err != nil {
+		it.err = err
+	}
+	return it
+}
+
+// SearchCompartment returns the first search result bundle of resourceType in the compartment
+func (o *OperationsRawService) SearchCompartment(compartment, id, resourceType string, opts *SearchOptions, options ...OptionFunc) (json.RawMessage, *Response, error) {
+	path, query, err := compartmentSearch(compartment, id, resourceType, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return o.do(http.MethodGet, path, nil, append([]OptionFunc{
+		func(req *http.Request) error {
+			req.URL.RawQuery = query.Encode()
+			return nil
+		},
+	}, options...)...)
+}
+
+func compartmentSearch(compartment, id, resourceType string, opts *SearchOptions) (string, url.Values, error) {
+	switch compartment {
+	case CompartmentPatient, CompartmentEncounter, CompartmentRelatedPerson, CompartmentPractitioner, CompartmentDevice:
+	default:
+		return "", nil, fmt.Errorf("compartment [%s]: %w", compartment, ErrInvalidCompartment)
+	}
+	if id == "" {
+		return "", nil, ErrMissingResourceID
+	}
+	if resourceType == "" {
+		resourceType = "*"
+	}
+	query := url.Values{}
+	if opts != nil {
+		var err error
+		if query, err = opts.Values(); err != nil {
+			return "", nil, err
+		}
+	}
+	return compartment + "/" + id + "/" + resourceType, query, nil
+}
@@ -0,0 +1,59 @@
+package cdr_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/fhir/go/jsonformat"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompartmentSearch(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient/123/Observation", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "8867-4", r.URL.Query().Get("code"))
+		assert.Equal(t, "10", r.URL.Query().Get("_count"))
+		w.Header().Set("Content-Type", "application/fhir+json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"resourceType":"Bundle","type":"searchset","total":2,"entry":[
+  {"resource":{"resourceType":"Observation","id":"1","status":"final","code":{"text":"heart rate"}}},
+  {"resource":{"resourceType":"Observation","id":"2","status":"final","code":{"text":"heart rate"}}}
+]}`)
+	})
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient/123/*", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/fhir+json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"resourceType":"Bundle","type":"searchset","total":5}`)
+	})
+
+	opts := &cdr.SearchOptions{Count: 10, Params: url.Values{"code": []string{"8867-4"}}}
+	resources, err := cdrClient.CompartmentIterator(context.Background(), cdr.CompartmentPatient, "123", "Observation", opts).All()
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, resources, 2)
+
+	bundle, _, err := cdrClient.OperationsRaw.SearchCompartment(cdr.CompartmentPatient, "123", "", nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	var result struct {
+		Total int `json:"total"`
+	}
+	assert.Nil(t, json.Unmarshal(bundle, &result))
+	assert.Equal(t, 5, result.Total)
+
+	_, err = cdrClient.CompartmentIterator(context.Background(), "Organization", "123", "Observation", nil).All()
+	assert.ErrorIs(t, err, cdr.ErrInvalidCompartment)
+	_, _, err = cdrClient.OperationsRaw.SearchCompartment(cdr.CompartmentPatient, "", "Observation", nil)
+	assert.ErrorIs(t, err, cdr.ErrMissingResourceID)
+}
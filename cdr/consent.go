@@ -0,0 +1,386 @@
+package cdr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	ConsentScopePatientPrivacy = "patient-privacy"
+	ConsentScopeResearch       = "research"
+	ConsentScopeTreatment      = "treatment"
+	ConsentScopeADR            = "adr"
+
+	ConsentActionAccess   = "access"
+	ConsentActionCollect  = "collect"
+	ConsentActionUse      = "use"
+	ConsentActionDisclose = "disclose"
+	ConsentActionCorrect  = "correct"
+
+	ConsentProvisionPermit = "permit"
+	ConsentProvisionDeny   = "deny"
+
+	ConsentDecisionPermit = "permit"
+	ConsentDecisionDeny   = "deny"
+	// ConsentDecisionNotApplicable is returned when the consent is not active or does
+	// not cover the request
+	ConsentDecisionNotApplicable = "not-applicable"
+
+	consentScopeSystem       = "http://terminology.hl7.org/CodeSystem/consentscope"
+	consentActionSystem      = "http://terminology.hl7.org/CodeSystem/consentaction"
+	participationTypeSystem  = "http://terminology.hl7.org/CodeSystem/v3-ParticipationType"
+	resourceTypesSystem      = "http://hl7.org/fhir/resource-types"
+	purposeOfUseSystem       = "http://terminology.hl7.org/CodeSystem/v3-ActReason"
+	defaultConsentActorRole  = "PRCP"
+	patientConsentLOINCCode  = "59284-0"
+	patientConsentLOINCTitle = "Patient Consent"
+)
+
+// CodeableConcept is a FHIR CodeableConcept
+type CodeableConcept struct {
+	Coding []Coding `json:"coding,omitempty"`
+	Text   string   `json:"text,omitempty"`
+}
+
+// Reference is a FHIR Reference
+type Reference struct {
+	Reference string `json:"reference,omitempty"`
+	Display   string `json:"display,omitempty"`
+}
+
+// Period is a FHIR Period of two dateTime values
+type Period struct {
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+// Consent is an R4 Consent resource
+type Consent struct {
+	ResourceType string            `json:"resourceType"`
+	ID           string            `json:"id,omitempty"`
+	Status       string            `json:"status"`
+	Scope        CodeableConcept   `json:"scope"`
+	Category     []CodeableConcept `json:"category"`
+	Patient      *Reference        `json:"patient,omitempty"`
+	DateTime     string            `json:"dateTime,omitempty"`
+	PolicyRule   *CodeableConcept  `json:"policyRule,omitempty"`
+	Provision    *ConsentProvision `json:"provision,omitempty"`
+}
+
+// ConsentProvision is a permit or deny rule of a Consent. Nested provisions are
+// exceptions to their parent
+type ConsentProvision struct {
+	Type      string             `json:"type,omitempty"`
+	Period    *Period            `json:"period,omitempty"`
+	Actor     []ConsentActor     `json:"actor,omitempty"`
+	Action    []CodeableConcept  `json:"action,omitempty"`
+	Purpose   []Coding           `json:"purpose,omitempty"`
+	Class     []Coding           `json:"class,omitempty"`
+	Provision []ConsentProvision `json:"provision,omitempty"`
+}
+
+// ConsentActor is who a provision applies to
+type ConsentActor struct {
+	Role      CodeableConcept `json:"role"`
+	Reference Reference       `json:"reference"`
+}
+
+// ConsentRule describes an exception to the base rule of a consent.
+// Empty fields match any value
+type ConsentRule struct {
+	// Actors are references, e.g. "Practitioner/123"
+	Actors []string
+	// Role of the actors, defaults to PRCP (primary information recipient)
+	Role string
+	// ResourceTypes limits the data classes, e.g. "Observation"
+	ResourceTypes []string
+	Actions       []string
+	// Purposes are v3 ActReason codes, e.g. "TREAT"
+	Purposes []string
+	Start    time.Time
+	End      time.Time
+}
+
+// ConsentRequest is an access to evaluate against a Consent
+type ConsentRequest struct {
+	Actor        string
+	ResourceType string
+	Action       string
+	Purpose      string
+	// At defaults to now
+	At time.Time
+}
+
+// ConsentBuilder builds an R4 Consent resource
+type ConsentBuilder struct {
+	consent Consent
+}
+
+// NewConsentBuilder returns a builder for an active consent of the patient, e.g. "Patient/123",
+// with the given scope. The base rule denies everything until changed with PermitByDefault
+func NewConsentBuilder(patient, scope string) *ConsentBuilder {
+	return &ConsentBuilder{consent: Consent{
+		ResourceType: "Consent",
+		Status:       "active",
+		Scope: CodeableConcept{Coding: []Coding{
+			{System: consentScopeSystem, Code: scope},
+		}},
+		Category: []CodeableConcept{{Coding: []Coding{
+			{System: "http://loinc.org", Code: patientConsentLOINCCode, Display: patientConsentLOINCTitle},
+		}}},
+		Patient:   &Reference{Reference: patient},
+		DateTime:  time.Now().UTC().Format(time.RFC3339),
+		Provision: &ConsentProvision{Type: ConsentProvisionDeny},
+	}}
+}
+
+// PermitByDefault makes the base rule permit everything not denied by a rule
+func (b *ConsentBuilder) PermitByDefault() *ConsentBuilder {
+	b.consent.Provision.Type = ConsentProvisionPermit
+	return b
+}
+
+// Period limits the consent to the given period, zero times are open ended
+func (b *ConsentBuilder) Period(start, end time.Time) *ConsentBuilder {
+	b.consent.Provision.Period = consentPeriod(start, end)
+	return b
+}
+
+// PolicyRule sets the policy the consent is based on
+func (b *ConsentBuilder) PolicyRule(system, code string) *ConsentBuilder {
+	b.consent.PolicyRule = &CodeableConcept{Coding: []Coding{{System: system, Code: code}}}
+	return b
+}
+
+// Permit adds a rule permitting the matching access
+func (b *ConsentBuilder) Permit(rule ConsentRule) *ConsentBuilder {
+	b.consent.Provision.Provision = append(b.consent.Provision.Provision, rule.provision(ConsentProvisionPermit))
+	return b
+}
+
+// Deny adds a rule denying the matching access
+func (b *ConsentBuilder) Deny(rule ConsentRule) *ConsentBuilder {
+	b.consent.Provision.Provision = append(b.consent.Provision.Provision, rule.provision(ConsentProvisionDeny))
+	return b
+}
+
+// Consent returns the consent built so far
+func (b *ConsentBuilder) Consent() Consent {
+	return b.consent
+}
+
+func (r ConsentRule) provision(provisionType string) ConsentProvision {
+	provision := ConsentProvision{
+		Type:   provisionType,
+		Period: consentPeriod(r.Start, r.End),
+	}
+	role := r.Role
+	if role == "" {
+		role = defaultConsentActorRole
+	}
+	for _, actor := range r.Actors {
+		provision.Actor = append(provision.Actor, ConsentActor{
+			Role:      CodeableConcept{Coding: []Coding{{System: participationTypeSystem, Code: role}}},
+			Reference: Reference{Reference: actor},
+		})
+	}
+	for _, action := range r.Actions {
+		provision.Action = append(provision.Action, CodeableConcept{Coding: []Coding{{System: consentActionSystem, Code: action}}})
+	}
+	for _, purpose := range r.Purposes {
+		provision.Purpose = append(provision.Purpose, Coding{System: purposeOfUseSystem, Code: purpose})
+	}
+	for _, resourceType := range r.ResourceTypes {
+		provision.Class = append(provision.Class, Coding{System: resourceTypesSystem, Code: resourceType})
+	}
+	return provision
+}
+
+func consentPeriod(start, end time.Time) *Period {
+	if start.IsZero() && end.IsZero() {
+		return nil
+	}
+	period := &Period{}
+	if !start.IsZero() {
+		period.Start = start.UTC().Format(time.RFC3339)
+	}
+	if !end.IsZero() {
+		period.End = end.UTC().Format(time.RFC3339)
+	}
+	return period
+}
+
+// Evaluate decides the request against the consent. Matching rules override the
+// base rule of the consent and a matching deny rule wins over a matching permit rule
+func (c Consent) Evaluate(request ConsentRequest) string {
+	if c.Status != "active" || c.Provision == nil {
+		return ConsentDecisionNotApplicable
+	}
+	if request.At.IsZero() {
+		request.At = time.Now()
+	}
+	if !c.Provision.matches(request) {
+		return ConsentDecisionNotApplicable
+	}
+	if c.Provision.decide(request) == ConsentProvisionPermit {
+		return ConsentDecisionPermit
+	}
+	return ConsentDecisionDeny
+}
+
+func (p ConsentProvision) decide(request ConsentRequest) string {
+	decision := p.Type
+	for _, nested := range p.Provision {
+		if !nested.matches(request) {
+			continue
+		}
+		nestedDecision := nested.decide(request)
+		if nestedDecision == "" {
+			continue
+		}
+		decision = nestedDecision
+		if decision == ConsentProvisionDeny {
+			// A matching deny is never overridden by a sibling permit
+			break
+		}
+	}
+	return decision
+}
+
+func (p ConsentProvision) matches(request ConsentRequest) bool {
+	if p.Period != nil && !p.Period.contains(request.At) {
+		return false
+	}
+	if len(p.Actor) > 0 {
+		found := false
+		for _, actor := range p.Actor {
+			if actor.Reference.Reference == request.Actor {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(p.Action) > 0 {
+		found := false
+		for _, action := range p.Action {
+			if containsCode(action.Coding, request.Action) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(p.Purpose) > 0 && !containsCode(p.Purpose, request.Purpose) {
+		return false
+	}
+	if len(p.Class) > 0 && !containsCode(p.Class, request.ResourceType) {
+		return false
+	}
+	return true
+}
+
+func containsCode(codings []Coding, code string) bool {
+	for _, coding := range codings {
+		if coding.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Period) contains(at time.Time) bool {
+	if p.Start != "" {
+		start, _, ok := parseFHIRDateTime(p.Start)
+		if !ok || at.Before(start) {
+			return false
+		}
+	}
+	if p.End != "" {
+		_, end, ok := parseFHIRDateTime(p.End)
+		if !ok || !at.Before(end) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseFHIRDateTime parses a possibly partial FHIR dateTime and returns the
+// start and exclusive end of the time span it denotes
+func parseFHIRDateTime(value string) (time.Time, time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, t.Add(time.Second), true
+	}
+	for _, layout := range []struct {
+		format        string
+		years, months int
+		days          int
+	}{
+		{"2006-01-02", 0, 0, 1},
+		{"2006-01", 0, 1, 0},
+		{"2006", 1, 0, 0},
+	} {
+		if t, err := time.Parse(layout.format, value); err == nil {
+			return t, t.AddDate(layout.years, layout.months, layout.days), true
+		}
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// CreateConsent stores the consent in the store. Only R4 stores are supported
+func (c *Client) CreateConsent(consent Consent, options ...OptionFunc) (*Consent, *Response, error) {
+	if c.fhirVersion != FHIRVersionR4 {
+		return nil, nil, fmt.Errorf("CreateConsent: %w", ErrUnsupportedFHIRVersion)
+	}
+	consent.ResourceType = "Consent"
+	body, err := json.Marshal(consent)
+	if err != nil {
+		return nil, nil, err
+	}
+	raw, resp, err := c.OperationsRaw.Create("Consent", body, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(raw) == 0 {
+		return &consent, resp, nil
+	}
+	var created Consent
+	if err := json.Unmarshal(raw, &created); err != nil {
+		return nil, resp, fmt.Errorf("JSON unmarshal: %w", err)
+	}
+	return &created, resp, nil
+}
+
+// GetPatientConsents returns the consents of the patient, e.g. "Patient/123".
+// Only R4 stores are supported
+func (c *Client) GetPatientConsents(patient string, options ...OptionFunc) ([]Consent, *Response, error) {
+	if c.fhirVersion != FHIRVersionR4 {
+		return nil, nil, fmt.Errorf("GetPatientConsents: %w", ErrUnsupportedFHIRVersion)
+	}
+	if patient == "" {
+		return nil, nil, ErrMissingResourceID
+	}
+	raw, resp, err := c.OperationsRaw.Search("Consent", url.Values{"patient": {patient}}, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	var bundle struct {
+		Entry []struct {
+			Resource Consent `json:"resource"`
+		} `json:"entry"`
+	}
+	if err := json.Unmarshal(raw, &bundle); err != nil {
+		return nil, resp, fmt.Errorf("JSON unmarshal: %w", err)
+	}
+	consents := make([]Consent, 0, len(bundle.Entry))
+	for _, e := range bundle.Entry {
+		consents = append(consents, e.Resource)
+	}
+	return consents, resp, nil
+}
@@ -0,0 +1,124 @@
+package cdr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/fhir/go/jsonformat"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsentEvaluate(t *testing.T) {
+	now := time.Now()
+	consent := cdr.NewConsentBuilder("Patient/123", cdr.ConsentScopePatientPrivacy).
+		Period(now.Add(-time.Hour), now.Add(24*time.Hour)).
+		Permit(cdr.ConsentRule{
+			Actors:        []string{"Practitioner/1", "Practitioner/2"},
+			ResourceTypes: []string{"Observation", "Condition"},
+			Actions:       []string{cdr.ConsentActionAccess},
+		}).
+		Deny(cdr.ConsentRule{
+			Actors: []string{"Practitioner/2"},
+			End:    now.Add(-time.Minute),
+		}).
+		Deny(cdr.ConsentRule{
+			Actors:        []string{"Practitioner/1"},
+			ResourceTypes: []string{"Condition"},
+		}).
+		Consent()
+
+	assert.Equal(t, cdr.ConsentDecisionPermit, consent.Evaluate(cdr.ConsentRequest{
+		Actor: "Practitioner/1", ResourceType: "Observation", Action: cdr.ConsentActionAccess,
+	}))
+	assert.Equal(t, cdr.ConsentDecisionDeny, consent.Evaluate(cdr.ConsentRequest{
+		Actor: "Practitioner/1", ResourceType: "Condition", Action: cdr.ConsentActionAccess,
+	}))
+	assert.Equal(t, cdr.ConsentDecisionDeny, consent.Evaluate(cdr.ConsentRequest{
+		Actor: "Practitioner/1", ResourceType: "Observation", Action: cdr.ConsentActionDisclose,
+	}))
+	assert.Equal(t, cdr.ConsentDecisionPermit, consent.Evaluate(cdr.ConsentRequest{
+		Actor: "Practitioner/2", ResourceType: "Observation", Action: cdr.ConsentActionAccess,
+	}))
+	assert.Equal(t, cdr.ConsentDecisionDeny, consent.Evaluate(cdr.ConsentRequest{
+		Actor: "Practitioner/2", ResourceType: "Observation", Action: cdr.ConsentActionAccess, At: now.Add(-30 * time.Minute),
+	}))
+	assert.Equal(t, cdr.ConsentDecisionNotApplicable, consent.Evaluate(cdr.ConsentRequest{
+		Actor: "Practitioner/1", ResourceType: "Observation", Action: cdr.ConsentActionAccess, At: now.Add(48 * time.Hour),
+	}))
+
+	consent.Provision.Period = &cdr.Period{Start: "2020", End: "2020-06"}
+	assert.Equal(t, cdr.ConsentDecisionPermit, consent.Evaluate(cdr.ConsentRequest{
+		Actor: "Practitioner/1", ResourceType: "Observation", Action: cdr.ConsentActionAccess,
+		At: time.Date(2020, 6, 30, 12, 0, 0, 0, time.UTC),
+	}))
+	assert.Equal(t, cdr.ConsentDecisionNotApplicable, consent.Evaluate(cdr.ConsentRequest{
+		Actor: "Practitioner/1", ResourceType: "Observation", Action: cdr.ConsentActionAccess,
+		At: time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC),
+	}))
+
+	consent.Status = "inactive"
+	assert.Equal(t, cdr.ConsentDecisionNotApplicable, consent.Evaluate(cdr.ConsentRequest{}))
+
+	open := cdr.NewConsentBuilder("Patient/123", cdr.ConsentScopeResearch).PermitByDefault().Consent()
+	assert.Equal(t, cdr.ConsentDecisionPermit, open.Evaluate(cdr.ConsentRequest{Actor: "Organization/9"}))
+}
+
+func TestCreateConsent(t *testing.T) {
+	teardown := setup(t, jsonformat.R4)
+	defer teardown()
+
+	_, _, err := cdrClient.CreateConsent(cdr.Consent{})
+	assert.True(t, errors.Is(err, cdr.ErrUnsupportedFHIRVersion))
+
+	r4Client, err := cdr.NewClient(iamClient, &cdr.Config{
+		CDRURL:      serverCDR.URL + "/store/fhir",
+		RootOrgID:   cdrOrgID,
+		FHIRVersion: cdr.FHIRVersionR4,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	var stored []byte
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Consent", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/fhir+json;fhirVersion=4.0")
+		switch r.Method {
+		case http.MethodPost:
+			var consent map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&consent)
+			consent["id"] = "c1"
+			stored, _ = json.Marshal(consent)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(stored)
+		case http.MethodGet:
+			assert.Equal(t, "Patient/123", r.URL.Query().Get("patient"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{"resourceType":"Bundle","type":"searchset","entry":[{"resource":`+string(stored)+`}]}`)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	consent := cdr.NewConsentBuilder("Patient/123", cdr.ConsentScopeTreatment).
+		Permit(cdr.ConsentRule{Purposes: []string{"TREAT"}}).
+		Consent()
+	created, resp, err := r4Client.CreateConsent(consent)
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) || !assert.NotNil(t, created) {
+		return
+	}
+	assert.Equal(t, "c1", created.ID)
+	assert.Equal(t, cdr.ConsentProvisionDeny, created.Provision.Type)
+
+	consents, _, err := r4Client.GetPatientConsents("Patient/123")
+	if !assert.Nil(t, err) || !assert.Len(t, consents, 1) {
+		return
+	}
+	assert.Equal(t, cdr.ConsentDecisionPermit, consents[0].Evaluate(cdr.ConsentRequest{Purpose: "TREAT"}))
+	assert.Equal(t, cdr.ConsentDecisionDeny, consents[0].Evaluate(cdr.ConsentRequest{Purpose: "HMARKT"}))
+}
@@ -6,7 +6,29 @@ import (
 
 // Errors
 var (
-	ErrCDRURLCannotBeEmpty = errors.New("base CDR URL cannot be empty")
-	ErrEmptyResult         = errors.New("empty result")
-	ErrMissingAcceptHeader = errors.New("missing accept header")
+	ErrCDRURLCannotBeEmpty    = errors.New("base CDR URL cannot be empty")
+	ErrEmptyResult            = errors.New("empty result")
+	ErrMissingAcceptHeader    = errors.New("missing accept header")
+	ErrUnsupportedFHIRVersion = errors.New("unsupported FHIR version")
+	ErrInvalidBundleType      = errors.New("invalid bundle type")
+	ErrEmptyBundle            = errors.New("bundle has no entries")
+	ErrMissingResourceType    = errors.New("missing resourceType")
+	ErrMissingResourceID      = errors.New("missing resource id")
+	ErrBundleEntryMismatch    = errors.New("bundle response entries do not match request")
+	ErrMissingVersionID       = errors.New("missing version id")
+	ErrMissingSearchCriteria  = errors.New("missing search criteria")
+	ErrInvalidNextLink        = errors.New("next link points to a different host")
+	ErrMissingContentType     = errors.New("missing content type")
+	ErrURLHostMismatch        = errors.New("URL points to a different host than the FHIR store")
+	ErrConflict               = errors.New("resource was modified concurrently")
+	ErrPurgeNotConfirmed      = errors.New("purge must be explicitly confirmed")
+	ErrPurgeNotAllowed        = errors.New("hard delete is only allowed on delete requests")
+	ErrResourceNotFound       = errors.New("resource not found")
+	ErrMultipleMatches        = errors.New("multiple resources match")
+	ErrInvalidLinkType        = errors.New("invalid link type")
+	ErrInvalidCompartment     = errors.New("invalid compartment")
+	ErrInvalidSearchParameter = errors.New("invalid search parameter")
+	ErrResourceTypeMismatch   = errors.New("resource type mismatch")
+	ErrMissingAttachment      = errors.New("missing attachment")
+	ErrMissingDocumentType    = errors.New("missing document type")
 )
@@ -0,0 +1,50 @@
+package cdr
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EverythingOptions holds the optional parameters of the Patient $everything operation
+type EverythingOptions struct {
+	// Types limits the resource types returned (_type)
+	Types []string
+	// Start and End limit the clinical date range of the record (start, end)
+	Start time.Time
+	End   time.Time
+	// Since only returns resources modified after this time (_since)
+	Since time.Time
+	// Count is the page size (_count)
+	Count int
+}
+
+// PatientEverything returns an iterator over the complete record of the patient
+// using the $everything operation, following the paging links of the server
+func (c *Client) PatientEverything(ctx context.Context, patientID string, opts *EverythingOptions, options ...OptionFunc) *SearchIterator {
+	query := url.Values{}
+	if opts != nil {
+		if len(opts.Types) > 0 {
+			query.Set("_type", strings.Join(opts.Types, ","))
+		}
+		if !opts.Start.IsZero() {
+			query.Set("start", opts.Start.Format("2006-01-02"))
+		}
+		if !opts.End.IsZero() {
+			query.Set("end", opts.End.Format("2006-01-02"))
+		}
+		if !opts.Since.IsZero() {
+			query.Set("_since", opts.Since.UTC().Format(time.RFC3339))
+		}
+		if opts.Count > 0 {
+			query.Set("_count", strconv.Itoa(opts.Count))
+		}
+	}
+	it := c.bundleIterator(ctx, "Patient/"+patientID+"/$everything", query, options...)
+	if patientID == "" {
+		it.err = ErrMissingResourceID
+	}
+	return it
+}
@@ -0,0 +1,65 @@
+package cdr_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/fhir/go/jsonformat"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatientEverything(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	everythingURL := serverCDR.URL + "/store/fhir/" + cdrOrgID + "/Patient/123/$everything"
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient/123/$everything", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, http.MethodGet, r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		query := r.URL.Query()
+		assert.Equal(t, "Patient,Observation", query.Get("_type"))
+		assert.Equal(t, "2021-01-01", query.Get("start"))
+		assert.Equal(t, "50", query.Get("_count"))
+		w.Header().Set("Content-Type", "application/fhir+json")
+		w.WriteHeader(http.StatusOK)
+		if query.Get("page") == "2" {
+			_, _ = io.WriteString(w, `{"resourceType":"Bundle","type":"searchset","entry":[
+  {"resource":{"resourceType":"Observation","id":"o2","status":"final","code":{"text":"bp"}}}
+]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"resourceType":"Bundle","type":"searchset","total":3,
+  "link":[{"relation":"next","url":"`+everythingURL+`?_type=Patient,Observation&start=2021-01-01&_count=50&page=2"}],
+  "entry":[
+  {"resource":{"resourceType":"Patient","id":"123"}},
+  {"resource":{"resourceType":"Observation","id":"o1","status":"final","code":{"text":"bp"}}}
+]}`)
+	})
+
+	it := cdrClient.PatientEverything(context.Background(), "123", &cdr.EverythingOptions{
+		Types: []string{"Patient", "Observation"},
+		Start: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		Count: 50,
+	})
+	resources, err := it.All()
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, resources, 3)
+	total, ok := it.Total()
+	assert.True(t, ok)
+	assert.Equal(t, 3, total)
+
+	it = cdrClient.PatientEverything(context.Background(), "", nil)
+	assert.False(t, it.Next())
+	assert.Equal(t, cdr.ErrMissingResourceID, it.Err())
+}
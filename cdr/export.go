@@ -0,0 +1,234 @@
+package cdr
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/philips-software/go-hsdp-api/internal"
+)
+
+const defaultExportPollInterval = 10 * time.Second
+
+// ExportOptions holds the optional parameters of a bulk $export kick-off
+type ExportOptions struct {
+	// Types limits the export to these resource types (_type)
+	Types []string
+	// Since only exports resources modified after this time (_since)
+	Since time.Time
+	// OutputFormat defaults to application/fhir+ndjson (_outputFormat)
+	OutputFormat string
+}
+
+// ExportJob is a running bulk export, identified by its status URL
+type ExportJob struct {
+	StatusURL string
+}
+
+// ExportStatus is the progress of a running bulk export
+type ExportStatus struct {
+	// Progress is the X-Progress indication of the server, if any
+	Progress string
+	// RetryAfter is the poll interval requested by the server, if any
+	RetryAfter time.Duration
+}
+
+// ExportManifest describes the files produced by a completed bulk export
+type ExportManifest struct {
+	TransactionTime     string         `json:"transactionTime"`
+	Request             string         `json:"request"`
+	RequiresAccessToken bool           `json:"requiresAccessToken"`
+	Output              []ExportOutput `json:"output"`
+	Error               []ExportOutput `json:"error"`
+}
+
+// ExportOutput is a single NDJSON file of an export
+type ExportOutput struct {
+	Type  string `json:"type"`
+	URL   string `json:"url"`
+	Count int    `json:"count,omitempty"`
+}
+
+// StartSystemExport kicks off a bulk export of the whole store
+func (c *Client) StartSystemExport(opts *ExportOptions, options ...OptionFunc) (*ExportJob, *Response, error) {
+	return c.startExport("$export", opts, options...)
+}
+
+// StartPatientExport kicks off a bulk export of all patient compartments
+func (c *Client) StartPatientExport(opts *ExportOptions, options ...OptionFunc) (*ExportJob, *Response, error) {
+	return c.startExport("Patient/$export", opts, options...)
+}
+
+// StartGroupExport kicks off a bulk export of the patients of the given Group
+func (c *Client) StartGroupExport(groupID string, opts *ExportOptions, options ...OptionFunc) (*ExportJob, *Response, error) {
+	if groupID == "" {
+		return nil, nil, ErrMissingResourceID
+	}
+	return c.startExport("Group/"+groupID+"/$export", opts, options...)
+}
+
+func (c *Client) startExport(path string, opts *ExportOptions, options ...OptionFunc) (*ExportJob, *Response, error) {
+	query := url.Values{}
+	if opts != nil {
+		if len(opts.Types) > 0 {
+			query.Set("_type", strings.Join(opts.Types, ","))
+		}
+		if !opts.Since.IsZero() {
+			query.Set("_since", opts.Since.Format(time.RFC3339))
+		}
+		if opts.OutputFormat != "" {
+			query.Set("_outputFormat", opts.OutputFormat)
+		}
+	}
+	req, err := c.newCDRRequest(http.MethodGet, path, nil, append([]OptionFunc{
+		func(req *http.Request) error {
+			req.URL.RawQuery = query.Encode()
+			req.Header.Set("Prefer", "respond-async")
+			return nil
+		},
+	}, options...))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/fhir+json")
+	resp, err := c.do(req, io.Discard)
+	if err != nil || resp == nil {
+		if resp == nil && err == nil {
+			err = fmt.Errorf("StartExport: %w", ErrEmptyResult)
+		}
+		return nil, resp, err
+	}
+	statusURL := resp.Header.Get("Content-Location")
+	if resp.StatusCode != http.StatusAccepted || statusURL == "" {
+		return nil, resp, fmt.Errorf("StartExport: status %d: %w", resp.StatusCode, ErrEmptyResult)
+	}
+	return &ExportJob{StatusURL: statusURL}, resp, nil
+}
+
+// ExportStatus polls the bulk export job once. While the export is in progress the
+// returned manifest is nil and the status describes the progress
+func (c *Client) ExportStatus(job ExportJob, options ...OptionFunc) (*ExportManifest, *ExportStatus, *Response, error) {
+	req, err := c.newCDRURLRequest(http.MethodGet, job.StatusURL, options)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	var statusResponse bytes.Buffer
+	resp, err := c.do(req, &statusResponse)
+	if (err != nil && err != io.EOF) || resp == nil {
+		if resp == nil && err != nil {
+			err = fmt.Errorf("ExportStatus: %w", ErrEmptyResult)
+		}
+		return nil, nil, resp, err
+	}
+	if resp.StatusCode == http.StatusAccepted {
+		retryAfter, _ := internal.ParseRetryAfter(resp.Header.Get("Retry-After"))
+		return nil, &ExportStatus{
+			Progress:   resp.Header.Get("X-Progress"),
+			RetryAfter: retryAfter,
+		}, resp, nil
+	}
+	var manifest ExportManifest
+	if err := json.Unmarshal(statusResponse.Bytes(), &manifest); err != nil {
+		return nil, nil, resp, fmt.Errorf("export manifest: %w", err)
+	}
+	return &manifest, nil, resp, nil
+}
+
+// WaitForExport polls the bulk export job until it completes or ctx is done.
+// The Retry-After interval of the server is honoured, otherwise interval is used
+// which defaults to 10 seconds
+func (c *Client) WaitForExport(ctx context.Context, job ExportJob, interval time.Duration, options ...OptionFunc) (*ExportManifest, error) {
+	if interval <= 0 {
+		interval = defaultExportPollInterval
+	}
+	for {
+		manifest, status, _, err := c.ExportStatus(job, options...)
+		if err != nil {
+			return nil, err
+		}
+		if manifest != nil {
+			return manifest, nil
+		}
+		wait := interval
+		if status.RetryAfter > 0 {
+			wait = status.RetryAfter
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// CancelExport cancels a running bulk export or removes the files of a completed one
+func (c *Client) CancelExport(job ExportJob, options ...OptionFunc) (bool, *Response, error) {
+	req, err := c.newCDRURLRequest(http.MethodDelete, job.StatusURL, options)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.do(req, io.Discard)
+	if err != nil || resp == nil {
+		return false, resp, err
+	}
+	return resp.StatusCode == http.StatusAccepted, resp, nil
+}
+
+// DownloadExportFile writes the NDJSON content of an export output file to w
+func (c *Client) DownloadExportFile(output ExportOutput, w io.Writer, options ...OptionFunc) (*Response, error) {
+	req, err := c.newCDRURLRequest(http.MethodGet, output.URL, options)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/fhir+ndjson")
+	return c.do(req, w)
+}
+
+// ReadNDJSON calls fn for every resource of the NDJSON stream r
+func ReadNDJSON(r io.Reader, fn func(resource json.RawMessage) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		resource := make(json.RawMessage, len(line))
+		copy(resource, line)
+		if err := fn(resource); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// newCDRURLRequest creates a request for an absolute URL returned by the store,
+// e.g. a status or file URL. The URL must point to the FHIR store host so the
+// token is never sent elsewhere
+func (c *Client) newCDRURLRequest(method, rawURL string, options []OptionFunc) (*http.Request, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host != c.fhirStoreURL.Host {
+		return nil, fmt.Errorf("URL [%s]: %w", rawURL, ErrURLHostMismatch)
+	}
+	req, err := c.newCDRRequest(method, "", nil, options)
+	if err != nil {
+		return nil, err
+	}
+	req.URL = u
+	req.Host = u.Host
+	return req, nil
+}
@@ -0,0 +1,124 @@
+package cdr_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/fhir/go/jsonformat"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkExport(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	groupID := "0d5d5f1c-7e43-4b5b-9b2d-4f3b7c2d1e0a"
+	statusURL := serverCDR.URL + "/store/fhir/" + cdrOrgID + "/$export-poll-status/123"
+	fileURL := serverCDR.URL + "/store/fhir/" + cdrOrgID + "/$export-files/patient.ndjson"
+	var polls int32
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Group/"+groupID+"/$export", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "respond-async", r.Header.Get("Prefer"))
+		assert.Equal(t, "Patient,Observation", r.URL.Query().Get("_type"))
+		w.Header().Set("Content-Location", statusURL)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/$export-poll-status/123", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		if atomic.AddInt32(&polls, 1) == 1 {
+			w.Header().Set("X-Progress", "50%")
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{
+  "transactionTime": "2021-01-02T03:04:05Z",
+  "request": "`+serverCDR.URL+`/Group/`+groupID+`/$export",
+  "requiresAccessToken": true,
+  "output": [{"type": "Patient", "url": "`+fileURL+`", "count": 2}],
+  "error": []
+}`)
+	})
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/$export-files/patient.ndjson", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/fhir+ndjson", r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", "application/fhir+ndjson")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"resourceType":"Patient","id":"1"}`+"\n"+`{"resourceType":"Patient","id":"2"}`+"\n")
+	})
+
+	job, resp, err := cdrClient.StartGroupExport(groupID, &cdr.ExportOptions{Types: []string{"Patient", "Observation"}})
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) || !assert.NotNil(t, job) {
+		return
+	}
+	assert.Equal(t, statusURL, job.StatusURL)
+
+	manifest, status, _, err := cdrClient.ExportStatus(*job)
+	if !assert.Nil(t, err) || !assert.NotNil(t, status) {
+		return
+	}
+	assert.Nil(t, manifest)
+	assert.Equal(t, "50%", status.Progress)
+
+	manifest, err = cdrClient.WaitForExport(context.Background(), *job, time.Millisecond)
+	if !assert.Nil(t, err) || !assert.NotNil(t, manifest) {
+		return
+	}
+	if !assert.Len(t, manifest.Output, 1) {
+		return
+	}
+
+	var buf bytes.Buffer
+	_, err = cdrClient.DownloadExportFile(manifest.Output[0], &buf)
+	if !assert.Nil(t, err) {
+		return
+	}
+	var ids []string
+	err = cdr.ReadNDJSON(&buf, func(resource json.RawMessage) error {
+		var r struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(resource, &r); err != nil {
+			return err
+		}
+		ids = append(ids, r.ID)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2"}, ids)
+
+	_, err = cdrClient.DownloadExportFile(cdr.ExportOutput{URL: "https://example.com/patient.ndjson"}, &buf)
+	assert.ErrorIs(t, err, cdr.ErrURLHostMismatch)
+
+	ok, _, err := cdrClient.CancelExport(*job)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestWaitForExportCancelled(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	statusURL := serverCDR.URL + "/store/fhir/" + cdrOrgID + "/$export-poll-status/456"
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/$export-poll-status/456", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := cdrClient.WaitForExport(ctx, cdr.ExportJob{StatusURL: statusURL}, 0)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
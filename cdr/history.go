@@ -0,0 +1,61 @@
+package cdr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	protov1 "github.com/golang/protobuf/proto"
+)
+
+// GetResourceVersion returns a specific version of a FHIR resource (vread) as a
+// ContainedResource of the configured FHIR version
+func (c *Client) GetResourceVersion(resourceType, id, versionID string, options ...OptionFunc) (protov1.Message, *Response, error) {
+	if resourceType == "" || id == "" {
+		return nil, nil, ErrMissingResourceID
+	}
+	if versionID == "" {
+		return nil, nil, ErrMissingVersionID
+	}
+	req, err := c.newCDRRequest(http.MethodGet, resourceType+"/"+id+"/_history/"+versionID, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", c.MediaType())
+	var operationResponse bytes.Buffer
+	resp, err := c.do(req, &operationResponse)
+	if (err != nil && err != io.EOF) || resp == nil {
+		if resp == nil && err != nil {
+			err = fmt.Errorf("GetResourceVersion: %w", ErrEmptyResult)
+		}
+		return nil, resp, err
+	}
+	resource, err := c.Unmarshaller().Unmarshal(operationResponse.Bytes())
+	if err != nil {
+		return nil, resp, fmt.Errorf("FHIR unmarshal: %w", err)
+	}
+	return resource, resp, nil
+}
+
+// GetHistory returns an iterator over the historical versions of a FHIR resource,
+// newest first. since and count are optional and map to _since and _count.
+// Deleted versions have no resource and are skipped
+func (c *Client) GetHistory(ctx context.Context, resourceType, id string, since time.Time, count int, options ...OptionFunc) *SearchIterator {
+	query := url.Values{}
+	if !since.IsZero() {
+		query.Set("_since", since.Format(time.RFC3339))
+	}
+	if count > 0 {
+		query.Set("_count", strconv.Itoa(count))
+	}
+	it := c.bundleIterator(ctx, resourceType+"/"+id+"/_history", query, options...)
+	if resourceType == "" || id == "" {
+		it.err = ErrMissingResourceID
+	}
+	return it
+}
@@ -0,0 +1,82 @@
+package cdr_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/fhir/go/jsonformat"
+	stu3pb "github.com/google/fhir/go/proto/google/fhir/proto/stu3/resources_go_proto"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	orgID := "f5fe538f-c3b5-4454-8774-cd3789f59b9f"
+	historyURL := serverCDR.URL + "/store/fhir/" + cdrOrgID + "/Organization/" + orgID + "/_history"
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Organization/"+orgID+"/_history/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/fhir+json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"resourceType":"Organization","id":"`+orgID+`","meta":{"versionId":"1"},"name":"Hospital1"}`)
+	})
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Organization/"+orgID+"/_history", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/fhir+json")
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = io.WriteString(w, `{
+  "resourceType": "Bundle",
+  "type": "history",
+  "entry": [
+    {"resource": {"resourceType":"Organization","id":"`+orgID+`","meta":{"versionId":"1"},"name":"Hospital1"}}
+  ]
+}`)
+			return
+		}
+		assert.Equal(t, "2", r.URL.Query().Get("_count"))
+		assert.Equal(t, "2021-01-02T03:04:05Z", r.URL.Query().Get("_since"))
+		_, _ = io.WriteString(w, `{
+  "resourceType": "Bundle",
+  "type": "history",
+  "total": 3,
+  "link": [{"relation": "next", "url": "`+historyURL+`?page=2"}],
+  "entry": [
+    {"request": {"method": "DELETE", "url": "Organization/`+orgID+`"}},
+    {"resource": {"resourceType":"Organization","id":"`+orgID+`","meta":{"versionId":"2"},"name":"Hospital2"}}
+  ]
+}`)
+	})
+
+	_, _, err := cdrClient.GetResourceVersion("Organization", orgID, "")
+	assert.ErrorIs(t, err, cdr.ErrMissingVersionID)
+
+	resource, resp, err := cdrClient.GetResourceVersion("Organization", orgID, "1")
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) {
+		return
+	}
+	contained, ok := resource.(*stu3pb.ContainedResource)
+	if assert.True(t, ok) {
+		assert.Equal(t, "Hospital1", contained.GetOrganization().GetName().GetValue())
+	}
+
+	since := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	versions, err := cdrClient.GetHistory(context.Background(), "Organization", orgID, since, 2).All()
+	if !assert.Nil(t, err) {
+		return
+	}
+	var versionIDs []string
+	for _, v := range versions {
+		versionIDs = append(versionIDs, v.(*stu3pb.ContainedResource).GetOrganization().GetMeta().GetVersionId().GetValue())
+	}
+	assert.Equal(t, []string{"2", "1"}, versionIDs)
+
+	_, err = cdrClient.GetHistory(context.Background(), "Organization", "", time.Time{}, 0).All()
+	assert.ErrorIs(t, err, cdr.ErrMissingResourceID)
+}
@@ -0,0 +1,155 @@
+package cdr
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/philips-software/go-hsdp-api/internal"
+	"github.com/philips-software/go-hsdp-api/notification"
+)
+
+// NotifiedSubscriptionRequest describes a FHIR Subscription together with the
+// notification Topic and Subscriber that should receive its notifications
+type NotifiedSubscriptionRequest struct {
+	// Criteria is the FHIR search criteria of the subscription, e.g. "Patient?given=Ron"
+	Criteria string
+	Reason   string
+	// Endpoint is the REST hook endpoint invoked by CDR and registered with the notification service
+	Endpoint string
+	Headers  []string
+
+	Topic      notification.Topic
+	Subscriber notification.Subscriber
+	// NotificationOptions are applied to the requests to the notification service, the
+	// options of CreateNotifiedSubscription only to the FHIR Subscription request
+	NotificationOptions []notification.OptionFunc
+}
+
+// NotifiedSubscription holds the resources created by CreateNotifiedSubscription
+type NotifiedSubscription struct {
+	// FHIRSubscription is the Subscription resource as returned by CDR
+	FHIRSubscription         json.RawMessage
+	FHIRSubscriptionID       string
+	Topic                    *notification.Topic
+	Subscriber               *notification.Subscriber
+	NotificationSubscription *notification.Subscription
+}
+
+// RollbackError is returned when creating a notified subscription failed.
+// Err is the original failure, RollbackErrs lists the resources that could not be
+// cleaned up and must be removed manually
+type RollbackError = internal.RollbackError
+
+// CreateNotifiedSubscription creates the notification Topic, Subscriber and Subscription
+// followed by the FHIR Subscription in CDR. When any step fails the resources created
+// so far are removed again and a *RollbackError is returned
+func (c *Client) CreateNotifiedSubscription(nc *notification.Client, request NotifiedSubscriptionRequest, options ...OptionFunc) (*NotifiedSubscription, error) {
+	if request.Criteria == "" {
+		return nil, ErrMissingSearchCriteria
+	}
+	var rollback internal.Rollback
+	fail := func(err error) (*NotifiedSubscription, error) {
+		return nil, rollback.Fail(err)
+	}
+	result := &NotifiedSubscription{}
+	ncOptions := request.NotificationOptions
+
+	topic, _, err := nc.Topic.CreateTopic(request.Topic, ncOptions...)
+	if err != nil {
+		return fail(fmt.Errorf("create topic: %w", err))
+	}
+	result.Topic = topic
+	rollback.Add(func() error {
+		_, _, err := nc.Topic.DeleteTopic(*topic, ncOptions...)
+		return err
+	})
+
+	subscriber, _, err := nc.Subscriber.CreateSubscriber(request.Subscriber, ncOptions...)
+	if err != nil {
+		return fail(fmt.Errorf("create subscriber: %w", err))
+	}
+	result.Subscriber = subscriber
+	rollback.Add(func() error {
+		_, _, err := nc.Subscriber.DeleteSubscriber(*subscriber, ncOptions...)
+		return err
+	})
+
+	subscription, _, err := nc.Subscription.CreateSubscription(notification.Subscription{
+		TopicID:              topic.ID,
+		SubscriberID:         subscriber.ID,
+		SubscriptionEndpoint: request.Endpoint,
+	}, ncOptions...)
+	if err != nil {
+		return fail(fmt.Errorf("create notification subscription: %w", err))
+	}
+	result.NotificationSubscription = subscription
+	rollback.Add(func() error {
+		_, _, err := nc.Subscription.DeleteSubscription(*subscription, ncOptions...)
+		return err
+	})
+
+	body, err := fhirSubscription(request)
+	if err != nil {
+		return fail(err)
+	}
+	created, _, err := c.OperationsRaw.Create("Subscription", body, options...)
+	if err != nil {
+		return fail(fmt.Errorf("create FHIR subscription: %w", err))
+	}
+	result.FHIRSubscription = created
+	if len(created) > 0 {
+		_, result.FHIRSubscriptionID, _ = resourceTypeAndID(created)
+	}
+	return result, nil
+}
+
+// DeleteNotifiedSubscription removes the FHIR Subscription and its notification
+// resources. ncOptions are applied to the requests to the notification service, options
+// to the FHIR Subscription request. All removals are attempted, the returned error
+// joins the errors of all removals which failed
+func (c *Client) DeleteNotifiedSubscription(nc *notification.Client, subscription NotifiedSubscription, ncOptions []notification.OptionFunc, options ...OptionFunc) error {
+	var errs []error
+	if subscription.FHIRSubscriptionID != "" {
+		if _, _, err := c.OperationsRaw.Delete("Subscription/"+subscription.FHIRSubscriptionID, options...); err != nil {
+			errs = append(errs, fmt.Errorf("delete FHIR subscription: %w", err))
+		}
+	}
+	if subscription.NotificationSubscription != nil {
+		if _, _, err := nc.Subscription.DeleteSubscription(*subscription.NotificationSubscription, ncOptions...); err != nil {
+			errs = append(errs, fmt.Errorf("delete notification subscription: %w", err))
+		}
+	}
+	if subscription.Subscriber != nil {
+		if _, _, err := nc.Subscriber.DeleteSubscriber(*subscription.Subscriber, ncOptions...); err != nil {
+			errs = append(errs, fmt.Errorf("delete subscriber: %w", err))
+		}
+	}
+	if subscription.Topic != nil {
+		if _, _, err := nc.Topic.DeleteTopic(*subscription.Topic, ncOptions...); err != nil {
+			errs = append(errs, fmt.Errorf("delete topic: %w", err))
+		}
+	}
+	return internal.JoinErrors(errs)
+}
+
+// fhirSubscription builds a REST hook Subscription valid for both STU3 and R4
+func fhirSubscription(request NotifiedSubscriptionRequest) ([]byte, error) {
+	channel := map[string]interface{}{
+		"type":     "rest-hook",
+		"endpoint": request.Endpoint,
+		"payload":  "application/fhir+json",
+	}
+	if len(request.Headers) > 0 {
+		channel["header"] = request.Headers
+	}
+	subscription := map[string]interface{}{
+		"resourceType": "Subscription",
+		"status":       "requested",
+		"criteria":     request.Criteria,
+		"channel":      channel,
+	}
+	if request.Reason != "" {
+		subscription["reason"] = request.Reason
+	}
+	return json.Marshal(subscription)
+}
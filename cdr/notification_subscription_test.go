@@ -0,0 +1,154 @@
+package cdr_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/google/fhir/go/jsonformat"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+	"github.com/philips-software/go-hsdp-api/notification"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifiedSubscription(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	muxNotification := http.NewServeMux()
+	serverNotification := httptest.NewServer(muxNotification)
+	defer serverNotification.Close()
+
+	var lock sync.Mutex
+	var deleted []string
+	failFHIR := false
+	var traces, cdrTraces []string
+	failDelete := false
+
+	created := func(w http.ResponseWriter, body string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, body)
+	}
+	deleteHandler := func(kind string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if !assert.Equal(t, http.MethodDelete, r.Method) {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			lock.Lock()
+			deleted = append(deleted, kind)
+			lock.Unlock()
+			if failDelete && kind != "fhir" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+	muxNotification.HandleFunc("/core/notification/Topic", func(w http.ResponseWriter, r *http.Request) {
+		traces = append(traces, r.Header.Get("X-Trace")+r.Header.Get("X-CDR"))
+		created(w, `{"_id":"topic1","name":"cdr-patients","producerId":"producer1","scope":"private"}`)
+	})
+	muxNotification.HandleFunc("/core/notification/Subscriber", func(w http.ResponseWriter, r *http.Request) {
+		created(w, `{"_id":"subscriber1","managingOrganizationId":"`+cdrOrgID+`","subscriberProductName":"product","subscriberServiceName":"service","subscriberServiceBaseUrl":"https://foo","subscriberServicePathUrl":"/notify"}`)
+	})
+	muxNotification.HandleFunc("/core/notification/Subscription", func(w http.ResponseWriter, r *http.Request) {
+		var subscription notification.Subscription
+		_ = json.NewDecoder(r.Body).Decode(&subscription)
+		assert.Equal(t, "topic1", subscription.TopicID)
+		assert.Equal(t, "subscriber1", subscription.SubscriberID)
+		created(w, `{"_id":"subscription1","topicId":"topic1","subscriberId":"subscriber1","subscriptionEndpoint":"https://foo/notify"}`)
+	})
+	muxNotification.HandleFunc("/core/notification/Topic/topic1", deleteHandler("topic"))
+	muxNotification.HandleFunc("/core/notification/Subscriber/subscriber1", deleteHandler("subscriber"))
+	muxNotification.HandleFunc("/core/notification/Subscription/subscription1", deleteHandler("subscription"))
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Subscription", func(w http.ResponseWriter, r *http.Request) {
+		if failFHIR {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		cdrTraces = append(cdrTraces, r.Header.Get("X-CDR"))
+		var subscription map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&subscription)
+		assert.Equal(t, "Patient?given=Ron", subscription["criteria"])
+		w.Header().Set("Content-Type", "application/fhir+json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"resourceType":"Subscription","id":"fhirsub1","status":"requested","criteria":"Patient?given=Ron"}`)
+	})
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Subscription/fhirsub1", deleteHandler("fhir"))
+
+	notificationClient, err := notification.NewClient(iamClient, &notification.Config{
+		NotificationURL: serverNotification.URL,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	request := cdr.NotifiedSubscriptionRequest{
+		Criteria: "Patient?given=Ron",
+		Endpoint: "https://foo/notify",
+		Topic: notification.Topic{
+			Name:       "cdr-patients",
+			ProducerID: "producer1",
+			Scope:      "private",
+		},
+		Subscriber: notification.Subscriber{
+			ManagingOrganizationID:   cdrOrgID,
+			SubscriberProductName:    "product",
+			SubscriberServicename:    "service",
+			SubscriberServiceBaseURL: "https://foo",
+			SubscriberServicePathURL: "/notify",
+		},
+	}
+
+	withCDR := func(req *http.Request) error {
+		req.Header.Set("X-CDR", "cdr1")
+		return nil
+	}
+	traced := request
+	traced.NotificationOptions = []notification.OptionFunc{func(req *http.Request) error {
+		req.Header.Set("X-Trace", "trace1")
+		return nil
+	}}
+	result, err := cdrClient.CreateNotifiedSubscription(notificationClient, traced, withCDR)
+	if !assert.Nil(t, err) || !assert.NotNil(t, result) {
+		return
+	}
+	// Options of CDR only apply to the FHIR request
+	assert.Equal(t, []string{"trace1"}, traces)
+	assert.Equal(t, []string{"cdr1"}, cdrTraces)
+	assert.Equal(t, "fhirsub1", result.FHIRSubscriptionID)
+	assert.Equal(t, "topic1", result.Topic.ID)
+	assert.Equal(t, "subscription1", result.NotificationSubscription.ID)
+
+	assert.Nil(t, cdrClient.DeleteNotifiedSubscription(notificationClient, *result, nil))
+	assert.Equal(t, []string{"fhir", "subscription", "subscriber", "topic"}, deleted)
+
+	// All failed removals are reported
+	deleted = nil
+	failDelete = true
+	err = cdrClient.DeleteNotifiedSubscription(notificationClient, *result, nil)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "delete notification subscription")
+		assert.Contains(t, err.Error(), "delete subscriber")
+		assert.Contains(t, err.Error(), "delete topic")
+	}
+	assert.Equal(t, []string{"fhir", "subscription", "subscriber", "topic"}, deleted)
+	failDelete = false
+
+	deleted = nil
+	failFHIR = true
+	result, err = cdrClient.CreateNotifiedSubscription(notificationClient, request)
+	assert.Nil(t, result)
+	var rollbackErr *cdr.RollbackError
+	if assert.ErrorAs(t, err, &rollbackErr) {
+		assert.Len(t, rollbackErr.RollbackErrs, 0)
+	}
+	assert.Equal(t, []string{"subscription", "subscriber", "topic"}, deleted)
+}
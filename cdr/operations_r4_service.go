@@ -17,11 +17,22 @@ type OperationsR4Service struct {
 	um       *jsonformat.Unmarshaller
 }
 
-// Patch makes changes to a FHIR resources accepting the JSONPatch format set
+// Patch makes changes to a FHIR resources accepting the JSONPatch format set.
+// Use WithIfMatch to only apply the patch to a specific version of the resource
 func (o *OperationsR4Service) Patch(resourceID string, jsonPatch []byte, options ...OptionFunc) (*r4pb.ContainedResource, *Response, error) {
-	req, err := o.client.newCDRRequest(http.MethodPatch, resourceID, jsonPatch, append([]OptionFunc{
+	return o.patch("application/json-patch+json", resourceID, jsonPatch, options...)
+}
+
+// FHIRPathPatch makes changes to a FHIR resource using a FHIRPath Patch, i.e. a
+// Parameters resource describing the operations
+func (o *OperationsR4Service) FHIRPathPatch(resourceID string, parameters []byte, options ...OptionFunc) (*r4pb.ContainedResource, *Response, error) {
+	return o.patch(r4MediaType, resourceID, parameters, options...)
+}
+
+func (o *OperationsR4Service) patch(contentType, resourceID string, body []byte, options ...OptionFunc) (*r4pb.ContainedResource, *Response, error) {
+	req, err := o.client.newCDRRequest(http.MethodPatch, resourceID, body, append([]OptionFunc{
 		func(req *http.Request) error {
-			req.Header.Set("Content-Type", "application/json-patch+json")
+			req.Header.Set("Content-Type", contentType)
 			return nil
 		},
 	},
@@ -29,15 +40,18 @@ func (o *OperationsR4Service) Patch(resourceID string, jsonPatch []byte, options
 	if err != nil {
 		return nil, nil, err
 	}
-	req.Header.Set("Accept", "application/fhir+json;fhirVersion=4.0")
+	req.Header.Set("Accept", r4MediaType)
 	var patchResponse bytes.Buffer
 	resp, err := o.client.do(req, &patchResponse)
 	if (err != nil && err != io.EOF) || resp == nil {
 		if resp == nil && err != nil {
-			err = fmt.Errorf("OperationsSTU3Service.Patch: %w", ErrEmptyResult)
+			err = fmt.Errorf("OperationsR4Service.Patch: %w", ErrEmptyResult)
 		}
 		return nil, resp, err
 	}
+	if patchResponse.Len() == 0 { // Empty body, e.g. Prefer: return=minimal
+		return &r4pb.ContainedResource{}, resp, nil
+	}
 	unmarshalled, err := o.um.Unmarshal(patchResponse.Bytes())
 	if err != nil {
 		return nil, resp, fmt.Errorf("FHIR unmarshal: %w", err)
@@ -51,7 +65,10 @@ func (o *OperationsR4Service) Post(resourceID string, jsonBody []byte, options .
 	return o.postOrPut(http.MethodPost, resourceID, jsonBody, options...)
 }
 
-// Put creates or updates new FHIR resources
+// Put creates or updates new FHIR resources.
+// When jsonBody carries a meta.versionId it is sent as If-Match precondition, so the
+// update fails with ErrConflict when the resource was modified since it was read.
+// Use WithoutIfMatch to overwrite the resource regardless
 func (o *OperationsR4Service) Put(resourceID string, jsonBody []byte, options ...OptionFunc) (*r4pb.ContainedResource, *Response, error) {
 	return o.postOrPut(http.MethodPut, resourceID, jsonBody, options...)
 }
@@ -60,7 +77,7 @@ func (o *OperationsR4Service) Put(resourceID string, jsonBody []byte, options ..
 func (o *OperationsR4Service) Get(resourceID string, options ...OptionFunc) (*r4pb.ContainedResource, *Response, error) {
 	req, err := o.client.newCDRRequest(http.MethodGet, resourceID, nil, append([]OptionFunc{
 		func(req *http.Request) error {
-			req.Header.Set("Content-Type", "application/fhir+json;fhirVersion=4.0")
+			req.Header.Set("Content-Type", r4MediaType)
 			return nil
 		},
 	},
@@ -68,7 +85,7 @@ func (o *OperationsR4Service) Get(resourceID string, options ...OptionFunc) (*r4
 	if err != nil {
 		return nil, nil, err
 	}
-	req.Header.Set("Accept", "application/fhir+json;fhirVersion=4.0")
+	req.Header.Set("Accept", r4MediaType)
 	var operationResponse bytes.Buffer
 	resp, err := o.client.do(req, &operationResponse)
 	if (err != nil && err != io.EOF) || resp == nil {
@@ -85,18 +102,24 @@ func (o *OperationsR4Service) Get(resourceID string, options ...OptionFunc) (*r4
 	return contained, resp, nil
 }
 
+// DeleteVersion removes a FHIR resource only when versionID is its current version,
+// e.g. the Response.VersionID of a previous read. ErrConflict is returned otherwise
+func (o *OperationsR4Service) DeleteVersion(resourceID, versionID string, options ...OptionFunc) (bool, *Response, error) {
+	return o.Delete(resourceID, append([]OptionFunc{WithIfMatch(versionID)}, options...)...)
+}
+
 // Delete removes a FHIR resource
 func (o *OperationsR4Service) Delete(resourceID string, options ...OptionFunc) (bool, *Response, error) {
 	req, err := o.client.newCDRRequest(http.MethodDelete, resourceID, nil, append([]OptionFunc{
 		func(req *http.Request) error {
-			req.Header.Set("Content-Type", "application/fhir+json;fhirVersion=4.0")
+			req.Header.Set("Content-Type", r4MediaType)
 			return nil
 		},
 	}, options...))
 	if err != nil {
 		return false, nil, err
 	}
-	req.Header.Set("Accept", "application/fhir+json;fhirVersion=4.0")
+	req.Header.Set("Accept", r4MediaType)
 	var operationResponse bytes.Buffer
 	resp, err := o.client.do(req, &operationResponse)
 	if (err != nil && err != io.EOF) || resp == nil {
@@ -111,14 +134,14 @@ func (o *OperationsR4Service) Delete(resourceID string, options ...OptionFunc) (
 func (o *OperationsR4Service) postOrPut(method, resourceID string, jsonBody []byte, options ...OptionFunc) (*r4pb.ContainedResource, *Response, error) {
 	req, err := o.client.newCDRRequest(method, resourceID, jsonBody, append([]OptionFunc{
 		func(req *http.Request) error {
-			req.Header.Set("Content-Type", "application/fhir+json;fhirVersion=4.0")
+			req.Header.Set("Content-Type", r4MediaType)
 			return nil
 		},
 	}, options...))
 	if err != nil {
 		return nil, nil, err
 	}
-	req.Header.Set("Accept", "application/fhir+json;fhirVersion=4.0")
+	req.Header.Set("Accept", r4MediaType)
 	var operationResponse bytes.Buffer
 	resp, err := o.client.do(req, &operationResponse)
 	if (err != nil && err != io.EOF) || resp == nil {
@@ -3,6 +3,7 @@ package cdr_test
 import (
 	"io"
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/google/fhir/go/jsonformat"
@@ -233,3 +234,126 @@ func TestR4DeleteOperation(t *testing.T) {
 	}
 	assert.True(t, ok)
 }
+
+func TestR4FHIRPathPatchOperation(t *testing.T) {
+	teardown := setup(t, jsonformat.R4)
+	defer teardown()
+
+	orgID := "f5fe538f-c3b5-4454-8774-cd3789f59b9f"
+	versionID := "6dfa7cc8-2000-11ea-91df-bb500f85c5e2"
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Organization/"+orgID, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if !assert.Equal(t, "application/fhir+json;fhirVersion=4.0", r.Header.Get("Content-Type")) {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		if r.Header.Get("If-Match") != `W/"`+versionID+`"` {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/fhir+json;fhirVersion=4.0")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{
+  "resourceType": "Organization",
+  "id": "`+orgID+`",
+  "meta": {
+    "versionId": "7c2f4c0e-2000-11ea-91df-bb500f85c5e2"
+  },
+  "active": true,
+  "name": "Hospital2"
+}`)
+	})
+	parameters := []byte(`{
+  "resourceType": "Parameters",
+  "parameter": [{
+    "name": "operation",
+    "part": [
+      {"name": "type", "valueCode": "replace"},
+      {"name": "path", "valueString": "Organization.name"},
+      {"name": "value", "valueString": "Hospital2"}
+    ]
+  }]
+}`)
+	_, _, err := cdrClient.OperationsR4.FHIRPathPatch("Organization/"+orgID, parameters, cdr.WithIfMatch(""))
+	assert.ErrorIs(t, err, cdr.ErrMissingVersionID)
+
+	_, resp, err := cdrClient.OperationsR4.FHIRPathPatch("Organization/"+orgID, parameters, cdr.WithIfMatch("stale"))
+	assert.NotNil(t, err)
+	if assert.NotNil(t, resp) {
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+	}
+
+	patched, resp, err := cdrClient.OperationsR4.FHIRPathPatch("Organization/"+orgID, parameters, cdr.WithIfMatch(versionID))
+	if !assert.Nil(t, err) {
+		return
+	}
+	if !assert.NotNil(t, resp) || !assert.NotNil(t, patched) {
+		return
+	}
+	assert.Equal(t, "Hospital2", patched.GetOrganization().GetName().GetValue())
+}
+
+func TestR4ConditionalOperations(t *testing.T) {
+	teardown := setup(t, jsonformat.R4)
+	defer teardown()
+
+	identifier := "https://identity.philips-healthsuite.com/organization|dae89cf0-888d-4a26-8c1d-578e97365efc"
+	org := `{
+  "resourceType": "Organization",
+  "identifier": [
+    {
+      "system": "https://identity.philips-healthsuite.com/organization",
+      "value": "dae89cf0-888d-4a26-8c1d-578e97365efc"
+    }
+  ],
+  "name": "Hospital"
+}`
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Organization", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/fhir+json;fhirVersion=4.0")
+		switch r.Method {
+		case "POST":
+			criteria, err := url.ParseQuery(r.Header.Get("If-None-Exist"))
+			if !assert.Nil(t, err) || !assert.Equal(t, identifier, criteria.Get("identifier")) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			// Matching resource exists already
+			w.WriteHeader(http.StatusOK)
+		case "PUT":
+			if !assert.Equal(t, identifier, r.URL.Query().Get("identifier")) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, `{"resourceType": "Organization", "id": "new", "name": "Hospital"}`)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	criteria := url.Values{"identifier": []string{identifier}}
+
+	_, _, err := cdrClient.OperationsR4.Post("Organization", []byte(org), cdr.WithIfNoneExist(nil))
+	assert.ErrorIs(t, err, cdr.ErrMissingSearchCriteria)
+
+	created, resp, err := cdrClient.OperationsR4.Post("Organization", []byte(org), cdr.WithIfNoneExist(criteria))
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotNil(t, created)
+
+	_, _, err = cdrClient.OperationsR4.Put("Organization", []byte(org), cdr.WithConditionalUpdate(url.Values{}))
+	assert.ErrorIs(t, err, cdr.ErrMissingSearchCriteria)
+
+	updated, resp, err := cdrClient.OperationsR4.Put("Organization", []byte(org), cdr.WithConditionalUpdate(criteria))
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "new", updated.GetOrganization().GetId().GetValue())
+}
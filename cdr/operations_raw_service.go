@@ -0,0 +1,103 @@
+package cdr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// OperationsRawService operates on FHIR resources as plain JSON, without
+// decoding them into FHIR protos. Use it for resources or extensions the
+// proto model cannot represent
+type OperationsRawService struct {
+	client *Client
+}
+
+// Get returns a FHIR resource, e.g. "Patient/123"
+func (o *OperationsRawService) Get(resourceID string, options ...OptionFunc) (json.RawMessage, *Response, error) {
+	return o.do(http.MethodGet, resourceID, nil, options...)
+}
+
+// Create creates a new FHIR resource of resourceType
+func (o *OperationsRawService) Create(resourceType string, jsonBody []byte, options ...OptionFunc) (json.RawMessage, *Response, error) {
+	return o.do(http.MethodPost, resourceType, jsonBody, options...)
+}
+
+// Update creates or updates the FHIR resource, e.g. "Patient/123".
+// When jsonBody carries a meta.versionId it is sent as If-Match precondition, so the
+// update fails with ErrConflict when the resource was modified since it was read.
+// Use WithoutIfMatch to overwrite the resource regardless
+func (o *OperationsRawService) Update(resourceID string, jsonBody []byte, options ...OptionFunc) (json.RawMessage, *Response, error) {
+	return o.do(http.MethodPut, resourceID, jsonBody, options...)
+}
+
+// DeleteVersion removes a FHIR resource only when versionID is its current version,
+// e.g. the Response.VersionID of a previous read. ErrConflict is returned otherwise
+func (o *OperationsRawService) DeleteVersion(resourceID, versionID string, options ...OptionFunc) (bool, *Response, error) {
+	return o.Delete(resourceID, append([]OptionFunc{WithIfMatch(versionID)}, options...)...)
+}
+
+// Delete removes a FHIR resource
+func (o *OperationsRawService) Delete(resourceID string, options ...OptionFunc) (bool, *Response, error) {
+	_, resp, err := o.do(http.MethodDelete, resourceID, nil, options...)
+	if err != nil {
+		return false, resp, err
+	}
+	return resp.StatusCode == http.StatusNoContent, resp, nil
+}
+
+// Search returns the search result bundle of resourceType matching query.
+// Use WithSearchOptions to add includes, element filters and paging parameters
+func (o *OperationsRawService) Search(resourceType string, query url.Values, options ...OptionFunc) (json.RawMessage, *Response, error) {
+	return o.do(http.MethodGet, resourceType, nil, append([]OptionFunc{
+		func(req *http.Request) error {
+			req.URL.RawQuery = query.Encode()
+			return nil
+		},
+	}, options...)...)
+}
+
+// GetMap returns a FHIR resource decoded into a generic map
+func (o *OperationsRawService) GetMap(resourceID string, options ...OptionFunc) (map[string]interface{}, *Response, error) {
+	raw, resp, err := o.Get(resourceID, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	var resource map[string]interface{}
+	if err := json.Unmarshal(raw, &resource); err != nil {
+		return nil, resp, fmt.Errorf("JSON unmarshal: %w", err)
+	}
+	return resource, resp, nil
+}
+
+func (o *OperationsRawService) do(method, resourceID string, jsonBody []byte, options ...OptionFunc) (json.RawMessage, *Response, error) {
+	return o.doWithMediaType(method, resourceID, jsonBody, o.client.MediaType(), options...)
+}
+
+func (o *OperationsRawService) doWithMediaType(method, resourceID string, jsonBody []byte, mediaType string, options ...OptionFunc) (json.RawMessage, *Response, error) {
+	req, err := o.client.newCDRRequest(method, resourceID, jsonBody, append([]OptionFunc{
+		func(req *http.Request) error {
+			req.Header.Set("Content-Type", mediaType)
+			return nil
+		},
+	}, options...))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", mediaType)
+	var operationResponse bytes.Buffer
+	resp, err := o.client.do(req, &operationResponse)
+	if (err != nil && err != io.EOF) || resp == nil {
+		if resp == nil && err != nil {
+			err = fmt.Errorf("OperationsRawService %s: %w", method, ErrEmptyResult)
+		}
+		return nil, resp, err
+	}
+	if operationResponse.Len() == 0 { // Empty body
+		return nil, resp, nil
+	}
+	return operationResponse.Bytes(), resp, nil
+}
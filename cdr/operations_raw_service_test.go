@@ -0,0 +1,213 @@
+package cdr_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/fhir/go/jsonformat"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawOperations(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	patientID := "1c3a6e4b-5e7e-4a0a-9c53-2a7d3c5f0c1e"
+	patient := `{"resourceType":"Patient","id":"` + patientID + `","extension":[{"url":"https://example.com/custom","valueString":"foo"}]}`
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient/"+patientID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/fhir+json", r.Header.Get("Accept"))
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Content-Type", "application/fhir+json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, patient)
+		case "PUT":
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/fhir+json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(body)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/fhir+json")
+		switch r.Method {
+		case "POST":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, patient)
+		case "GET":
+			assert.Equal(t, "foo", r.URL.Query().Get("name"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{"resourceType":"Bundle","type":"searchset","total":1,"entry":[{"resource":`+patient+`}]}`)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	raw, resp, err := cdrClient.OperationsRaw.Get("Patient/" + patientID)
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) {
+		return
+	}
+	assert.JSONEq(t, patient, string(raw))
+
+	resource, _, err := cdrClient.OperationsRaw.GetMap("Patient/" + patientID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "Patient", resource["resourceType"])
+
+	created, resp, err := cdrClient.OperationsRaw.Create("Patient", []byte(patient))
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.JSONEq(t, patient, string(created))
+
+	updated, _, err := cdrClient.OperationsRaw.Update("Patient/"+patientID, []byte(patient))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.JSONEq(t, patient, string(updated))
+
+	bundle, _, err := cdrClient.OperationsRaw.Search("Patient", url.Values{"name": []string{"foo"}})
+	if !assert.Nil(t, err) {
+		return
+	}
+	var result struct {
+		Total int `json:"total"`
+	}
+	assert.Nil(t, json.Unmarshal(bundle, &result))
+	assert.Equal(t, 1, result.Total)
+
+	ok, _, err := cdrClient.OperationsRaw.Delete("Patient/" + patientID)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestOptimisticConcurrency(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	patientID := "1c3a6e4b-5e7e-4a0a-9c53-2a7d3c5f0c1e"
+	currentVersion := "2"
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient/"+patientID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/fhir+json")
+		switch r.Method {
+		case "GET":
+			w.Header().Set("ETag", `W/"`+currentVersion+`"`)
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{"resourceType":"Patient","id":"`+patientID+`","meta":{"versionId":"`+currentVersion+`"}}`)
+		case "PUT", "DELETE":
+			if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != `W/"`+currentVersion+`"` {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				_, _ = io.WriteString(w, `{"resourceType":"OperationOutcome","issue":[{"severity":"error","code":"conflict"}]}`)
+				return
+			}
+			if r.Method == "DELETE" {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("ETag", `W/"3"`)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(body)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	_, resp, err := cdrClient.OperationsRaw.Get("Patient/" + patientID)
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Equal(t, `W/"2"`, resp.ETag())
+	assert.Equal(t, "2", resp.VersionID())
+
+	stale := []byte(`{"resourceType":"Patient","id":"` + patientID + `","meta":{"versionId":"1"}}`)
+	_, resp, err = cdrClient.OperationsRaw.Update("Patient/"+patientID, stale)
+	assert.ErrorIs(t, err, cdr.ErrConflict)
+	if assert.NotNil(t, resp) {
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+	}
+
+	_, resp, err = cdrClient.OperationsRaw.Update("Patient/"+patientID, stale, cdr.WithoutIfMatch())
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "3", resp.VersionID())
+
+	fresh := []byte(`{"resourceType":"Patient","id":"` + patientID + `","meta":{"versionId":"2"}}`)
+	_, _, err = cdrClient.OperationsR4.Put("Patient/"+patientID, fresh)
+	assert.Nil(t, err)
+
+	_, _, err = cdrClient.OperationsRaw.Delete("Patient/"+patientID, cdr.WithIfMatch("1"))
+	assert.ErrorIs(t, err, cdr.ErrConflict)
+	ok, _, err := cdrClient.OperationsRaw.Delete("Patient/"+patientID, cdr.WithIfMatch(currentVersion))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	// Deleting a version read before fails once the resource was modified
+	_, resp, err = cdrClient.OperationsRaw.Get("Patient/" + patientID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	read := resp.VersionID()
+	currentVersion = "4"
+	for _, deleteVersion := range []func(string, string, ...cdr.OptionFunc) (bool, *cdr.Response, error){
+		cdrClient.OperationsRaw.DeleteVersion,
+		cdrClient.OperationsSTU3.DeleteVersion,
+		cdrClient.OperationsR4.DeleteVersion,
+	} {
+		ok, resp, err = deleteVersion("Patient/"+patientID, read)
+		assert.ErrorIs(t, err, cdr.ErrConflict)
+		assert.False(t, ok)
+		if assert.NotNil(t, resp) {
+			assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+		}
+	}
+	ok, _, err = cdrClient.OperationsRaw.DeleteVersion("Patient/"+patientID, currentVersion)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestPrefer(t *testing.T) {
+	teardown := setup(t, jsonformat.R4)
+	defer teardown()
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "return=minimal, handling=strict", r.Header.Get("Prefer"))
+		w.Header().Set("Location", serverCDR.URL+"/store/fhir/"+cdrOrgID+"/Patient/123/_history/1")
+		w.WriteHeader(http.StatusCreated)
+	})
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient/123", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "return=minimal", r.Header.Get("Prefer"))
+		w.Header().Set("ETag", `W/"2"`)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	raw, resp, err := cdrClient.OperationsRaw.Create("Patient", []byte(`{"resourceType":"Patient"}`),
+		cdr.WithPrefer(cdr.PreferReturnMinimal, cdr.PreferHandlingStrict))
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Nil(t, raw)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	contained, resp, err := cdrClient.OperationsR4.Patch("Patient/123", []byte(`[{"op":"replace","path":"/active","value":true}]`),
+		cdr.WithPrefer(cdr.PreferReturnMinimal))
+	if !assert.Nil(t, err) || !assert.NotNil(t, contained) {
+		return
+	}
+	assert.Equal(t, "2", resp.VersionID())
+}
@@ -17,7 +17,8 @@ type OperationsSTU3Service struct {
 	um       *jsonformat.Unmarshaller
 }
 
-// Patch makes changes to a FHIR resources accepting the JSONPatch format set
+// Patch makes changes to a FHIR resources accepting the JSONPatch format set.
+// Use WithIfMatch to only apply the patch to a specific version of the resource
 func (o *OperationsSTU3Service) Patch(resourceID string, jsonPatch []byte, options ...OptionFunc) (*stu3pb.ContainedResource, *Response, error) {
 	req, err := o.client.newCDRRequest(http.MethodPatch, resourceID, jsonPatch, append([]OptionFunc{
 		func(req *http.Request) error {
@@ -37,6 +38,9 @@ func (o *OperationsSTU3Service) Patch(resourceID string, jsonPatch []byte, optio
 		}
 		return nil, resp, err
 	}
+	if patchResponse.Len() == 0 { // Empty body, e.g. Prefer: return=minimal
+		return &stu3pb.ContainedResource{}, resp, nil
+	}
 	unmarshalled, err := o.um.Unmarshal(patchResponse.Bytes())
 	if err != nil {
 		return nil, resp, fmt.Errorf("FHIR unmarshal: %w", err)
@@ -50,7 +54,10 @@ func (o *OperationsSTU3Service) Post(resourceID string, jsonBody []byte, options
 	return o.postOrPut(http.MethodPost, resourceID, jsonBody, options...)
 }
 
-// Put creates or updates new FHIR resources
+// Put creates or updates new FHIR resources.
+// When jsonBody carries a meta.versionId it is sent as If-Match precondition, so the
+// update fails with ErrConflict when the resource was modified since it was read.
+// Use WithoutIfMatch to overwrite the resource regardless
 func (o *OperationsSTU3Service) Put(resourceID string, jsonBody []byte, options ...OptionFunc) (*stu3pb.ContainedResource, *Response, error) {
 	return o.postOrPut(http.MethodPut, resourceID, jsonBody, options...)
 }
@@ -83,6 +90,12 @@ func (o *OperationsSTU3Service) Get(resourceID string, options ...OptionFunc) (*
 	return contained, resp, nil
 }
 
+// DeleteVersion removes a FHIR resource only when versionID is its current version,
+// e.g. the Response.VersionID of a previous read. ErrConflict is returned otherwise
+func (o *OperationsSTU3Service) DeleteVersion(resourceID, versionID string, options ...OptionFunc) (bool, *Response, error) {
+	return o.Delete(resourceID, append([]OptionFunc{WithIfMatch(versionID)}, options...)...)
+}
+
 // Delete removes a FHIR resource
 func (o *OperationsSTU3Service) Delete(resourceID string, options ...OptionFunc) (bool, *Response, error) {
 	req, err := o.client.newCDRRequest(http.MethodDelete, resourceID, nil, append([]OptionFunc{
@@ -0,0 +1,167 @@
+package cdr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/google/fhir/go/jsonformat"
+	r4pb "github.com/google/fhir/go/proto/google/fhir/proto/r4/core/resources/bundle_and_contained_resource_go_proto"
+	r4patient "github.com/google/fhir/go/proto/google/fhir/proto/r4/core/resources/patient_go_proto"
+)
+
+// Link types of Patient.link
+const (
+	LinkTypeReplacedBy = "replaced-by"
+	LinkTypeReplaces   = "replaces"
+	LinkTypeRefer      = "refer"
+	LinkTypeSeeAlso    = "seealso"
+)
+
+// PatientsR4Service provides typed helpers for FHIR R4 Patient resources
+type PatientsR4Service struct {
+	client   *Client
+	timeZone string
+	ma       *jsonformat.Marshaller
+	um       *jsonformat.Unmarshaller
+}
+
+// GetPatient returns the Patient with the given id
+func (p *PatientsR4Service) GetPatient(id string, options ...OptionFunc) (*r4patient.Patient, *Response, error) {
+	if id == "" {
+		return nil, nil, ErrMissingResourceID
+	}
+	contained, resp, err := p.client.OperationsR4.Get("Patient/"+id, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	patient := contained.GetPatient()
+	if patient == nil {
+		return nil, resp, fmt.Errorf("Patient/%s: %w", id, ErrResourceNotFound)
+	}
+	return patient, resp, nil
+}
+
+// GetPatientByIdentifier returns the single Patient with the given identifier.
+// ErrResourceNotFound or ErrMultipleMatches is returned when there is no unique match
+func (p *PatientsR4Service) GetPatientByIdentifier(ctx context.Context, system, value string, options ...OptionFunc) (*r4patient.Patient, error) {
+	resources, err := p.client.searchR4(ctx, p.um, "Patient", identifierQuery(system, value), options...)
+	if err != nil {
+		return nil, err
+	}
+	var patients []*r4patient.Patient
+	for _, r := range resources {
+		if patient := r.GetPatient(); patient != nil {
+			patients = append(patients, patient)
+		}
+	}
+	switch len(patients) {
+	case 0:
+		return nil, ErrResourceNotFound
+	case 1:
+		return patients[0], nil
+	}
+	return nil, fmt.Errorf("%d patients: %w", len(patients), ErrMultipleMatches)
+}
+
+// LinkPatient adds a link of linkType, e.g. LinkTypeSeeAlso, from the patient to the other patient
+func (p *PatientsR4Service) LinkPatient(patientID, otherPatientID, linkType string, options ...OptionFunc) (*r4patient.Patient, *Response, error) {
+	if otherPatientID == "" {
+		return nil, nil, ErrMissingResourceID
+	}
+	switch linkType {
+	case LinkTypeReplacedBy, LinkTypeReplaces, LinkTypeRefer, LinkTypeSeeAlso:
+	default:
+		return nil, nil, fmt.Errorf("link type [%s]: %w", linkType, ErrInvalidLinkType)
+	}
+	return p.updateLinks(patientID, func(links []interface{}) []interface{} {
+		for _, link := range links {
+			if linkOther(link) == "Patient/"+otherPatientID {
+				return links
+			}
+		}
+		return append(links, map[string]interface{}{
+			"other": map[string]interface{}{"reference": "Patient/" + otherPatientID},
+			"type":  linkType,
+		})
+	}, options...)
+}
+
+// UnlinkPatient removes all links from the patient to the other patient
+func (p *PatientsR4Service) UnlinkPatient(patientID, otherPatientID string, options ...OptionFunc) (*r4patient.Patient, *Response, error) {
+	if otherPatientID == "" {
+		return nil, nil, ErrMissingResourceID
+	}
+	return p.updateLinks(patientID, func(links []interface{}) []interface{} {
+		kept := make([]interface{}, 0, len(links))
+		for _, link := range links {
+			if linkOther(link) != "Patient/"+otherPatientID {
+				kept = append(kept, link)
+			}
+		}
+		return kept
+	}, options...)
+}
+
+// updateLinks reads the patient as JSON, so elements unknown to the protos survive,
+// and writes it back using the version read for optimistic concurrency
+func (p *PatientsR4Service) updateLinks(patientID string, update func([]interface{}) []interface{}, options ...OptionFunc) (*r4patient.Patient, *Response, error) {
+	if patientID == "" {
+		return nil, nil, ErrMissingResourceID
+	}
+	raw, resp, err := p.client.OperationsRaw.doWithMediaType(http.MethodGet, "Patient/"+patientID, nil, r4MediaType, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	var resource map[string]interface{}
+	if err := json.Unmarshal(raw, &resource); err != nil {
+		return nil, resp, fmt.Errorf("JSON unmarshal: %w", err)
+	}
+	links, _ := resource["link"].([]interface{})
+	links = update(links)
+	if len(links) == 0 {
+		delete(resource, "link")
+	} else {
+		resource["link"] = links
+	}
+	body, err := json.Marshal(resource)
+	if err != nil {
+		return nil, resp, err
+	}
+	contained, resp, err := p.client.OperationsR4.Put("Patient/"+patientID, body, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	return contained.GetPatient(), resp, nil
+}
+
+func linkOther(link interface{}) string {
+	l, _ := link.(map[string]interface{})
+	other, _ := l["other"].(map[string]interface{})
+	reference, _ := other["reference"].(string)
+	return reference
+}
+
+func identifierQuery(system, value string) url.Values {
+	identifier := value
+	if system != "" {
+		identifier = system + "|" + value
+	}
+	return url.Values{"identifier": []string{identifier}}
+}
+
+// searchR4 collects all R4 resources matching the query
+func (c *Client) searchR4(ctx context.Context, um *jsonformat.Unmarshaller, resourceType string, query url.Values, options ...OptionFunc) ([]*r4pb.ContainedResource, error) {
+	it := c.bundleIterator(ctx, resourceType, query, options...)
+	it.um = um
+	it.mediaType = r4MediaType
+	var resources []*r4pb.ContainedResource
+	for it.Next() {
+		if contained, ok := it.Resource().(*r4pb.ContainedResource); ok {
+			resources = append(resources, contained)
+		}
+	}
+	return resources, it.Err()
+}
@@ -0,0 +1,132 @@
+package cdr_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/fhir/go/jsonformat"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatientsR4Service(t *testing.T) {
+	teardown := setup(t, jsonformat.R4)
+	defer teardown()
+
+	patient := map[string]interface{}{
+		"resourceType": "Patient",
+		"id":           "123",
+		"meta":         map[string]interface{}{"versionId": "1"},
+		"identifier":   []interface{}{map[string]interface{}{"system": "urn:mrn", "value": "MRN1"}},
+	}
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/fhir+json;fhirVersion=4.0", r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", "application/fhir+json;fhirVersion=4.0")
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Query().Get("identifier") {
+		case "urn:mrn|MRN1":
+			body, _ := json.Marshal(patient)
+			_, _ = io.WriteString(w, `{"resourceType":"Bundle","type":"searchset","entry":[{"resource":`+string(body)+`}]}`)
+		case "urn:mrn|DUP":
+			_, _ = io.WriteString(w, `{"resourceType":"Bundle","type":"searchset","entry":[
+  {"resource":{"resourceType":"Patient","id":"1"}},
+  {"resource":{"resourceType":"Patient","id":"2"}}
+]}`)
+		default:
+			_, _ = io.WriteString(w, `{"resourceType":"Bundle","type":"searchset"}`)
+		}
+	})
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/fhir+json;fhirVersion=4.0")
+		switch r.Method {
+		case http.MethodGet:
+			body, _ := json.Marshal(patient)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(body)
+		case http.MethodPut:
+			assert.Equal(t, `W/"1"`, r.Header.Get("If-Match"))
+			var updated map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+			if links, ok := updated["link"]; ok {
+				patient["link"] = links
+			} else {
+				delete(patient, "link")
+			}
+			body, _ := json.Marshal(patient)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(body)
+		}
+	})
+
+	found, err := cdrClient.PatientsR4.GetPatientByIdentifier(context.Background(), "urn:mrn", "MRN1")
+	if !assert.Nil(t, err) || !assert.NotNil(t, found) {
+		return
+	}
+	assert.Equal(t, "123", found.GetId().GetValue())
+
+	_, err = cdrClient.PatientsR4.GetPatientByIdentifier(context.Background(), "urn:mrn", "NONE")
+	assert.ErrorIs(t, err, cdr.ErrResourceNotFound)
+	_, err = cdrClient.PatientsR4.GetPatientByIdentifier(context.Background(), "urn:mrn", "DUP")
+	assert.ErrorIs(t, err, cdr.ErrMultipleMatches)
+
+	_, _, err = cdrClient.PatientsR4.LinkPatient("123", "456", "sibling")
+	assert.ErrorIs(t, err, cdr.ErrInvalidLinkType)
+
+	linked, _, err := cdrClient.PatientsR4.LinkPatient("123", "456", cdr.LinkTypeSeeAlso)
+	if !assert.Nil(t, err) || !assert.NotNil(t, linked) {
+		return
+	}
+	if assert.Len(t, linked.GetLink(), 1) {
+		assert.Equal(t, "456", linked.GetLink()[0].GetOther().GetPatientId().GetValue())
+	}
+
+	unlinked, _, err := cdrClient.PatientsR4.UnlinkPatient("123", "456")
+	if !assert.Nil(t, err) || !assert.NotNil(t, unlinked) {
+		return
+	}
+	assert.Len(t, unlinked.GetLink(), 0)
+
+	got, _, err := cdrClient.PatientsR4.GetPatient("123")
+	if assert.Nil(t, err) {
+		assert.Equal(t, "MRN1", got.GetIdentifier()[0].GetValue().GetValue())
+	}
+}
+
+func TestPractitionersR4Service(t *testing.T) {
+	teardown := setup(t, jsonformat.R4)
+	defer teardown()
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Practitioner", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/fhir+json;fhirVersion=4.0")
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("name") == "Swanson" {
+			_, _ = io.WriteString(w, `{"resourceType":"Bundle","type":"searchset","entry":[
+  {"resource":{"resourceType":"Practitioner","id":"1","name":[{"family":"Swanson","given":["Ron"]}]}},
+  {"resource":{"resourceType":"Practitioner","id":"2","name":[{"family":"Swanson","given":["Tammy"]}]}}
+]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"resourceType":"Bundle","type":"searchset","entry":[
+  {"resource":{"resourceType":"Practitioner","id":"1","identifier":[{"system":"urn:npi","value":"42"}]}}
+]}`)
+	})
+
+	practitioners, err := cdrClient.PractitionersR4.FindPractitionersByName(context.Background(), "Swanson")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, practitioners, 2)
+	_, err = cdrClient.PractitionersR4.FindPractitionersByName(context.Background(), "")
+	assert.ErrorIs(t, err, cdr.ErrMissingSearchCriteria)
+
+	practitioner, err := cdrClient.PractitionersR4.GetPractitionerByIdentifier(context.Background(), "urn:npi", "42")
+	if assert.Nil(t, err) {
+		assert.Equal(t, "1", practitioner.GetId().GetValue())
+	}
+}
@@ -0,0 +1,73 @@
+package cdr
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/google/fhir/go/jsonformat"
+	r4pprac "github.com/google/fhir/go/proto/google/fhir/proto/r4/core/resources/practitioner_go_proto"
+)
+
+// PractitionersR4Service provides typed helpers for FHIR R4 Practitioner resources
+type PractitionersR4Service struct {
+	client   *Client
+	timeZone string
+	ma       *jsonformat.Marshaller
+	um       *jsonformat.Unmarshaller
+}
+
+// GetPractitioner returns the Practitioner with the given id
+func (p *PractitionersR4Service) GetPractitioner(id string, options ...OptionFunc) (*r4pprac.Practitioner, *Response, error) {
+	if id == "" {
+		return nil, nil, ErrMissingResourceID
+	}
+	contained, resp, err := p.client.OperationsR4.Get("Practitioner/"+id, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	practitioner := contained.GetPractitioner()
+	if practitioner == nil {
+		return nil, resp, fmt.Errorf("Practitioner/%s: %w", id, ErrResourceNotFound)
+	}
+	return practitioner, resp, nil
+}
+
+// GetPractitionerByIdentifier returns the single Practitioner with the given identifier.
+// ErrResourceNotFound or ErrMultipleMatches is returned when there is no unique match
+func (p *PractitionersR4Service) GetPractitionerByIdentifier(ctx context.Context, system, value string, options ...OptionFunc) (*r4pprac.Practitioner, error) {
+	practitioners, err := p.find(ctx, identifierQuery(system, value), options...)
+	if err != nil {
+		return nil, err
+	}
+	switch len(practitioners) {
+	case 0:
+		return nil, ErrResourceNotFound
+	case 1:
+		return practitioners[0], nil
+	}
+	return nil, fmt.Errorf("%d practitioners: %w", len(practitioners), ErrMultipleMatches)
+}
+
+// FindPractitionersByName returns all practitioners whose name matches, following
+// the FHIR string search semantics of the name parameter
+func (p *PractitionersR4Service) FindPractitionersByName(ctx context.Context, name string, options ...OptionFunc) ([]*r4pprac.Practitioner, error) {
+	if name == "" {
+		return nil, ErrMissingSearchCriteria
+	}
+	return p.find(ctx, url.Values{"name": []string{name}}, options...)
+}
+
+func (p *PractitionersR4Service) find(ctx context.Context, query url.Values, options ...OptionFunc) ([]*r4pprac.Practitioner, error) {
+	resources, err := p.client.searchR4(ctx, p.um, "Practitioner", query, options...)
+	if err != nil {
+		return nil, err
+	}
+	var practitioners []*r4pprac.Practitioner
+	for _, r := range resources {
+		if practitioner := r.GetPractitioner(); practitioner != nil {
+			practitioners = append(practitioners, practitioner)
+		}
+	}
+	return practitioners, nil
+}
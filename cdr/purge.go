@@ -0,0 +1,56 @@
+package cdr
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// PurgeOptions gates the irreversible removal of data
+type PurgeOptions struct {
+	// Confirmed must be set to acknowledge the data is removed permanently,
+	// including its version history
+	Confirmed bool
+}
+
+// WithHardDelete turns a Delete into a hard delete: the resource and all of its
+// versions are removed instead of only marking it deleted. This cannot be undone
+func WithHardDelete() OptionFunc {
+	return func(req *http.Request) error {
+		if req.Method != http.MethodDelete {
+			return fmt.Errorf("hard delete of %s request: %w", req.Method, ErrPurgeNotAllowed)
+		}
+		q := req.URL.Query()
+		q.Set("_purge", "true")
+		req.URL.RawQuery = q.Encode()
+		return nil
+	}
+}
+
+// PurgePatient permanently removes the patient and all resources in its compartment
+// using Patient/$purge, e.g. for GDPR erasure requests. opts.Confirmed must be set
+func (c *Client) PurgePatient(patientID string, opts PurgeOptions, options ...OptionFunc) (bool, *Response, error) {
+	if patientID == "" {
+		return false, nil, ErrMissingResourceID
+	}
+	if !opts.Confirmed {
+		return false, nil, ErrPurgeNotConfirmed
+	}
+	req, err := c.newCDRRequest(http.MethodPost, "Patient/"+patientID+"/$purge", nil, options)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("Accept", c.MediaType())
+	resp, err := c.do(req, io.Discard)
+	if err != nil || resp == nil {
+		if resp == nil && err == nil {
+			err = fmt.Errorf("PurgePatient: %w", ErrEmptyResult)
+		}
+		return false, resp, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent:
+		return true, resp, nil
+	}
+	return false, resp, fmt.Errorf("PurgePatient: HTTP %d", resp.StatusCode)
+}
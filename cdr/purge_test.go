@@ -0,0 +1,51 @@
+package cdr_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/fhir/go/jsonformat"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPurge(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	patientID := "1c3a6e4b-5e7e-4a0a-9c53-2a7d3c5f0c1e"
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient/"+patientID+"/$purge", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, http.MethodPost, r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Observation/123", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, http.MethodDelete, r.Method) || !assert.Equal(t, "true", r.URL.Query().Get("_purge")) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ok, _, err := cdrClient.PurgePatient(patientID, cdr.PurgeOptions{})
+	assert.ErrorIs(t, err, cdr.ErrPurgeNotConfirmed)
+	assert.False(t, ok)
+
+	ok, resp, err := cdrClient.PurgePatient(patientID, cdr.PurgeOptions{Confirmed: true})
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) {
+		return
+	}
+	assert.True(t, ok)
+
+	ok, _, err = cdrClient.OperationsRaw.Delete("Observation/123", cdr.WithHardDelete())
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	_, _, err = cdrClient.OperationsRaw.Get("Observation/123", cdr.WithHardDelete())
+	assert.ErrorIs(t, err, cdr.ErrPurgeNotAllowed)
+}
@@ -0,0 +1,112 @@
+package cdr
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	protov1 "github.com/golang/protobuf/proto"
+)
+
+const (
+	defaultGetResourcesConcurrency = 8
+)
+
+// GetResourcesOptions configures GetResources
+type GetResourcesOptions struct {
+	// Concurrency is the maximum number of resources fetched in parallel. Defaults to 8
+	Concurrency int
+}
+
+// GetResourceResult holds the outcome of a single read of GetResources
+type GetResourceResult struct {
+	Reference string
+	// Resource is a ContainedResource of the configured FHIR version
+	Resource protov1.Message
+	Response *Response
+	Err      error
+}
+
+// ResourceError is the failure to read a single resource
+type ResourceError struct {
+	Reference string
+	Err       error
+}
+
+func (e *ResourceError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Reference, e.Err)
+}
+
+func (e *ResourceError) Unwrap() error {
+	return e.Err
+}
+
+// ResourceErrors aggregates the per-resource errors of a bulk operation
+type ResourceErrors []*ResourceError
+
+func (e ResourceErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("%d resource(s) failed: %s", len(e), strings.Join(messages, "; "))
+}
+
+// GetResources reads the referenced resources, e.g. "Patient/123", using a bounded pool
+// of workers. The results are returned in the order of references. When one or more
+// reads fail the returned error is of type ResourceErrors
+func (c *Client) GetResources(references []string, opts GetResourcesOptions, options ...OptionFunc) ([]GetResourceResult, error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultGetResourcesConcurrency
+	}
+	results := make([]GetResourceResult, len(references))
+	for i, reference := range references {
+		results[i].Reference = reference
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Concurrency && w < len(references); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i].Resource, results[i].Response, results[i].Err = c.getResource(results[i].Reference, options...)
+			}
+		}()
+	}
+	for i := range references {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var errs ResourceErrors
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, &ResourceError{Reference: r.Reference, Err: r.Err})
+		}
+	}
+	if len(errs) > 0 {
+		return results, errs
+	}
+	return results, nil
+}
+
+func (c *Client) getResource(reference string, options ...OptionFunc) (protov1.Message, *Response, error) {
+	if reference == "" {
+		return nil, nil, ErrMissingResourceID
+	}
+	raw, resp, err := c.OperationsRaw.Get(reference, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(raw) == 0 {
+		return nil, resp, fmt.Errorf("%s: %w", reference, ErrEmptyResult)
+	}
+	resource, err := c.Unmarshaller().Unmarshal(raw)
+	if err != nil {
+		return nil, resp, fmt.Errorf("FHIR unmarshal: %w", err)
+	}
+	return resource, resp, nil
+}
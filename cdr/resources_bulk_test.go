@@ -0,0 +1,67 @@
+package cdr_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/fhir/go/jsonformat"
+	stu3pb "github.com/google/fhir/go/proto/google/fhir/proto/stu3/resources_go_proto"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResources(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	var inFlight, maxInFlight int32
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient/", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		id := strings.TrimPrefix(r.URL.Path, "/store/fhir/"+cdrOrgID+"/Patient/")
+		w.Header().Set("Content-Type", "application/fhir+json")
+		if id == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"resourceType":"Patient","id":"`+id+`"}`)
+	})
+
+	references := []string{"Patient/1", "Patient/2", "Patient/missing", "Patient/4", "Patient/5", "Patient/6"}
+	results, err := cdrClient.GetResources(references, cdr.GetResourcesOptions{Concurrency: 2})
+	var errs cdr.ResourceErrors
+	if !assert.True(t, errors.As(err, &errs)) {
+		return
+	}
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "Patient/missing", errs[0].Reference)
+	if !assert.Len(t, results, len(references)) {
+		return
+	}
+	for i, result := range results {
+		assert.Equal(t, references[i], result.Reference)
+		if result.Reference == "Patient/missing" {
+			assert.NotNil(t, result.Err)
+			continue
+		}
+		if !assert.Nil(t, result.Err) {
+			continue
+		}
+		contained := result.Resource.(*stu3pb.ContainedResource)
+		assert.Equal(t, strings.TrimPrefix(references[i], "Patient/"), contained.GetPatient().GetId().GetValue())
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+}
@@ -0,0 +1,219 @@
+package cdr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	protov1 "github.com/golang/protobuf/proto"
+	"github.com/google/fhir/go/jsonformat"
+)
+
+// SearchIterator walks over all resources matching a FHIR search, following
+// the next links of the search result bundles on demand
+type SearchIterator struct {
+	client    *Client
+	ctx       context.Context
+	options   []OptionFunc
+	um        *jsonformat.Unmarshaller
+	mediaType string
+
+	next    *url.URL
+	fetched bool
+	page    []json.RawMessage
+	index   int
+	total   *int
+
+	current protov1.Message
+	resp    *Response
+	err     error
+}
+
+type searchBundle struct {
+	ResourceType string `json:"resourceType"`
+	Total        *int   `json:"total,omitempty"`
+	Link         []struct {
+		Relation string `json:"relation"`
+		URL      string `json:"url"`
+	} `json:"link"`
+	Entry []searchBundleEntry `json:"entry"`
+}
+
+type searchBundleEntry struct {
+	FullURL  string          `json:"fullUrl,omitempty"`
+	Resource json.RawMessage `json:"resource"`
+	Request  *struct {
+		Method string `json:"method"`
+		URL    string `json:"url"`
+	} `json:"request,omitempty"`
+	Response *struct {
+		Status       string `json:"status"`
+		ETag         string `json:"etag,omitempty"`
+		LastModified string `json:"lastModified,omitempty"`
+	} `json:"response,omitempty"`
+}
+
+// SearchIterator returns an iterator over all resources of resourceType matching query.
+// Use SearchOptions.Values to build query from typed search options.
+// Resources are decoded with the unmarshaller of the configured FHIR version.
+// Iteration stops when ctx is cancelled. The options are applied to the request of
+// every page, but query parameters they add, e.g. by WithSearchOptions, are only
+// sent with the first page as the next links returned by CDR already include them.
+func (c *Client) SearchIterator(ctx context.Context, resourceType string, query url.Values, options ...OptionFunc) *SearchIterator {
+	return c.bundleIterator(ctx, resourceType, query, options...)
+}
+
+func (c *Client) bundleIterator(ctx context.Context, path string, query url.Values, options ...OptionFunc) *SearchIterator {
+	it := &SearchIterator{
+		client:    c,
+		ctx:       ctx,
+		options:   options,
+		um:        c.Unmarshaller(),
+		mediaType: c.MediaType(),
+	}
+	u := *c.fhirStoreURL
+	u.Opaque = c.fhirStoreURL.Path + c.config.RootOrgID + "/" + path
+	u.RawQuery = query.Encode()
+	it.next = &u
+	return it
+}
+
+// Next advances the iterator. It returns false when all resources have been
+// visited or an error occurred. Check Err() to distinguish between the two.
+func (it *SearchIterator) Next() bool {
+	for {
+		if it.err != nil {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		if it.index < len(it.page) {
+			break
+		}
+		if it.next == nil {
+			return false
+		}
+		it.fetch()
+	}
+	resource, err := it.um.Unmarshal(it.page[it.index])
+	it.index++
+	if err != nil {
+		it.err = fmt.Errorf("FHIR unmarshal: %w", err)
+		return false
+	}
+	it.current = resource
+	return true
+}
+
+func (it *SearchIterator) fetch() {
+	bundle, next, resp, err := it.client.fetchBundle(it.ctx, it.next, it.fetched, it.mediaType, it.options)
+	it.fetched = true
+	it.next = next
+	it.resp = resp
+	if err != nil {
+		it.err = err
+		return
+	}
+	if it.total == nil {
+		it.total = bundle.Total
+	}
+	it.page = it.page[:0]
+	it.index = 0
+	for _, entry := range bundle.Entry {
+		if len(entry.Resource) > 0 {
+			it.page = append(it.page, entry.Resource)
+		}
+	}
+}
+
+// fetchBundle reads the bundle at u and returns it together with its next link, if any.
+// The options are applied to every page request. When u is a next link, which already
+// carries the complete search, query parameters added by the options are dropped again
+func (c *Client) fetchBundle(ctx context.Context, u *url.URL, nextLink bool, mediaType string, options []OptionFunc) (*searchBundle, *url.URL, *Response, error) {
+	req, err := c.newCDRRequest(http.MethodGet, "", nil, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	req = req.WithContext(ctx)
+	pageURL := *u
+	req.URL = &pageURL
+	req.Host = u.Host
+	req.Header.Set("Accept", mediaType)
+	for _, fn := range options {
+		if fn == nil {
+			continue
+		}
+		if err := fn(req); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if nextLink {
+		req.URL.RawQuery = u.RawQuery
+	}
+	var searchResponse bytes.Buffer
+	resp, err := c.do(req, &searchResponse)
+	if (err != nil && err != io.EOF) || resp == nil {
+		if resp == nil && err != nil {
+			err = fmt.Errorf("SearchIterator: %w", ErrEmptyResult)
+		}
+		return nil, nil, resp, err
+	}
+	var bundle searchBundle
+	if err := json.Unmarshal(searchResponse.Bytes(), &bundle); err != nil {
+		return nil, nil, resp, fmt.Errorf("search bundle: %w", err)
+	}
+	var next *url.URL
+	for _, link := range bundle.Link {
+		if link.Relation != "next" || link.URL == "" {
+			continue
+		}
+		nextURL, err := u.Parse(link.URL)
+		if err != nil {
+			return nil, nil, resp, fmt.Errorf("next link: %w", err)
+		}
+		// Never send our token to another host
+		if nextURL.Host != c.fhirStoreURL.Host {
+			return nil, nil, resp, fmt.Errorf("next link [%s]: %w", link.URL, ErrInvalidNextLink)
+		}
+		next = nextURL
+	}
+	return &bundle, next, resp, nil
+}
+
+// Resource returns the current resource as a ContainedResource of the configured FHIR version
+func (it *SearchIterator) Resource() protov1.Message {
+	return it.current
+}
+
+// Total returns the total number of matches as reported by the first page, if known
+func (it *SearchIterator) Total() (int, bool) {
+	if it.total == nil {
+		return 0, false
+	}
+	return *it.total, true
+}
+
+// Response returns the response of the last page request
+func (it *SearchIterator) Response() *Response {
+	return it.resp
+}
+
+// Err returns the error, if any, that stopped the iteration
+func (it *SearchIterator) Err() error {
+	return it.err
+}
+
+// All collects the remaining resources of the iterator
+func (it *SearchIterator) All() ([]protov1.Message, error) {
+	var resources []protov1.Message
+	for it.Next() {
+		resources = append(resources, it.Resource())
+	}
+	return resources, it.Err()
+}
@@ -0,0 +1,110 @@
+package cdr_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/fhir/go/jsonformat"
+	r4pb "github.com/google/fhir/go/proto/google/fhir/proto/r4/core/resources/bundle_and_contained_resource_go_proto"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchIterator(t *testing.T) {
+	teardown := setup(t, jsonformat.R4)
+	defer teardown()
+
+	r4Client, err := cdr.NewClient(iamClient, &cdr.Config{
+		CDRURL:      serverCDR.URL + "/store/fhir",
+		RootOrgID:   cdrOrgID,
+		FHIRVersion: cdr.FHIRVersionR4,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	searchURL := serverCDR.URL + "/store/fhir/" + cdrOrgID + "/Organization"
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Organization", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, http.MethodGet, r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		assert.Equal(t, []string{"Hospital"}, r.URL.Query()["name"])
+		w.Header().Set("Content-Type", "application/fhir+json;fhirVersion=4.0")
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Query().Get("_page") {
+		case "":
+			_, _ = io.WriteString(w, `{
+  "resourceType": "Bundle",
+  "type": "searchset",
+  "total": 3,
+  "link": [
+    {"relation": "self", "url": "`+searchURL+`?name=Hospital"},
+    {"relation": "next", "url": "`+searchURL+`?name=Hospital&_page=2"}
+  ],
+  "entry": [
+    {"resource": {"resourceType": "Organization", "id": "1", "name": "Hospital1"}},
+    {"resource": {"resourceType": "Organization", "id": "2", "name": "Hospital2"}}
+  ]
+}`)
+		case "2":
+			_, _ = io.WriteString(w, `{
+  "resourceType": "Bundle",
+  "type": "searchset",
+  "link": [
+    {"relation": "self", "url": "`+searchURL+`?name=Hospital&_page=2"}
+  ],
+  "entry": [
+    {"resource": {"resourceType": "Organization", "id": "3", "name": "Hospital3"}}
+  ]
+}`)
+		case "evil":
+			_, _ = io.WriteString(w, `{
+  "resourceType": "Bundle",
+  "type": "searchset",
+  "link": [
+    {"relation": "next", "url": "https://example.com/Organization?_page=2"}
+  ]
+}`)
+		}
+	})
+	query := url.Values{"name": []string{"Hospital"}}
+
+	it := r4Client.SearchIterator(context.Background(), "Organization", query)
+	var ids []string
+	for it.Next() {
+		contained, ok := it.Resource().(*r4pb.ContainedResource)
+		if !assert.True(t, ok) {
+			return
+		}
+		ids = append(ids, contained.GetOrganization().GetId().GetValue())
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+	total, ok := it.Total()
+	assert.True(t, ok)
+	assert.Equal(t, 3, total)
+
+	// Query options apply to the first page, next links already carry them
+	opts := cdr.SearchOptions{Params: query}
+	resources, err := r4Client.SearchIterator(context.Background(), "Organization", nil, cdr.WithSearchOptions(opts)).All()
+	assert.Nil(t, err)
+	assert.Len(t, resources, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it = r4Client.SearchIterator(ctx, "Organization", query)
+	assert.True(t, it.Next())
+	cancel()
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+
+	evilQuery := url.Values{"name": []string{"Hospital"}, "_page": []string{"evil"}}
+	resources, err = r4Client.SearchIterator(context.Background(), "Organization", evilQuery).All()
+	assert.Len(t, resources, 0)
+	assert.ErrorIs(t, err, cdr.ErrInvalidNextLink)
+}
@@ -0,0 +1,72 @@
+package cdr
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/google/go-querystring/query"
+)
+
+// Modes of the _summary search parameter
+const (
+	SummaryTrue  = "true"
+	SummaryText  = "text"
+	SummaryData  = "data"
+	SummaryCount = "count"
+	SummaryFalse = "false"
+)
+
+// Modes of the _total search parameter
+const (
+	TotalNone     = "none"
+	TotalEstimate = "estimate"
+	TotalAccurate = "accurate"
+)
+
+// SearchOptions holds the common FHIR search result parameters
+type SearchOptions struct {
+	// Include lists the referenced resources to include, e.g. "Observation:patient"
+	Include []string `url:"_include,omitempty"`
+	// RevInclude lists the referring resources to include, e.g. "Provenance:target"
+	RevInclude []string `url:"_revinclude,omitempty"`
+	// Elements limits the returned elements of the resources, e.g. "identifier", "name"
+	Elements []string `url:"_elements,omitempty,comma"`
+	Sort     []string `url:"_sort,omitempty,comma"`
+	Summary  string   `url:"_summary,omitempty"`
+	Total    string   `url:"_total,omitempty"`
+	Count    int      `url:"_count,omitempty"`
+	// Params holds the resource specific search parameters, e.g. name=Ron
+	Params url.Values `url:"-"`
+}
+
+// Values returns the search options as query parameters
+func (o SearchOptions) Values() (url.Values, error) {
+	values, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+	for key, params := range o.Params {
+		for _, p := range params {
+			values.Add(key, p)
+		}
+	}
+	return values, nil
+}
+
+// WithSearchOptions adds the search options to the query of the request
+func WithSearchOptions(opts SearchOptions) OptionFunc {
+	return func(req *http.Request) error {
+		values, err := opts.Values()
+		if err != nil {
+			return err
+		}
+		q := req.URL.Query()
+		for key, params := range values {
+			for _, p := range params {
+				q.Add(key, p)
+			}
+		}
+		req.URL.RawQuery = q.Encode()
+		return nil
+	}
+}
@@ -0,0 +1,61 @@
+package cdr_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/fhir/go/jsonformat"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchOptions(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	opts := cdr.SearchOptions{
+		Include:    []string{"Observation:patient", "Observation:performer"},
+		RevInclude: []string{"Provenance:target"},
+		Elements:   []string{"identifier", "status"},
+		Sort:       []string{"-date"},
+		Summary:    cdr.SummaryData,
+		Total:      cdr.TotalAccurate,
+		Count:      50,
+		Params:     url.Values{"code": []string{"8867-4"}},
+	}
+	values, err := opts.Values()
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"Observation:patient", "Observation:performer"}, values["_include"])
+	assert.Equal(t, "Provenance:target", values.Get("_revinclude"))
+	assert.Equal(t, "identifier,status", values.Get("_elements"))
+	assert.Equal(t, "-date", values.Get("_sort"))
+	assert.Equal(t, "data", values.Get("_summary"))
+	assert.Equal(t, "accurate", values.Get("_total"))
+	assert.Equal(t, "50", values.Get("_count"))
+	assert.Equal(t, "8867-4", values.Get("code"))
+
+	empty, err := cdr.SearchOptions{}.Values()
+	assert.Nil(t, err)
+	assert.Len(t, empty, 0)
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Observation", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "final", q.Get("status"))
+		assert.Equal(t, "8867-4", q.Get("code"))
+		assert.Equal(t, []string{"Observation:patient", "Observation:performer"}, q["_include"])
+		w.Header().Set("Content-Type", "application/fhir+json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"resourceType":"Bundle","type":"searchset","total":0}`)
+	})
+	_, resp, err := cdrClient.OperationsRaw.Search("Observation", url.Values{"status": []string{"final"}}, cdr.WithSearchOptions(opts))
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
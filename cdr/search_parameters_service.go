@@ -0,0 +1,158 @@
+package cdr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SearchParametersService manages custom SearchParameter resources of the store
+type SearchParametersService struct {
+	client *Client
+}
+
+// SearchParameter is a custom search parameter definition
+type SearchParameter struct {
+	ResourceType string `json:"resourceType"`
+	ID           string `json:"id,omitempty"`
+	URL          string `json:"url"`
+	Name         string `json:"name"`
+	// Status is one of draft, active or retired
+	Status      string `json:"status"`
+	Description string `json:"description,omitempty"`
+	Code        string `json:"code"`
+	// Base lists the resource types the parameter applies to
+	Base []string `json:"base"`
+	// Type is one of number, date, string, token, reference, composite, quantity or uri
+	Type       string `json:"type"`
+	Expression string `json:"expression,omitempty"`
+}
+
+type searchParameterBundle struct {
+	Entry []struct {
+		Resource SearchParameter `json:"resource"`
+	} `json:"entry"`
+}
+
+// CreateSearchParameter creates a custom search parameter. Existing resources are only
+// searchable by the new parameter after a Reindex
+func (s *SearchParametersService) CreateSearchParameter(param SearchParameter, options ...OptionFunc) (*SearchParameter, *Response, error) {
+	if err := param.validate(); err != nil {
+		return nil, nil, err
+	}
+	param.ResourceType = "SearchParameter"
+	if param.Status == "" {
+		param.Status = "active"
+	}
+	body, err := json.Marshal(param)
+	if err != nil {
+		return nil, nil, err
+	}
+	raw, resp, err := s.client.OperationsRaw.Create("SearchParameter", body, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(raw) == 0 {
+		return &param, resp, nil
+	}
+	var created SearchParameter
+	if err := json.Unmarshal(raw, &created); err != nil {
+		return nil, resp, fmt.Errorf("JSON unmarshal: %w", err)
+	}
+	return &created, resp, nil
+}
+
+// GetSearchParameters lists the custom search parameters. When base is not empty only
+// the parameters of that resource type are returned
+func (s *SearchParametersService) GetSearchParameters(base string, options ...OptionFunc) ([]SearchParameter, *Response, error) {
+	query := url.Values{}
+	if base != "" {
+		query.Set("base", base)
+	}
+	raw, resp, err := s.client.OperationsRaw.Search("SearchParameter", query, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	var bundle searchParameterBundle
+	if err := json.Unmarshal(raw, &bundle); err != nil {
+		return nil, resp, fmt.Errorf("JSON unmarshal: %w", err)
+	}
+	params := make([]SearchParameter, 0, len(bundle.Entry))
+	for _, e := range bundle.Entry {
+		params = append(params, e.Resource)
+	}
+	return params, resp, nil
+}
+
+// DeleteSearchParameter removes the custom search parameter with the given id
+func (s *SearchParametersService) DeleteSearchParameter(id string, options ...OptionFunc) (bool, *Response, error) {
+	if id == "" {
+		return false, nil, ErrMissingResourceID
+	}
+	return s.client.OperationsRaw.Delete("SearchParameter/"+id, options...)
+}
+
+// Reindex triggers a $reindex of the given resource types, or of all resources when
+// none are given, so custom search parameters apply to existing data. The reindex
+// runs asynchronously on the server
+func (s *SearchParametersService) Reindex(resourceTypes []string, options ...OptionFunc) (bool, *Response, error) {
+	parameters := map[string]interface{}{
+		"resourceType": "Parameters",
+	}
+	if len(resourceTypes) > 0 {
+		params := make([]map[string]interface{}, 0, len(resourceTypes))
+		for _, t := range resourceTypes {
+			params = append(params, map[string]interface{}{"name": "type", "valueString": t})
+		}
+		parameters["parameter"] = params
+	}
+	body, err := json.Marshal(parameters)
+	if err != nil {
+		return false, nil, err
+	}
+	mediaType := s.client.MediaType()
+	req, err := s.client.newCDRRequest(http.MethodPost, "$reindex", body, append([]OptionFunc{
+		func(req *http.Request) error {
+			req.Header.Set("Content-Type", mediaType)
+			return nil
+		},
+	}, options...))
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("Accept", mediaType)
+	resp, err := s.client.do(req, io.Discard)
+	if err != nil || resp == nil {
+		if resp == nil && err == nil {
+			err = fmt.Errorf("Reindex: %w", ErrEmptyResult)
+		}
+		return false, resp, err
+	}
+	return resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusAccepted, resp, nil
+}
+
+func (p SearchParameter) validate() error {
+	var missing []string
+	if p.URL == "" {
+		missing = append(missing, "url")
+	}
+	if p.Name == "" {
+		missing = append(missing, "name")
+	}
+	if p.Code == "" {
+		missing = append(missing, "code")
+	}
+	if len(p.Base) == 0 {
+		missing = append(missing, "base")
+	}
+	if p.Type == "" {
+		missing = append(missing, "type")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing %s: %w", strings.Join(missing, ", "), ErrInvalidSearchParameter)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package cdr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/fhir/go/jsonformat"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchParameters(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	paramID := "4f7c5a3e-0d3b-4c8e-9a51-6f6d2b8e1c22"
+	param := `{"resourceType":"SearchParameter","id":"` + paramID + `","url":"https://example.com/SearchParameter/patient-nickname","name":"nickname","status":"active","code":"nickname","base":["Patient"],"type":"string","expression":"Patient.extension('https://example.com/nickname').value"}`
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/SearchParameter", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/fhir+json")
+		switch r.Method {
+		case "POST":
+			body, _ := io.ReadAll(r.Body)
+			var received map[string]interface{}
+			_ = json.Unmarshal(body, &received)
+			assert.Equal(t, "SearchParameter", received["resourceType"])
+			assert.Equal(t, "active", received["status"])
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, param)
+		case "GET":
+			assert.Equal(t, "Patient", r.URL.Query().Get("base"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{"resourceType":"Bundle","type":"searchset","total":1,"entry":[{"resource":`+param+`}]}`)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/SearchParameter/"+paramID, func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, "DELETE", r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/$reindex", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, "POST", r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"resourceType":"Parameters","parameter":[{"name":"type","valueString":"Patient"}]}`, string(body))
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	_, _, err := cdrClient.SearchParameters.CreateSearchParameter(cdr.SearchParameter{Name: "nickname"})
+	assert.True(t, errors.Is(err, cdr.ErrInvalidSearchParameter))
+
+	created, resp, err := cdrClient.SearchParameters.CreateSearchParameter(cdr.SearchParameter{
+		URL:        "https://example.com/SearchParameter/patient-nickname",
+		Name:       "nickname",
+		Code:       "nickname",
+		Base:       []string{"Patient"},
+		Type:       "string",
+		Expression: "Patient.extension('https://example.com/nickname').value",
+	})
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) || !assert.NotNil(t, created) {
+		return
+	}
+	assert.Equal(t, paramID, created.ID)
+
+	params, _, err := cdrClient.SearchParameters.GetSearchParameters("Patient")
+	if !assert.Nil(t, err) || !assert.Len(t, params, 1) {
+		return
+	}
+	assert.Equal(t, "nickname", params[0].Code)
+
+	ok, _, err := cdrClient.SearchParameters.Reindex([]string{"Patient"})
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, _, err = cdrClient.SearchParameters.DeleteSearchParameter(paramID)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
@@ -31,8 +31,8 @@ func (t *TenantR4Service) Onboard(organization *r4pb.Organization, options ...Op
 	if err != nil {
 		return nil, nil, err
 	}
-	req.Header.Set("Accept", "application/fhir+json;fhirVersion=4.0")
-	req.Header.Set("Content-Type", "application/fhir+json;fhirVersion=4.0")
+	req.Header.Set("Accept", r4MediaType)
+	req.Header.Set("Content-Type", r4MediaType)
 
 	var onboardResponse bytes.Buffer
 	resp, err := t.client.do(req, &onboardResponse)
@@ -56,8 +56,8 @@ func (t *TenantR4Service) GetOrganizationByID(orgID string) (*r4pb.Organization,
 	if err != nil {
 		return nil, nil, err
 	}
-	req.Header.Set("Accept", "application/fhir+json;fhirVersion=4.0")
-	req.Header.Set("Content-Type", "application/fhir+json;fhirVersion=4.0")
+	req.Header.Set("Accept", r4MediaType)
+	req.Header.Set("Content-Type", r4MediaType)
 
 	var getResponse bytes.Buffer
 	resp, err := t.client.do(req, &getResponse)
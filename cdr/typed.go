@@ -0,0 +1,127 @@
+package cdr
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	protov1 "github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const containedResourceOneof = "oneof_resource"
+
+// Get reads the resource with the given id directly into the FHIR proto type T
+// which must match the configured FHIR version, e.g.
+//
+//	patient, _, err := cdr.Get[*r4pb.Patient](client, "123")
+func Get[T protov1.Message](c *Client, id string, options ...OptionFunc) (T, *Response, error) {
+	var zero T
+	resourceType, err := typedResourceType[T](c)
+	if err != nil {
+		return zero, nil, err
+	}
+	if id == "" {
+		return zero, nil, ErrMissingResourceID
+	}
+	raw, resp, err := c.OperationsRaw.Get(resourceType+"/"+id, options...)
+	if err != nil {
+		return zero, resp, err
+	}
+	if len(raw) == 0 {
+		return zero, resp, fmt.Errorf("Get: %w", ErrEmptyResult)
+	}
+	resource, err := typedUnmarshal[T](c, raw)
+	return resource, resp, err
+}
+
+// Create stores resource as a new resource of its type and returns the created
+// resource as returned by the server. The returned resource is nil when the
+// server returns no content, e.g. with WithPrefer(PreferReturnMinimal)
+func Create[T protov1.Message](c *Client, resource T, options ...OptionFunc) (T, *Response, error) {
+	var zero T
+	resourceType, err := typedResourceType[T](c)
+	if err != nil {
+		return zero, nil, err
+	}
+	body, err := c.Marshaller().MarshalResource(resource)
+	if err != nil {
+		return zero, nil, fmt.Errorf("FHIR marshal: %w", err)
+	}
+	raw, resp, err := c.OperationsRaw.Create(resourceType, body, options...)
+	if err != nil || len(raw) == 0 {
+		return zero, resp, err
+	}
+	created, err := typedUnmarshal[T](c, raw)
+	return created, resp, err
+}
+
+// Search returns all resources of type T matching query, following the next
+// links of the search result bundles. Resources of other types in the bundles,
+// e.g. from _include, are skipped
+func Search[T protov1.Message](ctx context.Context, c *Client, query url.Values, options ...OptionFunc) ([]T, error) {
+	resourceType, err := typedResourceType[T](c)
+	if err != nil {
+		return nil, err
+	}
+	var resources []T
+	it := c.SearchIterator(ctx, resourceType, query, options...)
+	for it.Next() {
+		resource, err := typedResource[T](it.Resource())
+		if err != nil {
+			continue
+		}
+		resources = append(resources, resource)
+	}
+	return resources, it.Err()
+}
+
+// typedResourceType returns the FHIR resource type of T after checking T
+// belongs to the FHIR version of the client
+func typedResourceType[T protov1.Message](c *Client) (string, error) {
+	var zero T
+	descriptor := protov1.MessageV2(zero).ProtoReflect().Descriptor()
+	var fhirPackage protoreflect.FullName
+	switch c.fhirVersion {
+	case FHIRVersionR4:
+		fhirPackage = "google.fhir.r4.core"
+	default:
+		fhirPackage = "google.fhir.stu3.proto"
+	}
+	if descriptor.ParentFile().Package() != fhirPackage {
+		return "", fmt.Errorf("%s is not a FHIR %s resource: %w", descriptor.FullName(), c.fhirVersion, ErrResourceTypeMismatch)
+	}
+	return string(descriptor.Name()), nil
+}
+
+func typedUnmarshal[T protov1.Message](c *Client, raw []byte) (T, error) {
+	var zero T
+	contained, err := c.Unmarshaller().Unmarshal(raw)
+	if err != nil {
+		return zero, fmt.Errorf("FHIR unmarshal: %w", err)
+	}
+	return typedResource[T](contained)
+}
+
+// typedResource extracts the resource of type T from a ContainedResource
+func typedResource[T protov1.Message](contained protov1.Message) (T, error) {
+	var zero T
+	want := protov1.MessageV2(zero).ProtoReflect().Descriptor()
+	m := protov1.MessageV2(contained).ProtoReflect()
+	oneof := m.Descriptor().Oneofs().ByName(containedResourceOneof)
+	if oneof == nil {
+		return zero, fmt.Errorf("%s: %w", m.Descriptor().FullName(), ErrResourceTypeMismatch)
+	}
+	field := m.WhichOneof(oneof)
+	if field == nil {
+		return zero, fmt.Errorf("typed resource: %w", ErrEmptyResult)
+	}
+	if field.Message() == nil || field.Message().FullName() != want.FullName() {
+		return zero, fmt.Errorf("%s is not a %s: %w", field.Name(), want.Name(), ErrResourceTypeMismatch)
+	}
+	resource, ok := protov1.MessageV1(m.Get(field).Message().Interface()).(T)
+	if !ok {
+		return zero, fmt.Errorf("%s: %w", want.FullName(), ErrResourceTypeMismatch)
+	}
+	return resource, nil
+}
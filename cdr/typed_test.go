@@ -0,0 +1,84 @@
+package cdr_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/fhir/go/jsonformat"
+	r4dt "github.com/google/fhir/go/proto/google/fhir/proto/r4/core/datatypes_go_proto"
+	r4patientpb "github.com/google/fhir/go/proto/google/fhir/proto/r4/core/resources/patient_go_proto"
+	stu3pb "github.com/google/fhir/go/proto/google/fhir/proto/stu3/resources_go_proto"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedResources(t *testing.T) {
+	teardown := setup(t, jsonformat.R4)
+	defer teardown()
+
+	r4Client, err := cdr.NewClient(iamClient, &cdr.Config{
+		CDRURL:      serverCDR.URL + "/store/fhir",
+		RootOrgID:   cdrOrgID,
+		FHIRVersion: cdr.FHIRVersionR4,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	patient := `{"resourceType":"Patient","id":"123","active":true}`
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/fhir+json;fhirVersion=4.0")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, patient)
+	})
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/fhir+json;fhirVersion=4.0")
+		switch r.Method {
+		case http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"resourceType":"Patient","active":true}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, patient)
+		case http.MethodGet:
+			assert.Equal(t, "true", r.URL.Query().Get("active"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{"resourceType":"Bundle","type":"searchset","entry":[
+  {"resource":`+patient+`},
+  {"resource":{"resourceType":"Organization","id":"456"}}
+]}`)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	found, resp, err := cdr.Get[*r4patientpb.Patient](r4Client, "123")
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) || !assert.NotNil(t, found) {
+		return
+	}
+	assert.Equal(t, "123", found.GetId().GetValue())
+	assert.True(t, found.GetActive().GetValue())
+
+	created, _, err := cdr.Create(r4Client, &r4patientpb.Patient{Active: &r4dt.Boolean{Value: true}})
+	if !assert.Nil(t, err) || !assert.NotNil(t, created) {
+		return
+	}
+	assert.Equal(t, "123", created.GetId().GetValue())
+
+	patients, err := cdr.Search[*r4patientpb.Patient](context.Background(), r4Client, url.Values{"active": {"true"}})
+	if !assert.Nil(t, err) || !assert.Len(t, patients, 1) {
+		return
+	}
+	assert.Equal(t, "123", patients[0].GetId().GetValue())
+
+	_, _, err = cdr.Get[*stu3pb.Patient](r4Client, "123")
+	assert.True(t, errors.Is(err, cdr.ErrResourceTypeMismatch))
+
+	_, _, err = cdr.Get[*r4patientpb.Patient](r4Client, "")
+	assert.Equal(t, cdr.ErrMissingResourceID, err)
+}
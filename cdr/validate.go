@@ -0,0 +1,87 @@
+package cdr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Issue severities of an OperationOutcome
+const (
+	SeverityFatal       = "fatal"
+	SeverityError       = "error"
+	SeverityWarning     = "warning"
+	SeverityInformation = "information"
+)
+
+// OperationOutcome is the outcome of an operation such as $validate
+type OperationOutcome struct {
+	ResourceType string                  `json:"resourceType"`
+	Issue        []OperationOutcomeIssue `json:"issue"`
+}
+
+// OperationOutcomeIssue describes a single issue of an OperationOutcome
+type OperationOutcomeIssue struct {
+	Severity    string   `json:"severity"`
+	Code        string   `json:"code"`
+	Diagnostics string   `json:"diagnostics,omitempty"`
+	Location    []string `json:"location,omitempty"`
+	Expression  []string `json:"expression,omitempty"`
+	Details     *struct {
+		Text string `json:"text,omitempty"`
+	} `json:"details,omitempty"`
+}
+
+// HasErrors reports whether the outcome contains issues of severity error or fatal
+func (o OperationOutcome) HasErrors() bool {
+	for _, issue := range o.Issue {
+		if issue.Severity == SeverityError || issue.Severity == SeverityFatal {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the FHIR resource in jsonBody against the $validate endpoint of
+// the store without persisting it. A resource failing validation is not an error:
+// inspect the issues of the returned OperationOutcome instead
+func (c *Client) Validate(jsonBody []byte, options ...OptionFunc) (*OperationOutcome, *Response, error) {
+	resourceType, _, err := resourceTypeAndID(jsonBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	mediaType := c.MediaType()
+	req, err := c.newCDRRequest(http.MethodPost, resourceType+"/$validate", jsonBody, append([]OptionFunc{
+		func(req *http.Request) error {
+			req.Header.Set("Content-Type", mediaType)
+			return nil
+		},
+	}, options...))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", mediaType)
+	var validateResponse bytes.Buffer
+	resp, err := c.do(req, &validateResponse)
+	if resp == nil {
+		if err == nil || err == io.EOF {
+			err = fmt.Errorf("Validate: %w", ErrEmptyResult)
+		}
+		return nil, resp, err
+	}
+	if err != nil && err != io.EOF {
+		// Invalid resources are reported with a 4xx status and an OperationOutcome body
+		if resp.StatusCode != http.StatusBadRequest && resp.StatusCode != http.StatusUnprocessableEntity {
+			return nil, resp, err
+		}
+		validateResponse.Reset()
+		_, _ = io.Copy(&validateResponse, resp.Body)
+	}
+	var outcome OperationOutcome
+	if err := json.Unmarshal(validateResponse.Bytes(), &outcome); err != nil || outcome.ResourceType != "OperationOutcome" {
+		return nil, resp, fmt.Errorf("Validate: %w", ErrEmptyResult)
+	}
+	return &outcome, resp, nil
+}
@@ -0,0 +1,66 @@
+package cdr_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/fhir/go/jsonformat"
+
+	"github.com/philips-software/go-hsdp-api/cdr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	teardown := setup(t, jsonformat.STU3)
+	defer teardown()
+
+	muxCDR.HandleFunc("/store/fhir/"+cdrOrgID+"/Patient/$validate", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, http.MethodPost, r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/fhir+json")
+		if string(body) == `{"resourceType":"Patient","gender":"unknown"}` {
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{
+  "resourceType": "OperationOutcome",
+  "issue": [{"severity": "information", "code": "informational", "diagnostics": "All OK"}]
+}`)
+			return
+		}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = io.WriteString(w, `{
+  "resourceType": "OperationOutcome",
+  "issue": [{
+    "severity": "error",
+    "code": "code-invalid",
+    "diagnostics": "Unknown code 'robot'",
+    "location": ["Patient.gender"],
+    "expression": ["Patient.gender"]
+  }]
+}`)
+	})
+
+	_, _, err := cdrClient.Validate([]byte(`{"gender":"unknown"}`))
+	assert.ErrorIs(t, err, cdr.ErrMissingResourceType)
+
+	outcome, resp, err := cdrClient.Validate([]byte(`{"resourceType":"Patient","gender":"unknown"}`))
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) || !assert.NotNil(t, outcome) {
+		return
+	}
+	assert.False(t, outcome.HasErrors())
+
+	outcome, resp, err = cdrClient.Validate([]byte(`{"resourceType":"Patient","gender":"robot"}`))
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) || !assert.NotNil(t, outcome) {
+		return
+	}
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.True(t, outcome.HasErrors())
+	if assert.Len(t, outcome.Issue, 1) {
+		assert.Equal(t, cdr.SeverityError, outcome.Issue[0].Severity)
+		assert.Equal(t, []string{"Patient.gender"}, outcome.Issue[0].Location)
+	}
+}
@@ -1,6 +1,7 @@
 package iam
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"net/http"
@@ -25,8 +26,8 @@ type GetApplicationsOptions struct {
 }
 
 // GetApplicationByID retrieves an Application by its ID
-func (a *ApplicationsService) GetApplicationByID(id string) (*Application, *Response, error) {
-	apps, resp, err := a.GetApplications(&GetApplicationsOptions{ID: String(id)}, nil)
+func (a *ApplicationsService) GetApplicationByID(id string, options ...OptionFunc) (*Application, *Response, error) {
+	apps, resp, err := a.GetApplications(&GetApplicationsOptions{ID: String(id)}, options...)
 	if len(apps) == 0 {
 		return nil, resp, ErrNotFound
 	}
@@ -34,8 +35,8 @@ func (a *ApplicationsService) GetApplicationByID(id string) (*Application, *Resp
 }
 
 // GetApplicationByName retrieves an Application by its Name
-func (a *ApplicationsService) GetApplicationByName(name string) (*Application, *Response, error) {
-	apps, resp, err := a.GetApplications(&GetApplicationsOptions{ID: String(name)}, nil)
+func (a *ApplicationsService) GetApplicationByName(name string, options ...OptionFunc) (*Application, *Response, error) {
+	apps, resp, err := a.GetApplications(&GetApplicationsOptions{ID: String(name)}, options...)
 	if len(apps) == 0 {
 		return nil, resp, ErrNotFound
 	}
@@ -68,11 +69,11 @@ func (a *ApplicationsService) GetApplications(opt *GetApplicationsOptions, optio
 }
 
 // CreateApplication creates a Application
-func (a *ApplicationsService) CreateApplication(app Application) (*Application, *Response, error) {
+func (a *ApplicationsService) CreateApplication(app Application, options ...OptionFunc) (*Application, *Response, error) {
 	if err := a.client.validate.Struct(app); err != nil {
 		return nil, nil, err
 	}
-	req, err := a.client.newRequest(IDM, "POST", "authorize/identity/Application", &app, nil)
+	req, err := a.client.newRequest(IDM, "POST", "authorize/identity/Application", &app, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -103,5 +104,47 @@ func (a *ApplicationsService) CreateApplication(app Application) (*Application,
 	if count == 0 {
 		return nil, resp, fmt.Errorf("CreateApplication: %w", ErrCouldNoReadResourceAfterCreate)
 	}
-	return a.GetApplicationByID(id)
+	return a.GetApplicationByID(id, options...)
+}
+
+// UpdateApplication updates the name and description of an Application
+func (a *ApplicationsService) UpdateApplication(app Application, options ...OptionFunc) (*Application, *Response, error) {
+	if app.Meta == nil {
+		return nil, nil, ErrMissingEtagInformation
+	}
+	if err := a.client.validate.Struct(app); err != nil {
+		return nil, nil, err
+	}
+	req, err := a.client.newRequest(IDM, "PUT", "authorize/identity/Application/"+app.ID, &app, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", applicationAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", app.Meta.VersionID)
+
+	var updatedApp Application
+
+	resp, err := a.client.do(req, &updatedApp)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &updatedApp, resp, nil
+}
+
+// DeleteApplication deletes the given Application
+func (a *ApplicationsService) DeleteApplication(app Application, options ...OptionFunc) (bool, *Response, error) {
+	req, err := a.client.newRequest(IDM, "DELETE", "authorize/identity/Application/"+app.ID, nil, options)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("api-version", applicationAPIVersion)
+
+	var deleteResponse bytes.Buffer
+
+	resp, err := a.client.do(req, &deleteResponse)
+	if resp == nil || (resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusAccepted) {
+		return false, resp, err
+	}
+	return true, resp, nil
 }
@@ -100,3 +100,65 @@ func TestApplicationErrors(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Nil(t, app)
 }
+
+func TestUpdateDeleteApplication(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	appID := "10dc5e2f-3940-4cd8-b0ef-297e12ad2f3c"
+	propID := "3af7143e-de76-11e8-9681-6a0002b8cb70"
+	name := "RENAMEDAPP"
+
+	muxIDM.HandleFunc("/authorize/identity/Application/"+appID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "PUT":
+			if r.Header.Get("If-Match") != "0" {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				_, _ = io.WriteString(w, `{"resourceType": "OperationOutcome","issue": [{"severity": "error","code": "conflict"}]}`)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{
+				"name": "`+name+`",
+				"description": "TESTAPP Application",
+				"propositionId": "`+propID+`",
+				"globalReferenceId": "TESTAPPREF",
+				"id": "`+appID+`",
+				"meta": {
+					"versionId": "1",
+					"lastModified": "2018-11-02T05:48:41.042Z"
+				}
+			}`)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	app := Application{
+		ID:                appID,
+		Name:              name,
+		PropositionID:     propID,
+		GlobalReferenceID: "TESTAPPREF",
+	}
+	_, _, err := client.Applications.UpdateApplication(app)
+	assert.Equal(t, ErrMissingEtagInformation, err)
+
+	app.Meta = &VersionMeta{VersionID: "0"}
+	updated, resp, err := client.Applications.UpdateApplication(app)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotNil(t, resp)
+	if assert.NotNil(t, updated) {
+		assert.Equal(t, name, updated.Name)
+		assert.Equal(t, "1", updated.Meta.VersionID)
+	}
+
+	ok, resp, err := client.Applications.DeleteApplication(app)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	if assert.NotNil(t, resp) {
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+}
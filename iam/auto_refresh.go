@@ -0,0 +1,130 @@
+package iam
+
+import (
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultAutoRefreshMargin        = 5 * time.Minute
+	defaultAutoRefreshJitter        = 30 * time.Second
+	defaultAutoRefreshRetryInterval = 30 * time.Second
+	minAutoRefreshInterval          = 1 * time.Second
+)
+
+// AutoRefreshOptions configures the background token refresher
+type AutoRefreshOptions struct {
+	// Margin is the time before expiry at which a refresh is attempted. Defaults to 5 minutes
+	Margin time.Duration
+	// Jitter is the maximum random duration added to Margin so that a fleet
+	// of clients does not hit IAM at the same moment. Defaults to 30 seconds.
+	// A negative value disables jitter
+	Jitter time.Duration
+	// RetryInterval is the wait time after a failed refresh. Defaults to 30 seconds
+	RetryInterval time.Duration
+	// OnRefresh is called after each successful refresh. It may call StopAutoRefresh or Close
+	OnRefresh func()
+	// OnError is called when a refresh attempt fails. It may call StopAutoRefresh or Close
+	OnError func(error)
+}
+
+type autoRefresher struct {
+	stop chan struct{}
+	done chan struct{}
+	// inCallback is set while OnRefresh or OnError runs on the refresher goroutine
+	inCallback int32
+}
+
+// StartAutoRefresh starts a background goroutine which refreshes the access token
+// before it expires. Call StopAutoRefresh or Close to stop it.
+func (c *Client) StartAutoRefresh(opts AutoRefreshOptions) error {
+	c.Lock()
+	defer c.Unlock()
+	if c.refresher != nil {
+		return ErrAutoRefreshAlreadyRunning
+	}
+	if opts.Margin <= 0 {
+		opts.Margin = defaultAutoRefreshMargin
+	}
+	if opts.Jitter < 0 {
+		opts.Jitter = 0
+	} else if opts.Jitter == 0 {
+		opts.Jitter = defaultAutoRefreshJitter
+	}
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = defaultAutoRefreshRetryInterval
+	}
+	r := &autoRefresher{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	c.refresher = r
+	go c.autoRefreshLoop(r, opts)
+	return nil
+}
+
+// StopAutoRefresh stops the background token refresher, if running.
+// It blocks until the refresher goroutine has exited, unless a callback is running,
+// in which case the refresher exits as soon as the callback returns.
+func (c *Client) StopAutoRefresh() {
+	c.Lock()
+	r := c.refresher
+	c.refresher = nil
+	c.Unlock()
+	if r == nil {
+		return
+	}
+	close(r.stop)
+	if atomic.LoadInt32(&r.inCallback) == 1 {
+		// Waiting here would deadlock when called from the callback itself
+		return
+	}
+	<-r.done
+}
+
+func (c *Client) autoRefreshLoop(r *autoRefresher, opts AutoRefreshOptions) {
+	defer close(r.done)
+
+	wait := c.nextRefreshIn(opts)
+	for {
+		timer := time.NewTimer(wait)
+		select {
+		case <-r.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if err := c.TokenRefresh(); err != nil {
+			if opts.OnError != nil {
+				r.callback(func() { opts.OnError(err) })
+			}
+			wait = opts.RetryInterval
+			continue
+		}
+		if opts.OnRefresh != nil {
+			r.callback(opts.OnRefresh)
+		}
+		wait = c.nextRefreshIn(opts)
+	}
+}
+
+func (r *autoRefresher) callback(fn func()) {
+	atomic.StoreInt32(&r.inCallback, 1)
+	defer atomic.StoreInt32(&r.inCallback, 0)
+	fn()
+}
+
+func (c *Client) nextRefreshIn(opts AutoRefreshOptions) time.Duration {
+	expiresAt := c.ExpiresAt()
+
+	margin := opts.Margin
+	if opts.Jitter > 0 {
+		margin += time.Duration(rand.Int63n(int64(opts.Jitter)))
+	}
+	wait := time.Until(expiresAt) - margin
+	if wait < minAutoRefreshInterval {
+		wait = minAutoRefreshInterval
+	}
+	return wait
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -59,6 +60,11 @@ type Client struct {
 
 	baseIAMURL *url.URL
 	baseIDMURL *url.URL
+	failover   *failoverTransport
+
+	// tokenLock guards the token fields below so they can be read while
+	// a login or refresh is in progress
+	tokenLock sync.RWMutex
 
 	// token type used to make authenticated API calls.
 	tokenType tokenType
@@ -78,6 +84,14 @@ type Client struct {
 
 	debugFile *os.File
 
+	refresher       *autoRefresher
+	introspectCache *introspectCache
+
+	jwks          *JWKS
+	jwksFetchedAt time.Time
+
+	tokenSources map[string]*scopedTokenSource
+
 	Organizations    *OrganizationsService
 	Groups           *GroupsService
 	Permissions      *PermissionsService
@@ -93,6 +107,14 @@ type Client struct {
 	EmailTemplates   *EmailTemplatesService
 	SMSGateways      *SMSGatewaysService
 	SMSTemplates     *SMSTemplatesService
+	SCIM             *SCIMService
+	Policies         *PoliciesService
+	Events           *EventsService
+	Delegations      *DelegationsService
+	Federation       *FederationService
+	Consents         *ConsentsService
+	EmailDomains     *EmailDomainsService
+	DeviceGroups     *DeviceGroupsService
 
 	sync.Mutex
 }
@@ -120,8 +142,14 @@ func newClient(httpClient *http.Client, config *Config) (*Client, error) {
 	if err := c.SetBaseIDMURL(c.config.IDMURL); err != nil {
 		return nil, err
 	}
+	if err := c.setupFailover(httpClient); err != nil {
+		return nil, err
+	}
 	if config.Signer == nil {
-		signer, err := hsdpsigner.New(c.config.SharedKey, c.config.SecretKey)
+		signer, err := newSigner(c.config)
+		if err == ErrUnsupportedSignatureVersion {
+			return nil, err
+		}
 		if err != nil { // Allow nil signer
 			signer = nil
 		}
@@ -133,7 +161,7 @@ func newClient(httpClient *http.Client, config *Config) (*Client, error) {
 		var err error
 		c.debugFile, err = os.OpenFile(config.DebugLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
 		if err == nil {
-			httpClient.Transport = internal.NewLoggingRoundTripper(httpClient.Transport, c.debugFile)
+			c.Client.Transport = internal.NewLoggingRoundTripper(c.Client.Transport, c.debugFile)
 		}
 	}
 
@@ -153,6 +181,15 @@ func newClient(httpClient *http.Client, config *Config) (*Client, error) {
 	c.EmailTemplates = &EmailTemplatesService{client: c, validate: validator.New()}
 	c.SMSGateways = &SMSGatewaysService{client: c, validate: validator.New()}
 	c.SMSTemplates = &SMSTemplatesService{client: c, validate: validator.New()}
+	c.SCIM = &SCIMService{client: c, validate: validator.New()}
+	c.Policies = &PoliciesService{client: c, validate: validator.New()}
+	c.Events = &EventsService{client: c}
+	c.Delegations = &DelegationsService{client: c, validate: validator.New()}
+	c.Federation = &FederationService{client: c, validate: validator.New()}
+	c.Consents = &ConsentsService{client: c, validate: validator.New()}
+	c.EmailDomains = &EmailDomainsService{client: c, validate: validator.New()}
+	c.DeviceGroups = &DeviceGroupsService{client: c, validate: validator.New()}
+	c.loadStoredTokens()
 	return c, nil
 }
 
@@ -180,6 +217,7 @@ func (c *Client) validSigner() bool {
 
 // Close releases allocated resources of clients
 func (c *Client) Close() {
+	c.StopAutoRefresh()
 	if c.debugFile != nil {
 		_ = c.debugFile.Close()
 		c.debugFile = nil
@@ -217,38 +255,62 @@ func (c *Client) accessTokenEndpoint() string {
 
 // Token returns the current token
 func (c *Client) Token() (string, error) {
-	now := time.Now().Unix()
-	expires := c.expiresAt.Unix()
-
-	if expires-now < 60 {
+	if time.Until(c.ExpiresAt()) < 60*time.Second {
 		if err := c.TokenRefresh(); err != nil {
 			return "", err
 		}
 	}
-	c.Lock()
-	defer c.Unlock()
+	c.tokenLock.RLock()
+	defer c.tokenLock.RUnlock()
 	return c.token, nil
 }
 
 // ExpireToken expires the token immediately
 func (c *Client) ExpireToken() {
-	c.Lock()
-	defer c.Unlock()
+	c.tokenLock.Lock()
+	defer c.tokenLock.Unlock()
 	c.expiresAt = time.Now()
 }
 
 // TokenRefresh forces a token refresh
 func (c *Client) TokenRefresh() error {
+	start := time.Now()
+	err := c.tokenRefresh()
+	c.metrics().ObserveTokenRefresh(time.Since(start), err)
+	return err
+}
+
+func (c *Client) tokenRefresh() error {
 	c.Lock()
 	defer c.Unlock()
 
-	if c.refreshToken == "" {
-		if c.service.Valid() { // Possible service
-			return c.ServiceLogin(c.service)
+	c.tokenLock.RLock()
+	refreshToken, service := c.refreshToken, c.service
+	c.tokenLock.RUnlock()
+
+	if refreshToken == "" {
+		if service.Valid() { // Possible service
+			return c.ServiceLogin(service)
 		}
 		return ErrMissingRefreshToken
 	}
+	if !c.HasOAuth2Credentials() {
+		return ErrMissingOAuth2Credentials
+	}
+
+	err := c.doTokenRequest(c.refreshTokenRequest(refreshToken))
+	var invalidClient *invalidClientError
+	if !errors.As(err, &invalidClient) {
+		return err
+	}
+	// The client secret may have been rotated, retry once with the current one
+	if rotated, rotateErr := c.rotateSecret(); rotateErr != nil || !rotated {
+		return err
+	}
+	return c.doTokenRequest(c.refreshTokenRequest(refreshToken))
+}
 
+func (c *Client) refreshTokenRequest(refreshToken string) *http.Request {
 	u := *c.baseIAMURL
 	u.Opaque = c.baseIAMURL.Path + "authorize/oauth2/token"
 
@@ -263,28 +325,26 @@ func (c *Client) TokenRefresh() error {
 	}
 	form := url.Values{}
 	form.Add("grant_type", "refresh_token")
-	form.Add("refresh_token", c.refreshToken)
+	form.Add("refresh_token", refreshToken)
 	if len(c.config.Scopes) > 0 {
 		scopes := strings.Join(c.config.Scopes, " ")
 		form.Add("scope", scopes)
 	}
-	if !c.HasOAuth2Credentials() {
-		return ErrMissingOAuth2Credentials
-	}
-	req.SetBasicAuth(c.config.OAuth2ClientID, c.config.OAuth2Secret)
+	req.SetBasicAuth(c.config.OAuth2ClientID, c.oauth2Secret())
 	req.Body = io.NopCloser(strings.NewReader(form.Encode()))
 	req.ContentLength = int64(len(form.Encode()))
-
-	return c.doTokenRequest(req)
+	return req
 }
 
 // HasOAuth2Credentials returns true if the client is configured with OAuth2 credentials
 func (c *Client) HasOAuth2Credentials() bool {
-	return c.config.OAuth2ClientID != "" && c.config.OAuth2Secret != ""
+	return c.config.OAuth2ClientID != "" && c.oauth2Secret() != ""
 }
 
 // HasScopes returns true of all scopes are there for the client
 func (c *Client) HasScopes(scopes ...string) bool {
+	c.tokenLock.RLock()
+	defer c.tokenLock.RUnlock()
 	for _, s := range scopes {
 		found := false
 		for _, t := range c.scopes {
@@ -306,32 +366,22 @@ func (c *Client) HasPermissions(orgID string, permissions ...string) bool {
 	if err != nil {
 		return false
 	}
-	foundOrg := false
-	for _, org := range introspect.Organizations.OrganizationList {
-		if org.OrganizationID != orgID {
-			continue
-		}
-		foundOrg = true
-		// Search in the organization effective permissions list
-		for _, p := range permissions {
-			found := false
-			for _, q := range org.EffectivePermissions {
-				if p == q {
-					found = true
-					continue
-				}
-			}
-			if !found {
-				// Permission is missing so return false
-				return false
-			}
+	if introspect.Organization(orgID) == nil {
+		return false
+	}
+	for _, p := range permissions {
+		if !introspect.HasPermissionInOrg(orgID, p) {
+			// Permission is missing so return false
+			return false
 		}
 	}
-	return foundOrg
+	return true
 }
 
 // SetToken sets the token
 func (c *Client) SetToken(token string) {
+	c.tokenLock.Lock()
+	defer c.tokenLock.Unlock()
 	c.token = token
 	c.expiresAt = time.Now().Add(86400 * time.Second)
 	c.tokenType = OAuthToken
@@ -339,8 +389,8 @@ func (c *Client) SetToken(token string) {
 
 // SetTokens sets the token
 func (c *Client) SetTokens(accessToken, refreshToken, idToken string, expiresAt int64) {
-	c.Lock()
-	defer c.Unlock()
+	c.tokenLock.Lock()
+	defer c.tokenLock.Unlock()
 	c.token = accessToken
 	c.refreshToken = refreshToken
 	c.idToken = idToken
@@ -350,17 +400,50 @@ func (c *Client) SetTokens(accessToken, refreshToken, idToken string, expiresAt
 
 // RefreshToken returns the refresh token
 func (c *Client) RefreshToken() string {
+	c.tokenLock.RLock()
+	defer c.tokenLock.RUnlock()
 	return c.refreshToken
 }
 
 // IDToken returns the ID token
 func (c *Client) IDToken() string {
+	c.tokenLock.RLock()
+	defer c.tokenLock.RUnlock()
 	return c.idToken
 }
 
 // Expires returns the expiry time (Unix) of the access token
 func (c *Client) Expires() int64 {
-	return c.expiresAt.Unix()
+	return c.ExpiresAt().Unix()
+}
+
+// ExpiresAt returns the time the access token expires
+func (c *Client) ExpiresAt() time.Time {
+	c.tokenLock.RLock()
+	defer c.tokenLock.RUnlock()
+	return c.expiresAt
+}
+
+// TokenInfo is a consistent snapshot of the tokens of a client
+type TokenInfo struct {
+	AccessToken  string
+	RefreshToken string
+	IDToken      string
+	ExpiresAt    time.Time
+	Scopes       []string
+}
+
+// TokenInfo returns a snapshot of the current tokens without refreshing them
+func (c *Client) TokenInfo() TokenInfo {
+	c.tokenLock.RLock()
+	defer c.tokenLock.RUnlock()
+	return TokenInfo{
+		AccessToken:  c.token,
+		RefreshToken: c.refreshToken,
+		IDToken:      c.idToken,
+		ExpiresAt:    c.expiresAt,
+		Scopes:       append([]string{}, c.scopes...),
+	}
 }
 
 // BaseIAMURL return a copy of the baseIAMURL.
@@ -388,7 +471,10 @@ func (c *Client) SetBaseIAMURL(urlStr string) error {
 
 	var err error
 	c.baseIAMURL, err = url.Parse(urlStr)
-	return err
+	if err != nil || c.failover == nil {
+		return err
+	}
+	return c.failover.setPrimaries(c.baseIAMURL, c.baseIDMURL)
 }
 
 // SetBaseIDMURL sets the base URL for API requests to a custom endpoint. urlStr
@@ -404,7 +490,10 @@ func (c *Client) SetBaseIDMURL(urlStr string) error {
 
 	var err error
 	c.baseIDMURL, err = url.Parse(urlStr)
-	return err
+	if err != nil || c.failover == nil {
+		return err
+	}
+	return c.failover.setPrimaries(c.baseIAMURL, c.baseIDMURL)
 }
 
 // Endpoint type
@@ -453,7 +542,7 @@ func (c *Client) newRequest(endpoint, method, path string, opt interface{}, opti
 	}
 	req.Header.Set("User-Agent", userAgent)
 
-	if method == "POST" || method == "PUT" {
+	if method == "POST" || method == "PUT" || method == "PATCH" {
 		bodyBytes, err := json.Marshal(opt)
 		if err != nil {
 			return nil, err
@@ -468,7 +557,10 @@ func (c *Client) newRequest(endpoint, method, path string, opt interface{}, opti
 
 	req.Header.Set("Accept", "application/json")
 
-	switch c.tokenType {
+	c.tokenLock.RLock()
+	tokenType := c.tokenType
+	c.tokenLock.RUnlock()
+	switch tokenType {
 	case OAuthToken:
 		if token, err := c.Token(); err == nil {
 			req.Header.Set("Authorization", "Bearer "+token)
@@ -512,7 +604,13 @@ func (c *Client) doSigned(req *http.Request, v interface{}) (*Response, error) {
 }
 
 func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
+	start := time.Now()
 	resp, err := c.Do(req)
+	statusCode := 0
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+	c.metrics().ObserveRequest(metricsEndpoint(req.URL), req.Method, statusCode, time.Since(start))
 	if err != nil {
 		return nil, err
 	}
@@ -547,6 +645,39 @@ func WithContext(ctx context.Context) OptionFunc {
 	}
 }
 
+// WithOrgContext scopes the request to the given organization. Form encoded
+// requests such as introspect get an org_ctx field, all other requests get an
+// organizationId query parameter unless the request is already org scoped
+func WithOrgContext(organizationID string) OptionFunc {
+	return func(req *http.Request) error {
+		if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+			if err := req.ParseForm(); err != nil {
+				return err
+			}
+			form := url.Values{}
+			for k, v := range req.PostForm {
+				form[k] = v
+			}
+			form.Set("org_ctx", organizationID)
+			req.Body = io.NopCloser(strings.NewReader(form.Encode()))
+			req.ContentLength = int64(len(form.Encode()))
+			return nil
+		}
+		if req.URL == nil {
+			return ErrMalformedInputValue
+		}
+		q := req.URL.Query()
+		for _, key := range []string{"organizationId", "organizationID", "orgID"} {
+			if q.Get(key) != "" {
+				return nil
+			}
+		}
+		q.Set("organizationId", organizationID)
+		req.URL.RawQuery = q.Encode()
+		return nil
+	}
+}
+
 // String is a helper routine that allocates a new string value
 // to store v and returns a pointer to it.
 func String(v string) *string {
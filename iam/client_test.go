@@ -1,12 +1,14 @@
 package iam
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -124,6 +126,41 @@ func TestLogin(t *testing.T) {
 	assert.Equal(t, refreshToken, client.RefreshToken())
 }
 
+func TestRevokeToken(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	userToken := "a9b1c8ea-52f4-4d0b-8a3c-ef3bbf1e4f90"
+
+	muxIAM.HandleFunc("/authorize/oauth2/revoke", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, "POST", r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		_ = r.ParseForm()
+		if _, _, ok := r.BasicAuth(); !ok || r.Form.Get("token") != userToken {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error": "invalid_request"}`)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	err := client.Login("username", "password")
+	if !assert.Nil(t, err) {
+		return
+	}
+	resp, err := client.RevokeToken(userToken)
+	assert.Nil(t, err)
+	if assert.NotNil(t, resp) {
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	assert.Equal(t, token, client.token)
+
+	_, err = client.RevokeToken("")
+	assert.Equal(t, ErrMissingToken, err)
+}
+
 func TestCodeLogin(t *testing.T) {
 	muxIAM = http.NewServeMux()
 	serverIAM = httptest.NewServer(muxIAM)
@@ -325,6 +362,43 @@ func TestHasScopes(t *testing.T) {
 	assert.False(t, client.HasScopes("mail", "bogus"))
 }
 
+func TestTokenInfo(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	err := client.Login("username", "password")
+	if !assert.Nil(t, err) {
+		return
+	}
+	info := client.TokenInfo()
+	assert.Equal(t, token, info.AccessToken)
+	assert.Equal(t, refreshToken, info.RefreshToken)
+	assert.Equal(t, client.ExpiresAt(), info.ExpiresAt)
+	assert.Equal(t, client.Expires(), info.ExpiresAt.Unix())
+	assert.Contains(t, info.Scopes, "mail")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_ = client.Login("username", "password")
+			_ = client.TokenRefresh()
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = client.Token()
+			_ = client.RefreshToken()
+			_ = client.IDToken()
+			_ = client.ExpiresAt()
+			_ = client.HasScopes("mail")
+			_ = client.TokenInfo()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, token, client.TokenInfo().AccessToken)
+}
+
 func TestIAMRequest(t *testing.T) {
 	teardown := setup(t)
 	defer teardown()
@@ -575,3 +649,117 @@ func TestAutoconfig(t *testing.T) {
 	assert.Equal(t, foo, cfg.IAMURL)
 	assert.Equal(t, foo, cfg.IDMURL)
 }
+
+func TestWithContext(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	groupID := "dbf1d779-ab9f-4c27-b4aa-ea75f9efbbc0"
+	muxIDM.HandleFunc("/authorize/identity/Group/"+groupID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"id": "`+groupID+`", "name": "TestGroup"}`)
+	})
+	err := client.Login("username", "password")
+	if !assert.Nil(t, err) {
+		return
+	}
+	group, _, err := client.Groups.GetGroupByID(groupID, WithContext(context.Background()))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, groupID, group.ID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	group, _, err = client.Groups.GetGroupByID(groupID, WithContext(ctx))
+	assert.Nil(t, group)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestWithOrgContext(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	groupID := "dbf1d779-ab9f-4c27-b4aa-ea75f9efbbc0"
+	orgID := "46323bb4-ebba-4387-a339-252b5aa0755f"
+	var seenOrgID string
+	muxIDM.HandleFunc("/authorize/identity/Group/"+groupID, func(w http.ResponseWriter, r *http.Request) {
+		seenOrgID = r.URL.Query().Get("organizationId")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"id": "`+groupID+`", "name": "TestGroup"}`)
+	})
+	err := client.Login("username", "password")
+	if !assert.Nil(t, err) {
+		return
+	}
+	group, _, err := client.Groups.GetGroupByID(groupID, WithOrgContext(orgID))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, groupID, group.ID)
+	assert.Equal(t, orgID, seenOrgID)
+
+	req, err := client.newRequest(IDM, "GET", "authorize/identity/Group", &GetGroupOptions{
+		OrganizationID: String("explicit"),
+	}, []OptionFunc{WithOrgContext(orgID)})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "explicit", req.URL.Query().Get("orgID"))
+	assert.Empty(t, req.URL.Query().Get("organizationId"))
+}
+
+func TestAutoRefresh(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	err := client.Login("username", "password")
+	if !assert.Nil(t, err) {
+		return
+	}
+	refreshed := make(chan struct{}, 1)
+	err = client.StartAutoRefresh(AutoRefreshOptions{
+		Margin: 30 * time.Minute,
+		Jitter: -1,
+		OnRefresh: func() {
+			select {
+			case refreshed <- struct{}{}:
+			default:
+			}
+		},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, ErrAutoRefreshAlreadyRunning, client.StartAutoRefresh(AutoRefreshOptions{}))
+
+	select {
+	case <-refreshed:
+	case <-time.After(5 * time.Second):
+		t.Error("expected token to be refreshed")
+	}
+	client.Close()
+	assert.Nil(t, client.refresher)
+
+	// Stopping from within a callback must not deadlock
+	stopped := make(chan struct{})
+	err = client.StartAutoRefresh(AutoRefreshOptions{
+		Margin: 30 * time.Minute,
+		Jitter: -1,
+		OnRefresh: func() {
+			client.StopAutoRefresh()
+			close(stopped)
+		},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Error("expected StopAutoRefresh to return from the callback")
+	}
+	assert.Nil(t, client.refresher)
+}
@@ -0,0 +1,130 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultUpdateLifetimesConcurrency = 4
+)
+
+// TokenLifetimes holds token lifetimes in seconds. Lifetimes which are zero
+// keep the current value of the client
+type TokenLifetimes struct {
+	AccessToken  int
+	RefreshToken int
+	IDToken      int
+}
+
+// UpdateTokenLifetimesOptions configures UpdateTokenLifetimes
+type UpdateTokenLifetimesOptions struct {
+	// Concurrency is the maximum number of clients updated in parallel. Defaults to 4
+	Concurrency int
+	// Progress is called after each client update with the number of clients
+	// processed so far. Calls are serialized
+	Progress func(done, total int, result ClientLifetimeResult)
+}
+
+// ClientLifetimeResult holds the outcome of a single client update of UpdateTokenLifetimes
+type ClientLifetimeResult struct {
+	Client  ApplicationClient
+	Updated *ApplicationClient
+	Err     error
+}
+
+// ClientErrors aggregates the per-client errors of a bulk operation
+type ClientErrors []*ClientError
+
+func (e ClientErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, fmt.Sprintf("%s: %v", err.Client, err.Err))
+	}
+	return fmt.Sprintf("%d client(s) failed: %s", len(e), strings.Join(messages, "; "))
+}
+
+// ClientError is the error of a single client of a bulk operation
+type ClientError struct {
+	Client string
+	Err    error
+}
+
+func (e *ClientError) Error() string { return "client: " + e.Client }
+
+func (e *ClientError) Unwrap() error { return e.Err }
+
+// UpdateTokenLifetimes applies lifetimes to all clients of the given application
+// using a bounded pool of workers. The results are returned in the order the
+// clients were found. When one or more updates fail the returned error is of type ClientErrors
+func (c *ClientsService) UpdateTokenLifetimes(applicationID string, lifetimes TokenLifetimes, opts UpdateTokenLifetimesOptions, options ...OptionFunc) ([]ClientLifetimeResult, error) {
+	if applicationID == "" {
+		return nil, ErrMissingApplicationID
+	}
+	if lifetimes.AccessToken < 0 || lifetimes.RefreshToken < 0 || lifetimes.IDToken < 0 {
+		return nil, ErrMalformedInputValue
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultUpdateLifetimesConcurrency
+	}
+	clients, err := c.ClientsIterator(context.Background(), &GetClientsOptions{ApplicationID: &applicationID}, options...).All()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]ClientLifetimeResult, len(clients))
+	for i, client := range clients {
+		results[i].Client = client
+	}
+
+	var progressLock sync.Mutex
+	done := 0
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Concurrency && w < len(clients); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				ac := results[i].Client
+				if lifetimes.AccessToken > 0 {
+					ac.AccessTokenLifetime = lifetimes.AccessToken
+				}
+				if lifetimes.RefreshToken > 0 {
+					ac.RefreshTokenLifetime = lifetimes.RefreshToken
+				}
+				if lifetimes.IDToken > 0 {
+					ac.IDTokenLifetime = lifetimes.IDToken
+				}
+				results[i].Updated, _, results[i].Err = c.UpdateClient(ac, options...)
+				if opts.Progress != nil {
+					progressLock.Lock()
+					done++
+					opts.Progress(done, len(clients), results[i])
+					progressLock.Unlock()
+				}
+			}
+		}()
+	}
+	for i := range clients {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, collectClientErrors(results)
+}
+
+func collectClientErrors(results []ClientLifetimeResult) error {
+	var errs ClientErrors
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, &ClientError{Client: r.Client.ClientID, Err: r.Err})
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,96 @@
+package iam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateTokenLifetimes(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	applicationID := "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	var clients []ApplicationClient
+	for i := 1; i <= 3; i++ {
+		clients = append(clients, ApplicationClient{
+			ID:                   fmt.Sprintf("client-%d", i),
+			ClientID:             fmt.Sprintf("testclient%d", i),
+			Name:                 fmt.Sprintf("Test client %d", i),
+			Type:                 "Confidential",
+			ApplicationID:        applicationID,
+			GlobalReferenceID:    fmt.Sprintf("ref-%d", i),
+			Realms:               []string{"/"},
+			AccessTokenLifetime:  1800,
+			RefreshTokenLifetime: 2592000,
+			IDTokenLifetime:      3600,
+		})
+	}
+
+	var lock sync.Mutex
+	updated := map[string]ApplicationClient{}
+	muxIDM.HandleFunc("/authorize/identity/Client", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, applicationID, r.URL.Query().Get("applicationId"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"total": len(clients),
+			"entry": clients,
+		})
+	})
+	muxIDM.HandleFunc("/authorize/identity/Client/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		id := strings.TrimPrefix(r.URL.Path, "/authorize/identity/Client/")
+		if id == "client-3" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var ac ApplicationClient
+		if err := json.NewDecoder(r.Body).Decode(&ac); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		lock.Lock()
+		updated[id] = ac
+		lock.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(ac)
+	})
+
+	_, err := client.Clients.UpdateTokenLifetimes("", TokenLifetimes{AccessToken: 900}, UpdateTokenLifetimesOptions{})
+	assert.Equal(t, ErrMissingApplicationID, err)
+
+	var progress []int
+	results, err := client.Clients.UpdateTokenLifetimes(applicationID, TokenLifetimes{AccessToken: 900, IDToken: 900},
+		UpdateTokenLifetimesOptions{
+			Concurrency: 2,
+			Progress: func(done, total int, result ClientLifetimeResult) {
+				assert.Equal(t, 3, total)
+				progress = append(progress, done)
+			},
+		})
+	if !assert.Len(t, results, 3) {
+		return
+	}
+	assert.Equal(t, []int{1, 2, 3}, progress)
+
+	var clientErrs ClientErrors
+	if assert.True(t, errors.As(err, &clientErrs)) && assert.Len(t, clientErrs, 1) {
+		assert.Equal(t, "testclient3", clientErrs[0].Client)
+	}
+	assert.Nil(t, results[0].Err)
+	assert.NotNil(t, results[2].Err)
+	assert.Len(t, updated, 2)
+	for _, ac := range updated {
+		assert.Equal(t, 900, ac.AccessTokenLifetime)
+		assert.Equal(t, 2592000, ac.RefreshTokenLifetime)
+		assert.Equal(t, 900, ac.IDTokenLifetime)
+	}
+}
@@ -0,0 +1,115 @@
+package iam
+
+import (
+	"context"
+)
+
+// ClientsIterator walks over all clients matching a search, fetching
+// additional pages from IAM on demand
+type ClientsIterator struct {
+	service *ClientsService
+	ctx     context.Context
+	opts    GetClientsOptions
+	options []OptionFunc
+
+	page       []ApplicationClient
+	index      int
+	startIndex int
+	lastPage   bool
+
+	current ApplicationClient
+	resp    *Response
+	err     error
+}
+
+// ClientsIterator returns an iterator over all clients matching opts. The Count field
+// of opts controls how many clients are fetched per request and defaults to 100.
+// Iteration stops when ctx is cancelled.
+func (c *ClientsService) ClientsIterator(ctx context.Context, opts *GetClientsOptions, options ...OptionFunc) *ClientsIterator {
+	it := &ClientsIterator{
+		service:    c,
+		ctx:        ctx,
+		options:    append([]OptionFunc{WithContext(ctx)}, options...),
+		startIndex: 1,
+	}
+	if opts != nil {
+		it.opts = *opts
+	}
+	if it.opts.Count == nil {
+		count := defaultIteratorPageSize
+		it.opts.Count = &count
+	}
+	if it.opts.StartIndex != nil && *it.opts.StartIndex > 0 {
+		it.startIndex = *it.opts.StartIndex
+	}
+	return it
+}
+
+// Next advances the iterator. It returns false when all clients have been
+// visited or an error occurred. Check Err() to distinguish between the two.
+func (it *ClientsIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+	for it.index >= len(it.page) {
+		if it.lastPage {
+			return false
+		}
+		if !it.fetch() {
+			return false
+		}
+	}
+	it.current = it.page[it.index]
+	it.index++
+	return true
+}
+
+func (it *ClientsIterator) fetch() bool {
+	startIndex := it.startIndex
+	it.opts.StartIndex = &startIndex
+	clients, resp, err := it.service.GetClients(&it.opts, it.options...)
+	it.resp = resp
+	if err != nil {
+		it.err = err
+		return false
+	}
+	// IAM may return fewer clients than requested, so only an empty page, or
+	// the same page again when startIndex is ignored, ends the iteration
+	if len(*clients) == 0 || (len(it.page) > 0 && (*clients)[0].ID == it.page[0].ID) {
+		it.page = nil
+		it.lastPage = true
+		return true
+	}
+	it.page = *clients
+	it.index = 0
+	it.startIndex += len(it.page)
+	return true
+}
+
+// Client returns the current client
+func (it *ClientsIterator) Client() ApplicationClient {
+	return it.current
+}
+
+// Response returns the response of the last page request
+func (it *ClientsIterator) Response() *Response {
+	return it.resp
+}
+
+// Err returns the error, if any, that stopped the iteration
+func (it *ClientsIterator) Err() error {
+	return it.err
+}
+
+// All collects the remaining clients of the iterator
+func (it *ClientsIterator) All() ([]ApplicationClient, error) {
+	var clients []ApplicationClient
+	for it.Next() {
+		clients = append(clients, it.Client())
+	}
+	return clients, it.Err()
+}
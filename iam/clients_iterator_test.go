@@ -0,0 +1,88 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientsIterator(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	applicationID := "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	total := 5
+	pageLimit := 100
+	ignoreStartIndex := false
+
+	muxIDM.HandleFunc("/authorize/identity/Client", func(w http.ResponseWriter, r *http.Request) {
+		qp := r.URL.Query()
+		assert.Equal(t, applicationID, qp.Get("applicationId"))
+		startIndex, _ := strconv.Atoi(qp.Get("startIndex"))
+		count, _ := strconv.Atoi(qp.Get("count"))
+		if count > pageLimit {
+			count = pageLimit
+		}
+		if ignoreStartIndex {
+			startIndex = 1
+		}
+		var entry []ApplicationClient
+		for i := startIndex; i < startIndex+count && i <= total; i++ {
+			entry = append(entry, ApplicationClient{ID: fmt.Sprintf("client-%d", i)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"total": total,
+			"entry": entry,
+		})
+	})
+
+	count := 2
+	it := client.Clients.ClientsIterator(context.Background(), &GetClientsOptions{
+		ApplicationID: &applicationID,
+		Count:         &count,
+	})
+	clients, err := it.All()
+	if !assert.Nil(t, err) {
+		return
+	}
+	if !assert.Len(t, clients, total) {
+		return
+	}
+	assert.Equal(t, "client-1", clients[0].ID)
+	assert.Equal(t, "client-5", clients[4].ID)
+	assert.NotNil(t, it.Response())
+
+	// A server capping the page size below count
+	pageLimit = 2
+	count = 3
+	it = client.Clients.ClientsIterator(context.Background(), &GetClientsOptions{
+		ApplicationID: &applicationID,
+		Count:         &count,
+	})
+	clients, err = it.All()
+	assert.Nil(t, err)
+	assert.Len(t, clients, total)
+
+	// A server ignoring startIndex
+	ignoreStartIndex = true
+	it = client.Clients.ClientsIterator(context.Background(), &GetClientsOptions{
+		ApplicationID: &applicationID,
+		Count:         &count,
+	})
+	clients, err = it.All()
+	assert.Nil(t, err)
+	assert.Len(t, clients, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	it = client.Clients.ClientsIterator(ctx, &GetClientsOptions{ApplicationID: &applicationID})
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+}
@@ -2,7 +2,9 @@ package iam
 
 import (
 	"bytes"
+	"crypto/rand"
 	"fmt"
+	"math/big"
 	"net/http"
 
 	validator "github.com/go-playground/validator/v10"
@@ -12,6 +14,16 @@ var (
 	clientAPIVersion = "1"
 )
 
+const (
+	clientScopeUpdateRetries = 3
+
+	clientSecretLength = 16
+	secretLower        = "abcdefghijkmnopqrstuvwxyz"
+	secretUpper        = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	secretDigits       = "23456789"
+	secretSpecial      = "!#$%*+-=?@_"
+)
+
 // ApplicationClient represents an IAM client resource
 type ApplicationClient struct {
 	ID                   string      `json:"id,omitempty"`
@@ -35,10 +47,8 @@ type ApplicationClient struct {
 	Meta                 *ClientMeta `json:"meta,omitempty"`
 }
 
-type ClientMeta struct {
-	VersionID    string `json:"versionId,omitempty"`
-	LastModified string `json:"lastModified,omitempty"`
-}
+// ClientMeta holds the version information of a Client
+type ClientMeta = VersionMeta
 
 // ClientsService provides operations on IAM roles resources
 type ClientsService struct {
@@ -47,17 +57,39 @@ type ClientsService struct {
 	validate *validator.Validate
 }
 
+// SetValidator replaces the validator used for ApplicationClient resources.
+// Passing nil restores the default validator. This is not safe to call
+// while requests are in flight
+func (c *ClientsService) SetValidator(v *validator.Validate) {
+	if v == nil {
+		v = validator.New()
+	}
+	c.validate = v
+}
+
+// SetValidationRules overrides the validation tags of individual ApplicationClient
+// fields, keyed by field name. Fields which are not listed keep their default rules e.g.
+//
+//	client.Clients.SetValidationRules(map[string]string{"Password": "required_without=ID,max=32"})
+func (c *ClientsService) SetValidationRules(rules map[string]string) {
+	v := validator.New()
+	v.RegisterStructValidationMapRules(rules, ApplicationClient{})
+	c.validate = v
+}
+
 // GetClientsOptions describes search criteria for looking up roles
 type GetClientsOptions struct {
 	ID                *string `url:"_id,omitempty"`
 	Name              *string `url:"name,omitempty"`
 	GlobalReferenceID *string `url:"globalReferenceId,omitempty"`
 	ApplicationID     *string `url:"applicationId,omitempty"`
+	StartIndex        *int    `url:"startIndex,omitempty"`
+	Count             *int    `url:"count,omitempty"`
 }
 
 // CreateClient creates a Client
-func (c *ClientsService) CreateClient(ac ApplicationClient) (*ApplicationClient, *Response, error) {
-	if err := c.validate.Struct(ac); err != nil {
+func (c *ClientsService) CreateClient(ac ApplicationClient, options ...OptionFunc) (*ApplicationClient, *Response, error) {
+	if err := validateStruct(c.validate, ac); err != nil {
 		return nil, nil, err
 	}
 
@@ -67,7 +99,7 @@ func (c *ClientsService) CreateClient(ac ApplicationClient) (*ApplicationClient,
 	ac.Scopes = []string{}            // Defaults to ["mail", "sn"]
 	ac.DefaultScopes = []string{}
 
-	req, _ := c.client.newRequest(IDM, "POST", "authorize/identity/Client", ac, nil)
+	req, _ := c.client.newRequest(IDM, "POST", "authorize/identity/Client", ac, options)
 	req.Header.Set("api-version", clientAPIVersion)
 
 	var createdClient ApplicationClient
@@ -88,18 +120,18 @@ func (c *ClientsService) CreateClient(ac ApplicationClient) (*ApplicationClient,
 	}
 	ac.ID = id
 	if len(scopes) > 0 {
-		_, resp, err := c.UpdateScopes(ac, scopes, defaultScopes)
+		_, resp, err := c.UpdateScopes(ac, scopes, defaultScopes, options...)
 		if err != nil {
-			_, _, _ = c.DeleteClient(ac) // Clean up
+			_, _, _ = c.DeleteClient(ac, options...) // Clean up
 			return nil, resp, fmt.Errorf("CreateClient.UpdateScopes: %w", err)
 		}
 	}
-	return c.GetClientByID(id)
+	return c.GetClientByID(id, options...)
 }
 
 // DeleteClient deletes the given Client
-func (c *ClientsService) DeleteClient(ac ApplicationClient) (bool, *Response, error) {
-	req, err := c.client.newRequest(IDM, "DELETE", "authorize/identity/Client/"+ac.ID, nil, nil)
+func (c *ClientsService) DeleteClient(ac ApplicationClient, options ...OptionFunc) (bool, *Response, error) {
+	req, err := c.client.newRequest(IDM, "DELETE", "authorize/identity/Client/"+ac.ID, nil, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -115,8 +147,8 @@ func (c *ClientsService) DeleteClient(ac ApplicationClient) (bool, *Response, er
 }
 
 // GetClientByID finds a client by its ID
-func (c *ClientsService) GetClientByID(id string) (*ApplicationClient, *Response, error) {
-	clients, resp, err := c.GetClients(&GetClientsOptions{ID: &id}, nil)
+func (c *ClientsService) GetClientByID(id string, options ...OptionFunc) (*ApplicationClient, *Response, error) {
+	clients, resp, err := c.GetClients(&GetClientsOptions{ID: &id}, options...)
 
 	if err != nil {
 		return nil, resp, err
@@ -154,7 +186,7 @@ func (c *ClientsService) GetClients(opt *GetClientsOptions, options ...OptionFun
 }
 
 // UpdateScope updates a clients scope
-func (c *ClientsService) UpdateScopes(ac ApplicationClient, scopes []string, defaultScopes []string) (bool, *Response, error) {
+func (c *ClientsService) UpdateScopes(ac ApplicationClient, scopes []string, defaultScopes []string, options ...OptionFunc) (bool, *Response, error) {
 	var requestBody = struct {
 		Scopes        []string `json:"scopes"`
 		DefaultScopes []string `json:"defaultScopes"`
@@ -162,7 +194,7 @@ func (c *ClientsService) UpdateScopes(ac ApplicationClient, scopes []string, def
 		scopes,
 		defaultScopes,
 	}
-	req, err := c.client.newRequest(IDM, "PUT", "authorize/identity/Client/"+ac.ID+"/$scopes", requestBody, nil)
+	req, err := c.client.newRequest(IDM, "PUT", "authorize/identity/Client/"+ac.ID+"/$scopes", requestBody, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -180,12 +212,72 @@ func (c *ClientsService) UpdateScopes(ac ApplicationClient, scopes []string, def
 	return true, resp, nil
 }
 
+// AddScopes adds scopes and default scopes to the client while keeping the
+// current ones. Default scopes are also added as scopes
+func (c *ClientsService) AddScopes(ac ApplicationClient, scopes []string, defaultScopes []string, options ...OptionFunc) (bool, *Response, error) {
+	return c.modifyScopes(ac, func(current *ApplicationClient) ([]string, []string) {
+		newScopes := mergeScopes(mergeScopes(current.Scopes, scopes, nil), defaultScopes, nil)
+		newDefaultScopes := mergeScopes(current.DefaultScopes, defaultScopes, nil)
+		return newScopes, newDefaultScopes
+	}, options)
+}
+
+// RemoveScopes removes scopes from the client. Removed scopes are also removed from the default scopes
+func (c *ClientsService) RemoveScopes(ac ApplicationClient, scopes []string, options ...OptionFunc) (bool, *Response, error) {
+	return c.modifyScopes(ac, func(current *ApplicationClient) ([]string, []string) {
+		return mergeScopes(current.Scopes, nil, scopes), mergeScopes(current.DefaultScopes, nil, scopes)
+	}, options)
+}
+
+// modifyScopes reads the current scopes, applies fn and writes the result. When
+// the client was modified concurrently the update is retried on the fresh state
+func (c *ClientsService) modifyScopes(ac ApplicationClient, fn func(current *ApplicationClient) ([]string, []string), options []OptionFunc) (bool, *Response, error) {
+	var resp *Response
+	var err error
+	for attempt := 0; attempt < clientScopeUpdateRetries; attempt++ {
+		var current *ApplicationClient
+		current, resp, err = c.GetClientByID(ac.ID, options...)
+		if err != nil {
+			return false, resp, err
+		}
+		scopes, defaultScopes := fn(current)
+		updateOptions := options
+		if current.Meta != nil && current.Meta.VersionID != "" {
+			updateOptions = append([]OptionFunc{addIfMatchHeader(current.Meta.VersionID)}, options...)
+		}
+		var ok bool
+		ok, resp, err = c.UpdateScopes(*current, scopes, defaultScopes, updateOptions...)
+		if resp != nil && (resp.StatusCode == http.StatusConflict || resp.StatusCode == http.StatusPreconditionFailed) {
+			continue
+		}
+		return ok, resp, err
+	}
+	return false, resp, fmt.Errorf("modifyScopes: %w", ErrConcurrentModification)
+}
+
+// mergeScopes returns current with add appended and remove removed, without duplicates
+func mergeScopes(current, add, remove []string) []string {
+	skip := make(map[string]bool)
+	for _, r := range remove {
+		skip[r] = true
+	}
+	merged := []string{}
+	for _, s := range append(append([]string{}, current...), add...) {
+		if skip[s] {
+			continue
+		}
+		skip[s] = true
+		merged = append(merged, s)
+	}
+	return merged
+}
+
 // UpdateClient updates a client
-func (c *ClientsService) UpdateClient(ac ApplicationClient) (*ApplicationClient, *Response, error) {
-	if err := c.validate.Struct(ac); err != nil {
+func (c *ClientsService) UpdateClient(ac ApplicationClient, options ...OptionFunc) (*ApplicationClient, *Response, error) {
+	if err := validateStruct(c.validate, ac); err != nil {
 		return nil, nil, err
 	}
-	req, err := c.client.newRequest(IDM, "PUT", "authorize/identity/Client/"+ac.ID, ac, nil)
+	req, err := c.client.newRequest(IDM, "PUT", "authorize/identity/Client/"+ac.ID, ac, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -199,3 +291,68 @@ func (c *ClientsService) UpdateClient(ac ApplicationClient) (*ApplicationClient,
 	}
 	return &updatedClient, resp, nil
 }
+
+// UpdateClientPassword changes the password of a client. The current state of the
+// client is fetched first so only the password is changed
+func (c *ClientsService) UpdateClientPassword(ac ApplicationClient, newPassword string, options ...OptionFunc) (*ApplicationClient, *Response, error) {
+	if newPassword == "" {
+		return nil, nil, fmt.Errorf("UpdateClientPassword: %w", ErrMissingPassword)
+	}
+	current, resp, err := c.GetClientByID(ac.ID, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	current.Password = newPassword
+	return c.UpdateClient(*current, options...)
+}
+
+// RotateSecret generates a new random password for the client and sets it.
+// The generated secret is returned and cannot be retrieved from IAM afterwards
+func (c *ClientsService) RotateSecret(ac ApplicationClient, options ...OptionFunc) (string, *ApplicationClient, *Response, error) {
+	secret, err := GenerateClientSecret()
+	if err != nil {
+		return "", nil, nil, err
+	}
+	updatedClient, resp, err := c.UpdateClientPassword(ac, secret, options...)
+	if err != nil {
+		return "", nil, resp, err
+	}
+	return secret, updatedClient, resp, nil
+}
+
+// GenerateClientSecret returns a random secret which satisfies the
+// IAM client password rules: 16 characters containing upper and lower case
+// letters, digits and special characters
+func GenerateClientSecret() (string, error) {
+	classes := []string{secretLower, secretUpper, secretDigits, secretSpecial}
+	all := secretLower + secretUpper + secretDigits + secretSpecial
+	secret := make([]byte, clientSecretLength)
+	for i := range secret {
+		set := all
+		if i < len(classes) {
+			set = classes[i]
+		}
+		ch, err := randomChar(set)
+		if err != nil {
+			return "", err
+		}
+		secret[i] = ch
+	}
+	// Shuffle so the guaranteed characters are not always at the start
+	for i := len(secret) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		secret[i], secret[j.Int64()] = secret[j.Int64()], secret[i]
+	}
+	return string(secret), nil
+}
+
+func randomChar(set string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(set))))
+	if err != nil {
+		return 0, err
+	}
+	return set[n.Int64()], nil
+}
@@ -1,8 +1,12 @@
 package iam
 
 import (
+	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/go-playground/validator/v10"
@@ -143,6 +147,23 @@ func TestClientCRUD(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 	assert.Equal(t, "Public", cl.Type)
 
+	cl, resp, err = client.Clients.UpdateClientPassword(*createdClient, "N3wP@ssword")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotNil(t, cl)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	_, _, err = client.Clients.UpdateClientPassword(*createdClient, "")
+	assert.ErrorIs(t, err, ErrMissingPassword)
+
+	secret, cl, resp, err := client.Clients.RotateSecret(*createdClient)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotNil(t, cl)
+	assert.NotNil(t, resp)
+	assert.Len(t, secret, 16)
+
 	ok, resp, err := client.Clients.DeleteClient(*createdClient)
 	assert.True(t, ok)
 	assert.Nil(t, err)
@@ -171,3 +192,129 @@ func TestPasswordValidation(t *testing.T) {
 	err = validate.Struct(c)
 	assert.Nil(t, err)
 }
+
+func TestGenerateClientSecret(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		secret, err := GenerateClientSecret()
+		if !assert.Nil(t, err) {
+			return
+		}
+		assert.Len(t, secret, 16)
+		assert.True(t, strings.ContainsAny(secret, secretLower))
+		assert.True(t, strings.ContainsAny(secret, secretUpper))
+		assert.True(t, strings.ContainsAny(secret, secretDigits))
+		assert.True(t, strings.ContainsAny(secret, secretSpecial))
+	}
+}
+
+func TestAddRemoveScopes(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	clientID := "a0d7c4a3-7d6d-4cfc-9e56-3d2c4f7b6a01"
+	version := 1
+	scopes := []string{"mail", "sn"}
+	defaultScopes := []string{"mail"}
+	conflicts := 1
+
+	muxIDM.HandleFunc("/authorize/identity/Client", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		body, _ := json.Marshal(struct {
+			Total int                 `json:"total"`
+			Entry []ApplicationClient `json:"entry"`
+		}{1, []ApplicationClient{{
+			ID:            clientID,
+			Scopes:        scopes,
+			DefaultScopes: defaultScopes,
+			Meta:          &ClientMeta{VersionID: strconv.Itoa(version)},
+		}}})
+		_, _ = w.Write(body)
+	})
+	muxIDM.HandleFunc("/authorize/identity/Client/"+clientID+"/$scopes", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if conflicts > 0 || r.Header.Get("If-Match") != strconv.Itoa(version) {
+			conflicts--
+			version++ // Simulate a concurrent update
+			w.WriteHeader(http.StatusConflict)
+			_, _ = io.WriteString(w, `{"resourceType": "OperationOutcome","issue": [{"severity": "error","code": "conflict"}]}`)
+			return
+		}
+		var update struct {
+			Scopes        []string `json:"scopes"`
+			DefaultScopes []string `json:"defaultScopes"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&update)
+		scopes = update.Scopes
+		defaultScopes = update.DefaultScopes
+		version++
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ac := ApplicationClient{ID: clientID}
+	ok, resp, err := client.Clients.AddScopes(ac, []string{"cn", "mail"}, []string{"sn"})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.True(t, ok)
+	assert.NotNil(t, resp)
+	assert.Equal(t, []string{"mail", "sn", "cn"}, scopes)
+	assert.Equal(t, []string{"mail", "sn"}, defaultScopes)
+
+	ok, _, err = client.Clients.RemoveScopes(ac, []string{"mail"})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.True(t, ok)
+	assert.Equal(t, []string{"sn", "cn"}, scopes)
+	assert.Equal(t, []string{"sn"}, defaultScopes)
+
+	conflicts = clientScopeUpdateRetries
+	ok, _, err = client.Clients.RemoveScopes(ac, []string{"cn"})
+	assert.False(t, ok)
+	assert.ErrorIs(t, err, ErrConcurrentModification)
+	assert.Equal(t, []string{"sn", "cn"}, scopes)
+}
+
+func TestClientValidation(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	ac := ApplicationClient{
+		ClientID:          "testclient",
+		Type:              "Public",
+		Name:              "abc",
+		Password:          "ThisPasswordIsLong1!",
+		RedirectionURIs:   []string{"https://example.com/callback"},
+		ApplicationID:     "b8ffc78d-4c14-4ec1-b1b3-ab28e20a1c0b",
+		GlobalReferenceID: "some-ref",
+	}
+	_, _, err := client.Clients.CreateClient(ac)
+	var validationErr *ValidationError
+	if !assert.True(t, errors.As(err, &validationErr)) {
+		return
+	}
+	if !assert.Len(t, validationErr.Violations, 2) {
+		return
+	}
+	assert.Equal(t, "ApplicationClient.Name", validationErr.Violations[0].Field)
+	assert.Equal(t, "min", validationErr.Violations[0].Rule)
+	assert.Equal(t, "5", validationErr.Violations[0].Param)
+	assert.Equal(t, "ApplicationClient.Password", validationErr.Violations[1].Field)
+	assert.Equal(t, "max", validationErr.Violations[1].Rule)
+
+	client.Clients.SetValidationRules(map[string]string{
+		"Name":     "required,min=3,max=50",
+		"Password": "required_without=ID,max=32",
+	})
+	assert.Nil(t, validateStruct(client.Clients.validate, ac))
+	ac.ClientID = "abc"
+	assert.NotNil(t, validateStruct(client.Clients.validate, ac))
+
+	client.Clients.SetValidator(nil)
+	assert.NotNil(t, validateStruct(client.Clients.validate, ac))
+}
@@ -1,6 +1,8 @@
 package iam
 
 import (
+	"time"
+
 	hsdpsigner "github.com/philips-software/go-hsdp-signer"
 )
 
@@ -23,4 +25,26 @@ type Config struct {
 	Debug            bool
 	DebugLog         string
 	Signer           *hsdpsigner.Signer
+	// SignatureVersion selects the HSDP API signing algorithm used when Signer
+	// is not set. Use SignatureV2 to also sign the method, query and body
+	SignatureVersion int
+	// TokenStore persists the tokens after each login or refresh. Stored tokens
+	// are loaded when the client is created so a login can be skipped
+	TokenStore TokenStore
+	// FailoverIAMURLs and FailoverIDMURLs list secondary endpoints which are used
+	// in order when the primary endpoint returns connection errors or 5xx responses
+	FailoverIAMURLs []string
+	FailoverIDMURLs []string
+	// FailoverRegions lists secondary regions whose IAM and IDM endpoints are
+	// resolved for Environment and appended to the failover URLs
+	FailoverRegions []string
+	// FailoverRecoveryInterval is the time after which a failed endpoint is
+	// health checked and used again. Defaults to 1 minute
+	FailoverRecoveryInterval time.Duration
+	// SecretProvider is called when IAM rejects OAuth2Secret during a token
+	// refresh. When it returns a different secret the refresh is retried once
+	SecretProvider SecretProvider
+	// Metrics receives measurements of requests, token refreshes,
+	// introspects and failed logins
+	Metrics Metrics
 }
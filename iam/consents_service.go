@@ -0,0 +1,224 @@
+package iam
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	consentAPIVersion = "1"
+)
+
+// ConsentsService provides operations on terms of use documents and the consents users give to them
+type ConsentsService struct {
+	client *Client
+
+	validate *validator.Validate
+}
+
+// TermsDocument is a terms and conditions document of an organization
+type TermsDocument struct {
+	ID             string     `json:"id,omitempty"`
+	Name           string     `json:"name" validate:"required,min=1,max=255"`
+	Version        string     `json:"version" validate:"required"`
+	OrganizationID string     `json:"organizationId" validate:"required"`
+	Locale         string     `json:"locale,omitempty"`
+	URL            string     `json:"url,omitempty" validate:"required_without=Content,omitempty,url"`
+	Content        string     `json:"content,omitempty"`
+	Mandatory      bool       `json:"mandatory"`
+	EffectiveFrom  *time.Time `json:"effectiveFrom,omitempty"`
+	Meta           *Meta      `json:"meta,omitempty"`
+}
+
+// Consent records the acceptance of a version of a TermsDocument by a user
+type Consent struct {
+	ID           string    `json:"id,omitempty"`
+	UserID       string    `json:"userId"`
+	TermsID      string    `json:"termsId"`
+	TermsVersion string    `json:"termsVersion"`
+	AcceptedAt   time.Time `json:"acceptedAt"`
+}
+
+// GetTermsOptions describes the criteria for looking up terms documents
+type GetTermsOptions struct {
+	ID             *string `url:"_id,omitempty"`
+	Name           *string `url:"name,omitempty"`
+	OrganizationID *string `url:"organizationId,omitempty"`
+	Locale         *string `url:"locale,omitempty"`
+}
+
+// GetConsentsOptions describes the criteria for looking up consents
+type GetConsentsOptions struct {
+	UserID         *string `url:"userId,omitempty"`
+	TermsID        *string `url:"termsId,omitempty"`
+	OrganizationID *string `url:"organizationId,omitempty"`
+}
+
+// CreateTerms creates a terms document
+func (c *ConsentsService) CreateTerms(terms TermsDocument, options ...OptionFunc) (*TermsDocument, *Response, error) {
+	if err := c.validate.Struct(terms); err != nil {
+		return nil, nil, err
+	}
+	req, err := c.client.newRequest(IDM, "POST", "authorize/identity/Terms", &terms, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", consentAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var createdTerms TermsDocument
+
+	resp, err := c.client.do(req, &createdTerms)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &createdTerms, resp, nil
+}
+
+// GetTermsByID retrieves a terms document by ID
+func (c *ConsentsService) GetTermsByID(id string, options ...OptionFunc) (*TermsDocument, *Response, error) {
+	req, err := c.client.newRequest(IDM, "GET", "authorize/identity/Terms/"+id, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", consentAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var terms TermsDocument
+
+	resp, err := c.client.do(req, &terms)
+	if err != nil {
+		return nil, resp, err
+	}
+	if terms.ID != id {
+		return nil, resp, ErrNotFound
+	}
+	return &terms, resp, nil
+}
+
+// GetTerms looks up terms documents based on GetTermsOptions
+func (c *ConsentsService) GetTerms(opt *GetTermsOptions, options ...OptionFunc) (*[]TermsDocument, *Response, error) {
+	req, err := c.client.newRequest(IDM, "GET", "authorize/identity/Terms", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", consentAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var bundleResponse struct {
+		Total int             `json:"total"`
+		Entry []TermsDocument `json:"entry"`
+	}
+
+	resp, err := c.client.do(req, &bundleResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &bundleResponse.Entry, resp, nil
+}
+
+// UpdateTerms updates a terms document. Publishing a new Version requires users to consent again
+func (c *ConsentsService) UpdateTerms(terms TermsDocument, options ...OptionFunc) (*TermsDocument, *Response, error) {
+	if terms.Meta == nil {
+		return nil, nil, ErrMissingEtagInformation
+	}
+	if err := c.validate.Struct(terms); err != nil {
+		return nil, nil, err
+	}
+	req, err := c.client.newRequest(IDM, "PUT", "authorize/identity/Terms/"+terms.ID, &terms, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", consentAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", terms.Meta.Version)
+
+	var updatedTerms TermsDocument
+
+	resp, err := c.client.do(req, &updatedTerms)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &updatedTerms, resp, nil
+}
+
+// DeleteTerms deletes the given terms document
+func (c *ConsentsService) DeleteTerms(terms TermsDocument, options ...OptionFunc) (bool, *Response, error) {
+	req, err := c.client.newRequest(IDM, "DELETE", "authorize/identity/Terms/"+terms.ID, nil, options)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("api-version", consentAPIVersion)
+
+	var deleteResponse bytes.Buffer
+
+	resp, err := c.client.do(req, &deleteResponse)
+	if resp == nil || resp.StatusCode != http.StatusNoContent {
+		return false, resp, err
+	}
+	return true, resp, nil
+}
+
+// RecordConsent records that the user accepted the current version of the terms document
+func (c *ConsentsService) RecordConsent(userID string, terms TermsDocument, options ...OptionFunc) (*Consent, *Response, error) {
+	body := Consent{
+		UserID:       userID,
+		TermsID:      terms.ID,
+		TermsVersion: terms.Version,
+	}
+	req, err := c.client.newRequest(IDM, "POST", "authorize/identity/Consent", &body, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", consentAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var consent Consent
+
+	resp, err := c.client.do(req, &consent)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &consent, resp, nil
+}
+
+// GetConsents looks up recorded consents based on GetConsentsOptions
+func (c *ConsentsService) GetConsents(opt *GetConsentsOptions, options ...OptionFunc) (*[]Consent, *Response, error) {
+	req, err := c.client.newRequest(IDM, "GET", "authorize/identity/Consent", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", consentAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var bundleResponse struct {
+		Total int       `json:"total"`
+		Entry []Consent `json:"entry"`
+	}
+
+	resp, err := c.client.do(req, &bundleResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &bundleResponse.Entry, resp, nil
+}
+
+// HasConsented returns true if the user accepted the current version of the terms document
+func (c *ConsentsService) HasConsented(userID string, terms TermsDocument, options ...OptionFunc) (bool, *Response, error) {
+	consents, resp, err := c.GetConsents(&GetConsentsOptions{
+		UserID:  &userID,
+		TermsID: &terms.ID,
+	}, options...)
+	if err != nil {
+		return false, resp, err
+	}
+	for _, consent := range *consents {
+		if consent.TermsVersion == terms.Version {
+			return true, resp, nil
+		}
+	}
+	return false, resp, nil
+}
@@ -0,0 +1,128 @@
+package iam
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testTermsJSON(id, version string) string {
+	return `{
+  "id": "` + id + `",
+  "name": "Patient Portal Terms",
+  "version": "` + version + `",
+  "organizationId": "c57b2625-eda3-4b27-a8e6-86f0a0e76afc",
+  "locale": "en-US",
+  "url": "https://portal.example.com/terms/` + version + `",
+  "mandatory": true,
+  "meta": {"version": "W/\"1\""}
+}`
+}
+
+func TestConsents(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	termsID := "d1a7d6a4-62a3-4b43-9b36-5a0f0a0c7f11"
+	userID := "f5fe538f-c3b5-4454-8774-cd3789f59b9f"
+
+	muxIDM.HandleFunc("/authorize/identity/Terms", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "POST":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, testTermsJSON(termsID, "1.0"))
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{"total":1,"entry":[`+testTermsJSON(termsID, "1.0")+`]}`)
+		}
+	})
+	muxIDM.HandleFunc("/authorize/identity/Terms/"+termsID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, testTermsJSON(termsID, "1.0"))
+		case "PUT":
+			assert.Equal(t, `W/"1"`, r.Header.Get("If-Match"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, testTermsJSON(termsID, "2.0"))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	muxIDM.HandleFunc("/authorize/identity/Consent", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "POST":
+			var consent Consent
+			if err := json.NewDecoder(r.Body).Decode(&consent); err != nil || consent.UserID != userID {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = io.WriteString(w, `{"resourceType": "OperationOutcome","issue": [{"severity": "error","code": "invalid"}]}`)
+				return
+			}
+			consent.ID = "0d8f7d5e-8f0a-4b8e-9f3c-1a2b3c4d5e6f"
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(consent)
+		case "GET":
+			assert.Equal(t, userID, r.URL.Query().Get("userId"))
+			assert.Equal(t, termsID, r.URL.Query().Get("termsId"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{"total":1,"entry":[{
+				"id": "0d8f7d5e-8f0a-4b8e-9f3c-1a2b3c4d5e6f",
+				"userId": "`+userID+`",
+				"termsId": "`+termsID+`",
+				"termsVersion": "1.0",
+				"acceptedAt": "2022-07-01T12:00:00Z"
+			}]}`)
+		}
+	})
+
+	terms := TermsDocument{
+		Name:           "Patient Portal Terms",
+		Version:        "1.0",
+		OrganizationID: "c57b2625-eda3-4b27-a8e6-86f0a0e76afc",
+		Mandatory:      true,
+	}
+	_, _, err := client.Consents.CreateTerms(terms)
+	assert.NotNil(t, err, "terms without URL or content should fail validation")
+
+	terms.URL = "https://portal.example.com/terms/1.0"
+	created, resp, err := client.Consents.CreateTerms(terms)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, termsID, created.ID)
+
+	consent, _, err := client.Consents.RecordConsent(userID, *created)
+	if assert.Nil(t, err) {
+		assert.Equal(t, "1.0", consent.TermsVersion)
+	}
+	ok, _, err := client.Consents.HasConsented(userID, *created)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	updated, _, err := client.Consents.UpdateTerms(*created)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "2.0", updated.Version)
+	ok, _, err = client.Consents.HasConsented(userID, *updated)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	list, _, err := client.Consents.GetTerms(&GetTermsOptions{OrganizationID: &terms.OrganizationID})
+	if assert.Nil(t, err) {
+		assert.Len(t, *list, 1)
+	}
+	_, _, err = client.Consents.GetTermsByID(termsID)
+	assert.Nil(t, err)
+
+	ok, _, err = client.Consents.DeleteTerms(*created)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
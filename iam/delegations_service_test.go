@@ -0,0 +1,98 @@
+package iam
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testDelegationJSON(id string) string {
+	return `{
+  "id": "` + id + `",
+  "delegatorId": "f5fe538f-c3b5-4454-8774-cd3789f59b9f",
+  "delegateeId": "867128a6-0e02-431c-ba1e-9e764436dae4",
+  "organizationId": "c57b2625-eda3-4b27-a8e6-86f0a0e76afc",
+  "permissions": ["CARE_PLAN.READ"],
+  "purpose": "Proxy access for caregiver",
+  "validFrom": "2022-07-01T00:00:00Z",
+  "validTo": "2022-12-31T00:00:00Z",
+  "status": "ACTIVE"
+}`
+}
+
+func TestDelegations(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	delegationID := "2bd4a0e4-8c4c-4c8a-9cfa-86e2a7a3a5d1"
+	delegatorID := "f5fe538f-c3b5-4454-8774-cd3789f59b9f"
+
+	muxIDM.HandleFunc("/authorize/identity/Delegation", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "POST":
+			var delegation Delegation
+			if err := json.NewDecoder(r.Body).Decode(&delegation); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, testDelegationJSON(delegationID))
+		case "GET":
+			assert.Equal(t, delegatorID, r.URL.Query().Get("delegatorId"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{"total":1,"entry":[`+testDelegationJSON(delegationID)+`]}`)
+		}
+	})
+	muxIDM.HandleFunc("/authorize/identity/Delegation/"+delegationID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, testDelegationJSON(delegationID))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	validFrom := time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)
+	delegation := Delegation{
+		DelegatorID:    delegatorID,
+		DelegateeID:    "867128a6-0e02-431c-ba1e-9e764436dae4",
+		OrganizationID: "c57b2625-eda3-4b27-a8e6-86f0a0e76afc",
+		Permissions:    []string{"CARE_PLAN.READ"},
+		ValidFrom:      validFrom,
+		ValidTo:        validFrom.Add(-time.Hour),
+	}
+	_, _, err := client.Delegations.GrantDelegation(delegation)
+	assert.NotNil(t, err, "validTo before validFrom should fail validation")
+
+	delegation.ValidTo = time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC)
+	created, resp, err := client.Delegations.GrantDelegation(delegation)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, delegationID, created.ID)
+	assert.True(t, created.Active(time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, created.Active(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	found, _, err := client.Delegations.GetDelegationByID(delegationID)
+	if assert.Nil(t, err) {
+		assert.Equal(t, DelegationStatusActive, found.Status)
+	}
+
+	list, _, err := client.Delegations.GetDelegations(&GetDelegationsOptions{DelegatorID: &delegatorID})
+	if assert.Nil(t, err) {
+		assert.Len(t, *list, 1)
+	}
+
+	ok, resp, err := client.Delegations.RevokeDelegation(*created)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
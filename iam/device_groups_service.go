@@ -0,0 +1,231 @@
+package iam
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	deviceGroupAPIVersion = "1"
+)
+
+// DeviceGroupsService provides operations on IAM device groups. Like user groups,
+// roles assigned to a device group apply to all device identities in the group
+type DeviceGroupsService struct {
+	client *Client
+
+	validate *validator.Validate
+}
+
+// DeviceGroup represents an IAM device group resource
+type DeviceGroup struct {
+	ID                   string `json:"id,omitempty"`
+	Name                 string `json:"name" validate:"required,max=50"`
+	Description          string `json:"description,omitempty" validate:"max=250"`
+	ManagingOrganization string `json:"managingOrganization" validate:"required"`
+	Meta                 *Meta  `json:"meta,omitempty"`
+}
+
+// GetDeviceGroupsOptions describes the criteria for looking up device groups
+type GetDeviceGroupsOptions struct {
+	ID             *string `url:"_id,omitempty"`
+	OrganizationID *string `url:"organizationId,omitempty"`
+	Name           *string `url:"name,omitempty"`
+	DeviceID       *string `url:"deviceId,omitempty"`
+	Count          *int    `url:"_count,omitempty"`
+	Page           *int    `url:"_page,omitempty"`
+}
+
+// CreateDeviceGroup creates a device group
+func (d *DeviceGroupsService) CreateDeviceGroup(group DeviceGroup, options ...OptionFunc) (*DeviceGroup, *Response, error) {
+	if err := d.validate.Struct(group); err != nil {
+		return nil, nil, err
+	}
+	req, err := d.client.newRequest(IDM, "POST", "authorize/identity/DeviceGroup", &group, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", deviceGroupAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var createdGroup DeviceGroup
+
+	resp, err := d.client.do(req, &createdGroup)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &createdGroup, resp, nil
+}
+
+// GetDeviceGroupByID retrieves a device group by ID
+func (d *DeviceGroupsService) GetDeviceGroupByID(id string, options ...OptionFunc) (*DeviceGroup, *Response, error) {
+	req, err := d.client.newRequest(IDM, "GET", "authorize/identity/DeviceGroup/"+id, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", deviceGroupAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var group DeviceGroup
+
+	resp, err := d.client.do(req, &group)
+	if err != nil {
+		return nil, resp, err
+	}
+	if group.ID != id {
+		return nil, resp, ErrNotFound
+	}
+	return &group, resp, nil
+}
+
+// GetDeviceGroups looks up device groups based on GetDeviceGroupsOptions
+func (d *DeviceGroupsService) GetDeviceGroups(opt *GetDeviceGroupsOptions, options ...OptionFunc) (*[]DeviceGroup, *Response, error) {
+	req, err := d.client.newRequest(IDM, "GET", "authorize/identity/DeviceGroup", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", deviceGroupAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var bundleResponse struct {
+		Total int           `json:"total"`
+		Entry []DeviceGroup `json:"entry"`
+	}
+
+	resp, err := d.client.do(req, &bundleResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &bundleResponse.Entry, resp, nil
+}
+
+// UpdateDeviceGroup updates the name and description of a device group
+func (d *DeviceGroupsService) UpdateDeviceGroup(group DeviceGroup, options ...OptionFunc) (*DeviceGroup, *Response, error) {
+	if group.Meta == nil {
+		return nil, nil, ErrMissingEtagInformation
+	}
+	if err := d.validate.Struct(group); err != nil {
+		return nil, nil, err
+	}
+	req, err := d.client.newRequest(IDM, "PUT", "authorize/identity/DeviceGroup/"+group.ID, &group, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", deviceGroupAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", group.Meta.Version)
+
+	var updatedGroup DeviceGroup
+
+	resp, err := d.client.do(req, &updatedGroup)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &updatedGroup, resp, nil
+}
+
+// DeleteDeviceGroup deletes the given device group
+func (d *DeviceGroupsService) DeleteDeviceGroup(group DeviceGroup, options ...OptionFunc) (bool, *Response, error) {
+	req, err := d.client.newRequest(IDM, "DELETE", "authorize/identity/DeviceGroup/"+group.ID, nil, options)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("api-version", deviceGroupAPIVersion)
+
+	var deleteResponse bytes.Buffer
+
+	resp, err := d.client.do(req, &deleteResponse)
+	if resp == nil || resp.StatusCode != http.StatusNoContent {
+		return false, resp, err
+	}
+	return true, resp, nil
+}
+
+func (d *DeviceGroupsService) groupAction(group DeviceGroup, action string, body interface{}, options []OptionFunc) (MemberResponse, *Response, error) {
+	req, err := d.client.newRequest(IDM, "POST", "authorize/identity/DeviceGroup/"+group.ID+"/"+action, body, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", deviceGroupAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var actionResponse MemberResponse
+
+	resp, err := d.client.do(req, &actionResponse)
+	if err != nil && err != io.EOF { // EOF is valid
+		return actionResponse, resp, err
+	}
+	return actionResponse, resp, nil
+}
+
+// AddDevices adds device identities to the given device group
+func (d *DeviceGroupsService) AddDevices(group DeviceGroup, devices ...string) (MemberResponse, *Response, error) {
+	return d.AddDevicesWithOptions(group, devices)
+}
+
+// AddDevicesWithOptions is AddDevices with request options
+func (d *DeviceGroupsService) AddDevicesWithOptions(group DeviceGroup, devices []string, options ...OptionFunc) (MemberResponse, *Response, error) {
+	return perSlice(devices, groupMemberBatchSize, func(chunk []string) (MemberResponse, *Response, error) {
+		return d.groupAction(group, "$add-members", memberRequestBody("DEVICE", chunk...), options)
+	})
+}
+
+// RemoveDevices removes device identities from the given device group
+func (d *DeviceGroupsService) RemoveDevices(group DeviceGroup, devices ...string) (MemberResponse, *Response, error) {
+	return d.RemoveDevicesWithOptions(group, devices)
+}
+
+// RemoveDevicesWithOptions is RemoveDevices with request options
+func (d *DeviceGroupsService) RemoveDevicesWithOptions(group DeviceGroup, devices []string, options ...OptionFunc) (MemberResponse, *Response, error) {
+	return perSlice(devices, groupMemberBatchSize, func(chunk []string) (MemberResponse, *Response, error) {
+		return d.groupAction(group, "$remove-members", memberRequestBody("DEVICE", chunk...), options)
+	})
+}
+
+// GetRoles returns the roles assigned to the device group
+func (d *DeviceGroupsService) GetRoles(group DeviceGroup, options ...OptionFunc) (*[]Role, *Response, error) {
+	opt := &GetRolesOptions{
+		GroupID: &group.ID,
+	}
+	req, err := d.client.newRequest(IDM, "GET", "authorize/identity/Role", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", roleAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var responseStruct struct {
+		Total int    `json:"total"`
+		Entry []Role `json:"entry"`
+	}
+
+	resp, err := d.client.do(req, &responseStruct)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &responseStruct.Entry, resp, err
+}
+
+func (d *DeviceGroupsService) roleAction(group DeviceGroup, role Role, action string, options []OptionFunc) (bool, *Response, error) {
+	_, resp, err := d.groupAction(group, action, groupRequest{Roles: []string{role.ID}}, options)
+	if err != nil {
+		return false, resp, err
+	}
+	if resp == nil || resp.StatusCode != http.StatusOK {
+		return false, resp, nil
+	}
+	return true, resp, nil
+}
+
+// AssignRole adds a role to a device group
+func (d *DeviceGroupsService) AssignRole(group DeviceGroup, role Role, options ...OptionFunc) (bool, *Response, error) {
+	return d.roleAction(group, role, "$assign-role", options)
+}
+
+// RemoveRole removes a role from a device group
+func (d *DeviceGroupsService) RemoveRole(group DeviceGroup, role Role, options ...OptionFunc) (bool, *Response, error) {
+	return d.roleAction(group, role, "$remove-role", options)
+}
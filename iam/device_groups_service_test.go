@@ -0,0 +1,157 @@
+package iam
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testDeviceGroupJSON(id string) string {
+	return `{
+  "id": "` + id + `",
+  "name": "Gateways",
+  "description": "Edge gateways",
+  "managingOrganization": "c57b2625-eda3-4b27-a8e6-86f0a0e76afc",
+  "meta": {"version": "W/\"1\""}
+}`
+}
+
+func TestDeviceGroups(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	groupID := "8b2c3f1e-4d5a-4e6b-9c7d-0e1f2a3b4c5d"
+	orgID := "c57b2625-eda3-4b27-a8e6-86f0a0e76afc"
+	roleID := "5a7c3ae4-c0b6-4c3a-9a5e-2f1d8c7b6a59"
+	var members []string
+
+	muxIDM.HandleFunc("/authorize/identity/DeviceGroup", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "POST":
+			var group DeviceGroup
+			if err := json.NewDecoder(r.Body).Decode(&group); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, testDeviceGroupJSON(groupID))
+		case "GET":
+			assert.Equal(t, orgID, r.URL.Query().Get("organizationId"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{"total":1,"entry":[`+testDeviceGroupJSON(groupID)+`]}`)
+		}
+	})
+	muxIDM.HandleFunc("/authorize/identity/DeviceGroup/"+groupID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, testDeviceGroupJSON(groupID))
+		case "PUT":
+			assert.Equal(t, `W/"1"`, r.Header.Get("If-Match"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, testDeviceGroupJSON(groupID))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	memberHandler := func(add bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var body memberRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.MemberType != "DEVICE" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if add {
+				members = append(members, body.Value...)
+			} else {
+				members = members[:len(members)-len(body.Value)]
+			}
+			w.WriteHeader(http.StatusOK)
+		}
+	}
+	muxIDM.HandleFunc("/authorize/identity/DeviceGroup/"+groupID+"/$add-members", memberHandler(true))
+	muxIDM.HandleFunc("/authorize/identity/DeviceGroup/"+groupID+"/$remove-members", memberHandler(false))
+	roleHandler := func(w http.ResponseWriter, r *http.Request) {
+		var body groupRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Roles) != 1 || body.Roles[0] != roleID {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{}`)
+	}
+	muxIDM.HandleFunc("/authorize/identity/DeviceGroup/"+groupID+"/$assign-role", roleHandler)
+	muxIDM.HandleFunc("/authorize/identity/DeviceGroup/"+groupID+"/$remove-role", roleHandler)
+	muxIDM.HandleFunc("/authorize/identity/Role", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, groupID, r.URL.Query().Get("groupId"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"total":1,"entry":[{"id":"`+roleID+`","name":"DEVICEROLE"}]}`)
+	})
+
+	_, _, err := client.DeviceGroups.CreateDeviceGroup(DeviceGroup{Name: "Gateways"})
+	assert.NotNil(t, err)
+
+	group, resp, err := client.DeviceGroups.CreateDeviceGroup(DeviceGroup{
+		Name:                 "Gateways",
+		Description:          "Edge gateways",
+		ManagingOrganization: orgID,
+	})
+	if !assert.Nil(t, err) || !assert.NotNil(t, group) {
+		return
+	}
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, groupID, group.ID)
+
+	found, _, err := client.DeviceGroups.GetDeviceGroupByID(groupID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "Gateways", found.Name)
+
+	groups, _, err := client.DeviceGroups.GetDeviceGroups(&GetDeviceGroupsOptions{OrganizationID: String(orgID)})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, *groups, 1)
+
+	_, _, err = client.DeviceGroups.UpdateDeviceGroup(DeviceGroup{ID: groupID, Name: "Gateways", ManagingOrganization: orgID})
+	assert.Equal(t, ErrMissingEtagInformation, err)
+	updated, _, err := client.DeviceGroups.UpdateDeviceGroup(*found)
+	assert.Nil(t, err)
+	assert.NotNil(t, updated)
+
+	devices := make([]string, groupMemberBatchSize+2)
+	for i := range devices {
+		devices[i] = "device" + string(rune('a'+i))
+	}
+	_, _, err = client.DeviceGroups.AddDevices(*group, devices...)
+	assert.Nil(t, err)
+	assert.Len(t, members, len(devices))
+	_, _, err = client.DeviceGroups.RemoveDevices(*group, devices[:2]...)
+	assert.Nil(t, err)
+	assert.Len(t, members, len(devices)-2)
+
+	role := Role{ID: roleID}
+	ok, _, err := client.DeviceGroups.AssignRole(*group, role)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	roles, _, err := client.DeviceGroups.GetRoles(*group)
+	if assert.Nil(t, err) && assert.Len(t, *roles, 1) {
+		assert.Equal(t, roleID, (*roles)[0].ID)
+	}
+	ok, _, err = client.DeviceGroups.RemoveRole(*group, role)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, resp, err = client.DeviceGroups.DeleteDeviceGroup(*group)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
@@ -53,7 +53,7 @@ type GetDevicesOptions struct {
 	Count             *int    `url:"_count,omitempty"`
 	Page              *int    `url:"_page,omitempty"`
 	DeviceExtIDValue  *string `url:"deviceExtId.value,omitempty"`
-	DeviceExtIDType   *string `url:"deviceExtId.value,omitempty"`
+	DeviceExtIDType   *string `url:"deviceExtId.type.code,omitempty"`
 	DeviceExtIDSystem *string `url:"deviceExtId.system,omitempty"`
 	LoginID           *string `url:"loginId,omitempty" validate:""`
 	ForTest           *bool   `url:"forTest,omitempty"`
@@ -79,7 +79,7 @@ func (p *DevicesService) GetDevices(opt *GetDevicesOptions, options ...OptionFun
 	if err != nil {
 		return nil, nil, err
 	}
-	req.Header.Set("api-version", servicesAPIVersion)
+	req.Header.Set("api-version", deviceAPIVersion)
 	req.Header.Set("Content-Type", "application/json")
 
 	var bundleResponse struct {
@@ -95,23 +95,39 @@ func (p *DevicesService) GetDevices(opt *GetDevicesOptions, options ...OptionFun
 }
 
 // GetDeviceByID retrieves a device by ID
-func (p *DevicesService) GetDeviceByID(deviceID string) (*Device, *Response, error) {
+func (p *DevicesService) GetDeviceByID(deviceID string, options ...OptionFunc) (*Device, *Response, error) {
 	devices, resp, err := p.GetDevices(&GetDevicesOptions{
 		ID: &deviceID,
-	})
+	}, options...)
 	if devices == nil || len(*devices) == 0 {
 		return nil, resp, ErrNotFound
 	}
 	return &(*devices)[0], resp, err
 }
 
+// GetDevicesByExtID looks up Devices by their external identifier.
+// Empty fields of the identifier are not used as search criteria
+func (p *DevicesService) GetDevicesByExtID(ext DeviceIdentifier, options ...OptionFunc) (*[]Device, *Response, error) {
+	opt := &GetDevicesOptions{}
+	if ext.Value != "" {
+		opt.DeviceExtIDValue = &ext.Value
+	}
+	if ext.System != "" {
+		opt.DeviceExtIDSystem = &ext.System
+	}
+	if ext.Type.Code != "" {
+		opt.DeviceExtIDType = &ext.Type.Code
+	}
+	return p.GetDevices(opt, options...)
+}
+
 // CreateDevice creates a Device
 // A user with DEVICE.WRITE permission can create devices under the organization.
-func (p *DevicesService) CreateDevice(device Device) (*Device, *Response, error) {
+func (p *DevicesService) CreateDevice(device Device, options ...OptionFunc) (*Device, *Response, error) {
 	if err := p.validate.Struct(device); err != nil {
 		return nil, nil, err
 	}
-	req, _ := p.client.newRequest(IDM, "POST", "authorize/identity/Device", device, nil)
+	req, _ := p.client.newRequest(IDM, "POST", "authorize/identity/Device", device, options)
 	req.Header.Set("api-version", deviceAPIVersion)
 
 	var createdDevice Device
@@ -129,15 +145,15 @@ func (p *DevicesService) CreateDevice(device Device) (*Device, *Response, error)
 	if count == 0 {
 		return nil, resp, ErrCouldNoReadResourceAfterCreate
 	}
-	return p.GetDeviceByID(id)
+	return p.GetDeviceByID(id, options...)
 }
 
 // UpdateDevice updates Device properties.
 // Any user with DEVICE.WRITE permission within the organization can update device properties.
 // The entire resource data must be passed as request body to update a device.
 // If read-only attributes (such as id, loginId, password, meta, organizationId) are passed, that will be ignored.
-func (p *DevicesService) UpdateDevice(device Device) (*Device, *Response, error) {
-	req, err := p.client.newRequest(IDM, "PUT", "authorize/identity/Device/"+device.ID, &device, nil)
+func (p *DevicesService) UpdateDevice(device Device, options ...OptionFunc) (*Device, *Response, error) {
+	req, err := p.client.newRequest(IDM, "PUT", "authorize/identity/Device/"+device.ID, &device, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -157,8 +173,8 @@ func (p *DevicesService) UpdateDevice(device Device) (*Device, *Response, error)
 // The is usually done by a organization administrator.
 // Any user with DEVICE.WRITE or DEVICE.DELETE permission within
 // the organization can delete a device from an organization.
-func (p *DevicesService) DeleteDevice(device Device) (bool, *Response, error) {
-	req, err := p.client.newRequest(IDM, "DELETE", "authorize/identity/Device/"+device.ID, nil, nil)
+func (p *DevicesService) DeleteDevice(device Device, options ...OptionFunc) (bool, *Response, error) {
+	req, err := p.client.newRequest(IDM, "DELETE", "authorize/identity/Device/"+device.ID, nil, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -175,7 +191,7 @@ func (p *DevicesService) DeleteDevice(device Device) (bool, *Response, error) {
 
 // ChangePassword changes the password. The current pasword must be provided as well.
 // No password history will be maintained for device.
-func (p *DevicesService) ChangePassword(deviceID, oldPassword, newPassword string) (bool, *Response, error) {
+func (p *DevicesService) ChangePassword(deviceID, oldPassword, newPassword string, options ...OptionFunc) (bool, *Response, error) {
 	body := struct {
 		OldPassword string `json:"oldPassword" validate:"required,min=8"`
 		NewPassword string `json:"newPassword" validate:"required,min=8"`
@@ -186,11 +202,11 @@ func (p *DevicesService) ChangePassword(deviceID, oldPassword, newPassword strin
 	if err := p.validate.Struct(body); err != nil {
 		return false, nil, err
 	}
-	return p.deviceActionV(deviceID, body, "$change-password", deviceAPIVersion)
+	return p.deviceActionV(deviceID, body, "$change-password", deviceAPIVersion, options...)
 }
 
-func (p *DevicesService) deviceActionV(deviceID string, body interface{}, action, apiVersion string) (bool, *Response, error) {
-	req, err := p.client.newRequest(IDM, "POST", "authorize/identity/Device/"+deviceID+"/"+action, body, nil)
+func (p *DevicesService) deviceActionV(deviceID string, body interface{}, action, apiVersion string, options ...OptionFunc) (bool, *Response, error) {
+	req, err := p.client.newRequest(IDM, "POST", "authorize/identity/Device/"+deviceID+"/"+action, body, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -21,6 +21,11 @@ func TestDevicesCRUD(t *testing.T) {
 			w.Header().Set("Location", "/authorize/identity/Device/"+deviceID)
 			w.WriteHeader(http.StatusCreated)
 		case "GET":
+			if v := r.URL.Query().Get("deviceExtId.value"); v != "" && v != "0001" {
+				w.WriteHeader(http.StatusOK)
+				_, _ = io.WriteString(w, `{"total": 0, "entry": []}`)
+				return
+			}
 			w.WriteHeader(http.StatusOK)
 			_, _ = io.WriteString(w, `{
   "total": 1,
@@ -150,6 +155,18 @@ func TestDevicesCRUD(t *testing.T) {
 		return
 	}
 	assert.Equal(t, deviceID, device.ID)
+
+	devices, _, err := client.Devices.GetDevicesByExtID(d.DeviceExtID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, *devices, 1)
+	devices, _, err = client.Devices.GetDevicesByExtID(DeviceIdentifier{Value: "0002"})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, *devices, 0)
+
 	ok, resp, err := client.Devices.DeleteDevice(*device)
 	if !assert.Nil(t, err) {
 		return
@@ -0,0 +1,177 @@
+package iam
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	emailDomainAPIVersion = "1"
+)
+
+// Email domain verification statuses
+const (
+	EmailDomainStatusPending  = "PENDING"
+	EmailDomainStatusVerified = "VERIFIED"
+	EmailDomainStatusFailed   = "FAILED"
+)
+
+// EmailDomainsService provides operations on the email domains of an organization.
+// A domain must be verified through a DNS challenge before IAM accepts it
+type EmailDomainsService struct {
+	client *Client
+
+	validate *validator.Validate
+}
+
+// EmailDomain is an email domain registered for an organization
+type EmailDomain struct {
+	ID             string     `json:"id,omitempty"`
+	OrganizationID string     `json:"organizationId" validate:"required"`
+	Domain         string     `json:"domain" validate:"required,fqdn"`
+	Status         string     `json:"status,omitempty"`
+	VerifiedAt     *time.Time `json:"verifiedAt,omitempty"`
+	Meta           *Meta      `json:"meta,omitempty"`
+}
+
+// Verified returns true if the domain ownership has been verified
+func (e EmailDomain) Verified() bool {
+	return e.Status == EmailDomainStatusVerified
+}
+
+// DNSChallenge is the DNS record which must be published to prove ownership of a domain
+type DNSChallenge struct {
+	RecordType string    `json:"recordType"`
+	RecordName string    `json:"recordName"`
+	Value      string    `json:"value"`
+	ExpiresAt  time.Time `json:"expiresAt,omitempty"`
+}
+
+// GetEmailDomainsOptions describes the criteria for looking up email domains
+type GetEmailDomainsOptions struct {
+	ID             *string `url:"_id,omitempty"`
+	OrganizationID *string `url:"organizationId,omitempty"`
+	Domain         *string `url:"domain,omitempty"`
+	Status         *string `url:"status,omitempty"`
+}
+
+// AddDomain registers an email domain for an organization. The domain starts
+// out in the PENDING status, see GetChallenge and VerifyDomain
+func (e *EmailDomainsService) AddDomain(domain EmailDomain, options ...OptionFunc) (*EmailDomain, *Response, error) {
+	if err := e.validate.Struct(domain); err != nil {
+		return nil, nil, err
+	}
+	req, err := e.client.newRequest(IDM, "POST", "authorize/identity/EmailDomain", &domain, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", emailDomainAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var createdDomain EmailDomain
+
+	resp, err := e.client.do(req, &createdDomain)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &createdDomain, resp, nil
+}
+
+// GetDomainByID retrieves an email domain by ID
+func (e *EmailDomainsService) GetDomainByID(id string, options ...OptionFunc) (*EmailDomain, *Response, error) {
+	req, err := e.client.newRequest(IDM, "GET", "authorize/identity/EmailDomain/"+id, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", emailDomainAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var domain EmailDomain
+
+	resp, err := e.client.do(req, &domain)
+	if err != nil {
+		return nil, resp, err
+	}
+	if domain.ID != id {
+		return nil, resp, ErrNotFound
+	}
+	return &domain, resp, nil
+}
+
+// GetDomains looks up email domains based on GetEmailDomainsOptions
+func (e *EmailDomainsService) GetDomains(opt *GetEmailDomainsOptions, options ...OptionFunc) (*[]EmailDomain, *Response, error) {
+	req, err := e.client.newRequest(IDM, "GET", "authorize/identity/EmailDomain", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", emailDomainAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var bundleResponse struct {
+		Total int           `json:"total"`
+		Entry []EmailDomain `json:"entry"`
+	}
+
+	resp, err := e.client.do(req, &bundleResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &bundleResponse.Entry, resp, nil
+}
+
+// GetChallenge retrieves the DNS record which must be published for the domain before calling VerifyDomain
+func (e *EmailDomainsService) GetChallenge(domain EmailDomain, options ...OptionFunc) (*DNSChallenge, *Response, error) {
+	req, err := e.client.newRequest(IDM, "GET", "authorize/identity/EmailDomain/"+domain.ID+"/$challenge", nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", emailDomainAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var challenge DNSChallenge
+
+	resp, err := e.client.do(req, &challenge)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &challenge, resp, nil
+}
+
+// VerifyDomain asks IAM to check the DNS challenge of the domain and returns the
+// updated domain. Use Verified to check the outcome
+func (e *EmailDomainsService) VerifyDomain(domain EmailDomain, options ...OptionFunc) (*EmailDomain, *Response, error) {
+	req, err := e.client.newRequest(IDM, "POST", "authorize/identity/EmailDomain/"+domain.ID+"/$verify", nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", emailDomainAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var verifiedDomain EmailDomain
+
+	resp, err := e.client.do(req, &verifiedDomain)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &verifiedDomain, resp, nil
+}
+
+// DeleteDomain removes an email domain from its organization
+func (e *EmailDomainsService) DeleteDomain(domain EmailDomain, options ...OptionFunc) (bool, *Response, error) {
+	req, err := e.client.newRequest(IDM, "DELETE", "authorize/identity/EmailDomain/"+domain.ID, nil, options)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("api-version", emailDomainAPIVersion)
+
+	var deleteResponse bytes.Buffer
+
+	resp, err := e.client.do(req, &deleteResponse)
+	if resp == nil || resp.StatusCode != http.StatusNoContent {
+		return false, resp, err
+	}
+	return true, resp, nil
+}
@@ -0,0 +1,114 @@
+package iam
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testEmailDomainJSON(id, status string) string {
+	return `{
+  "id": "` + id + `",
+  "organizationId": "c57b2625-eda3-4b27-a8e6-86f0a0e76afc",
+  "domain": "example.com",
+  "status": "` + status + `"
+}`
+}
+
+func TestEmailDomains(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	domainID := "0a4e7a1b-5b5e-4b8e-9b0a-6e2d4e9b1c3f"
+	orgID := "c57b2625-eda3-4b27-a8e6-86f0a0e76afc"
+
+	muxIDM.HandleFunc("/authorize/identity/EmailDomain", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "POST":
+			var domain EmailDomain
+			if err := json.NewDecoder(r.Body).Decode(&domain); err != nil || domain.Domain != "example.com" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, testEmailDomainJSON(domainID, EmailDomainStatusPending))
+		case "GET":
+			assert.Equal(t, orgID, r.URL.Query().Get("organizationId"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{"total":1,"entry":[`+testEmailDomainJSON(domainID, EmailDomainStatusPending)+`]}`)
+		}
+	})
+	muxIDM.HandleFunc("/authorize/identity/EmailDomain/"+domainID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, testEmailDomainJSON(domainID, EmailDomainStatusPending))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	muxIDM.HandleFunc("/authorize/identity/EmailDomain/"+domainID+"/$challenge", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{
+  "recordType": "TXT",
+  "recordName": "_hsdp-verification.example.com",
+  "value": "hsdp-verification=5f3a9c",
+  "expiresAt": "2022-08-01T00:00:00Z"
+}`)
+	})
+	muxIDM.HandleFunc("/authorize/identity/EmailDomain/"+domainID+"/$verify", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, testEmailDomainJSON(domainID, EmailDomainStatusVerified))
+	})
+
+	_, _, err := client.EmailDomains.AddDomain(EmailDomain{OrganizationID: orgID, Domain: "not a domain"})
+	assert.NotNil(t, err)
+
+	domain, resp, err := client.EmailDomains.AddDomain(EmailDomain{OrganizationID: orgID, Domain: "example.com"})
+	if !assert.Nil(t, err) || !assert.NotNil(t, domain) {
+		return
+	}
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, domainID, domain.ID)
+	assert.False(t, domain.Verified())
+
+	found, _, err := client.EmailDomains.GetDomainByID(domainID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "example.com", found.Domain)
+
+	domains, _, err := client.EmailDomains.GetDomains(&GetEmailDomainsOptions{OrganizationID: String(orgID)})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, *domains, 1)
+
+	challenge, _, err := client.EmailDomains.GetChallenge(*domain)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "TXT", challenge.RecordType)
+	assert.Equal(t, "_hsdp-verification.example.com", challenge.RecordName)
+	assert.Equal(t, "hsdp-verification=5f3a9c", challenge.Value)
+
+	verified, _, err := client.EmailDomains.VerifyDomain(*domain)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.True(t, verified.Verified())
+
+	ok, resp, err := client.EmailDomains.DeleteDomain(*domain)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
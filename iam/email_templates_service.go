@@ -17,13 +17,27 @@ type EmailTemplatesService struct {
 	validate *validator.Validate
 }
 
+// Email template types
+const (
+	EmailTemplateAccountAlreadyVerified = "ACCOUNT_ALREADY_VERIFIED"
+	EmailTemplateAccountUnlocked        = "ACCOUNT_UNLOCKED"
+	EmailTemplateAccountVerification    = "ACCOUNT_VERIFICATION"
+	EmailTemplateMFADisabled            = "MFA_DISABLED"
+	EmailTemplateMFAEnabled             = "MFA_ENABLED"
+	EmailTemplateMFAOTP                 = "MFA_OTP"
+	EmailTemplatePasswordChanged        = "PASSWORD_CHANGED"
+	EmailTemplatePasswordExpiry         = "PASSWORD_EXPIRY"
+	EmailTemplatePasswordFailedAttempts = "PASSWORD_FAILED_ATTEMPTS"
+	EmailTemplatePasswordRecovery       = "PASSWORD_RECOVERY"
+)
+
 // EmailTemplate describes an email template
 type EmailTemplate struct {
 	// ID is the UUID generated for a stored email template
 	ID string `json:"id,omitempty"`
 
 	// Type is the type of the email template
-	Type string `json:"type" validate:"required" enum:"ACCOUNT_ALREADY_VERIFIED|ACCOUNT_UNLOCKED|ACCOUNT_VERIFICATION|MFA_DISABLED|MFA_ENABLED|PASSWORD_CHANGED|PASSWORD_EXPIRY|PASSWORD_FAILED_ATTEMPTS|PASSWORD_RECOVERY"`
+	Type string `json:"type" validate:"required,oneof=ACCOUNT_ALREADY_VERIFIED ACCOUNT_UNLOCKED ACCOUNT_VERIFICATION MFA_DISABLED MFA_ENABLED MFA_OTP PASSWORD_CHANGED PASSWORD_EXPIRY PASSWORD_FAILED_ATTEMPTS PASSWORD_RECOVERY" enum:"ACCOUNT_ALREADY_VERIFIED|ACCOUNT_UNLOCKED|ACCOUNT_VERIFICATION|MFA_DISABLED|MFA_ENABLED|MFA_OTP|PASSWORD_CHANGED|PASSWORD_EXPIRY|PASSWORD_FAILED_ATTEMPTS|PASSWORD_RECOVERY"`
 
 	// ManagingOrganization is the Unique UUID of the organization under which the email template needs to be created.
 	ManagingOrganization string `json:"managingOrganization" validate:"required"`
@@ -32,7 +46,7 @@ type EmailTemplate struct {
 	From string `json:"from,omitempty"`
 
 	// Format is the template format. Must be HTML at this time
-	Format string `json:"format" validate:"required" enum:"HTML"`
+	Format string `json:"format" validate:"required,oneof=HTML" enum:"HTML"`
 
 	// Locale is the locale for the email template. The locale is case insensitive
 	Locale string `json:"locale,omitempty"`
@@ -52,11 +66,11 @@ type EmailTemplate struct {
 
 // CreateTemplate creates an EmailTemplate
 // A user with EMAILTEMPLATE.WRITE permission can create templates under the organization.
-func (e *EmailTemplatesService) CreateTemplate(template EmailTemplate) (*EmailTemplate, *Response, error) {
+func (e *EmailTemplatesService) CreateTemplate(template EmailTemplate, options ...OptionFunc) (*EmailTemplate, *Response, error) {
 	if err := e.client.validate.Struct(template); err != nil {
 		return nil, nil, err
 	}
-	req, err := e.client.newRequest(IDM, "POST", "authorize/identity/EmailTemplate", &template, nil)
+	req, err := e.client.newRequest(IDM, "POST", "authorize/identity/EmailTemplate", &template, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -71,9 +85,34 @@ func (e *EmailTemplatesService) CreateTemplate(template EmailTemplate) (*EmailTe
 	return &createdTemplate, resp, err
 }
 
+// UpdateTemplate updates an EmailTemplate. The Meta.Version of the template is used for concurrency control
+// A user with EMAILTEMPLATE.WRITE permission can update templates under the organization.
+func (e *EmailTemplatesService) UpdateTemplate(template EmailTemplate, options ...OptionFunc) (*EmailTemplate, *Response, error) {
+	if template.Meta == nil {
+		return nil, nil, ErrMissingEtagInformation
+	}
+	if err := e.client.validate.Struct(template); err != nil {
+		return nil, nil, err
+	}
+	req, err := e.client.newRequest(IDM, "PUT", "authorize/identity/EmailTemplate/"+template.ID, &template, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", emailTemplateAPIVersion)
+	req.Header.Set("If-Match", template.Meta.Version)
+
+	var updatedTemplate EmailTemplate
+
+	resp, err := e.client.do(req, &updatedTemplate)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &updatedTemplate, resp, err
+}
+
 // DeleteTemplate deletes the given EmailTemplate
-func (e *EmailTemplatesService) DeleteTemplate(template EmailTemplate) (bool, *Response, error) {
-	req, err := e.client.newRequest(IDM, "DELETE", "authorize/identity/EmailTemplate/"+template.ID, nil, nil)
+func (e *EmailTemplatesService) DeleteTemplate(template EmailTemplate, options ...OptionFunc) (bool, *Response, error) {
+	req, err := e.client.newRequest(IDM, "DELETE", "authorize/identity/EmailTemplate/"+template.ID, nil, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -88,6 +127,8 @@ func (e *EmailTemplatesService) DeleteTemplate(template EmailTemplate) (bool, *R
 	return true, resp, nil
 }
 
+// GetEmailTemplatesOptions describes search criteria for looking up email templates.
+// Use Locale to select a specific locale variant of a template type
 type GetEmailTemplatesOptions struct {
 	Type           *string `url:"type,omitempty"`
 	OrganizationID *string `url:"organizationId,omitempty"`
@@ -119,7 +160,7 @@ func (e *EmailTemplatesService) GetTemplates(opt *GetEmailTemplatesOptions, opti
 		return nil, resp, ErrNotFound
 	}
 	for _, t := range bundleResponse.Entry {
-		template, _, err := e.GetTemplateByID(t.ID)
+		template, _, err := e.GetTemplateByID(t.ID, options...)
 		if err != nil {
 			continue
 		}
@@ -128,8 +169,9 @@ func (e *EmailTemplatesService) GetTemplates(opt *GetEmailTemplatesOptions, opti
 	return &templates, resp, nil
 }
 
-func (e *EmailTemplatesService) GetTemplateByID(ID string) (*EmailTemplate, *Response, error) {
-	req, err := e.client.newRequest(IDM, "GET", "authorize/identity/EmailTemplate/"+ID, nil, nil)
+// GetTemplateByID retrieves an EmailTemplate by ID
+func (e *EmailTemplatesService) GetTemplateByID(ID string, options ...OptionFunc) (*EmailTemplate, *Response, error) {
+	req, err := e.client.newRequest(IDM, "GET", "authorize/identity/EmailTemplate/"+ID, nil, options)
 	if err != nil {
 		return nil, nil, err
 	}
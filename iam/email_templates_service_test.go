@@ -70,7 +70,11 @@ func TestEmailTemplateCreateDelete(t *testing.T) {
 		switch r.Method {
 		case "DELETE":
 			w.WriteHeader(http.StatusNoContent)
-		case "GET":
+		case "GET", "PUT":
+			if r.Method == "PUT" && r.Header.Get("If-Match") == "" {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
 			w.WriteHeader(http.StatusOK)
 			_, _ = io.WriteString(w, `{
   "id": "`+id+`",
@@ -127,6 +131,26 @@ func TestEmailTemplateCreateDelete(t *testing.T) {
 	}
 	assert.Equal(t, template.ID, foundTemplate.ID)
 
+	foundTemplate.Message = e.Message
+	foundTemplate.Subject = "Your password was changed"
+	updatedTemplate, resp, err := client.EmailTemplates.UpdateTemplate(*foundTemplate)
+	if !assert.Nil(t, err) {
+		return
+	}
+	if !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, id, updatedTemplate.ID)
+
+	foundTemplate.Meta = nil
+	_, _, err = client.EmailTemplates.UpdateTemplate(*foundTemplate)
+	assert.Equal(t, ErrMissingEtagInformation, err)
+
+	e.Type = "BOGUS_TYPE"
+	_, _, err = client.EmailTemplates.CreateTemplate(e)
+	assert.NotNil(t, err)
+
 	templates, resp, err := client.EmailTemplates.GetTemplates(&GetEmailTemplatesOptions{
 		OrganizationID: &orgID,
 	})
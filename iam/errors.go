@@ -25,6 +25,28 @@ var (
 	ErrNotAuthorized                  = errors.New("not authorized")
 	ErrNoValidSignerAvailable         = errors.New("no valid HSDP signer available")
 	ErrMissingOAuth2Credentials       = errors.New("missing OAuth2 credentials")
+	ErrAutoRefreshAlreadyRunning      = errors.New("auto refresh already running")
+	ErrMissingPassword                = errors.New("missing password")
+	ErrMissingCodeVerifier            = errors.New("missing PKCE code verifier")
+	ErrOrganizationCycle              = errors.New("cycle detected in organization hierarchy")
+	ErrMissingSubjectToken            = errors.New("missing subject token")
+	ErrNoHealthyEndpoint              = errors.New("no healthy endpoint available")
+	ErrConcurrentModification         = errors.New("resource was modified concurrently")
+	ErrMissingLoginID                 = errors.New("missing login ID")
+	ErrMissingKBAAnswers              = errors.New("missing KBA answers")
+	ErrKBAValidationFailed            = errors.New("KBA validation failed")
+	ErrMissingToken                   = errors.New("missing token")
+	ErrUnknownSigningKey              = errors.New("unknown token signing key")
+	ErrTokenExpired                   = errors.New("token expired")
+	ErrInvalidAudience                = errors.New("invalid token audience")
+	ErrUnsupportedSignatureVersion    = errors.New("unsupported signature version")
+	ErrMissingMetadata                = errors.New("missing metadata")
+	ErrUserAlreadyActive              = errors.New("user is already active")
+	ErrUserAlreadyInactive            = errors.New("user is already inactive")
+	ErrMFARequired                    = errors.New("login requires a second factor")
+	ErrMissingOTP                     = errors.New("missing one-time password")
+	ErrEmptyPatch                     = errors.New("patch contains no changes")
+	ErrMissingApplicationID           = errors.New("missing application ID")
 )
 
 type UserError struct {
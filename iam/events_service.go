@@ -0,0 +1,127 @@
+package iam
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	eventAPIVersion = "1"
+)
+
+// Event types
+const (
+	EventTypeTokenIssued  = "TOKEN_ISSUED"
+	EventTypeTokenRevoked = "TOKEN_REVOKED"
+	EventTypeLoginSuccess = "LOGIN_SUCCESS"
+	EventTypeLoginFailed  = "LOGIN_FAILED"
+	EventTypeAdminChange  = "ADMIN_CHANGE"
+)
+
+// EventsService provides access to the IAM audit events
+type EventsService struct {
+	client *Client
+}
+
+// Event describes a single IAM audit event
+type Event struct {
+	ID             string            `json:"id"`
+	Type           string            `json:"type"`
+	Action         string            `json:"action,omitempty"`
+	Outcome        string            `json:"outcome,omitempty"`
+	Timestamp      time.Time         `json:"timestamp"`
+	UserID         string            `json:"userId,omitempty"`
+	LoginID        string            `json:"loginId,omitempty"`
+	ClientID       string            `json:"clientId,omitempty"`
+	OrganizationID string            `json:"organizationId,omitempty"`
+	IPAddress      string            `json:"ipAddress,omitempty"`
+	Resource       string            `json:"resource,omitempty"`
+	Details        map[string]string `json:"details,omitempty"`
+}
+
+// GetEventsOptions describes the criteria for looking up audit events
+type GetEventsOptions struct {
+	From           *time.Time `url:"from,omitempty"`
+	To             *time.Time `url:"to,omitempty"`
+	Type           *string    `url:"type,omitempty"`
+	UserID         *string    `url:"userId,omitempty"`
+	ClientID       *string    `url:"clientId,omitempty"`
+	OrganizationID *string    `url:"organizationId,omitempty"`
+	PageSize       *int       `url:"pageSize,omitempty"`
+	PageNumber     *int       `url:"pageNumber,omitempty"`
+}
+
+// EventList is a page of audit events
+type EventList struct {
+	Total       int
+	PageNumber  int
+	HasNextPage bool
+	Events      []Event
+}
+
+// GetEvents looks up audit events based on GetEventsOptions
+func (e *EventsService) GetEvents(opt *GetEventsOptions, options ...OptionFunc) (*EventList, *Response, error) {
+	if opt != nil && opt.From != nil && opt.To != nil && opt.To.Before(*opt.From) {
+		return nil, nil, ErrMalformedInputValue
+	}
+	req, err := e.client.newRequest(IDM, http.MethodGet, "authorize/identity/Event", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", eventAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var bundleResponse struct {
+		Total int     `json:"total"`
+		Entry []Event `json:"entry"`
+		Link  []struct {
+			Relation string `json:"relation"`
+			URL      string `json:"url"`
+		} `json:"link"`
+	}
+
+	resp, err := e.client.do(req, &bundleResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+	list := &EventList{
+		Total:  bundleResponse.Total,
+		Events: bundleResponse.Entry,
+	}
+	list.PageNumber = 1
+	if opt != nil && opt.PageNumber != nil {
+		list.PageNumber = *opt.PageNumber
+	}
+	for _, l := range bundleResponse.Link {
+		if l.Relation == "next" {
+			list.HasNextPage = true
+		}
+	}
+	return list, resp, nil
+}
+
+// GetAllEvents retrieves all pages of audit events matching GetEventsOptions
+func (e *EventsService) GetAllEvents(opt *GetEventsOptions, options ...OptionFunc) ([]Event, *Response, error) {
+	var events []Event
+	search := GetEventsOptions{}
+	if opt != nil {
+		search = *opt
+	}
+	pageNumber := 1
+	if search.PageNumber != nil {
+		pageNumber = *search.PageNumber
+	}
+	for {
+		current := pageNumber
+		search.PageNumber = &current
+		list, resp, err := e.GetEvents(&search, options...)
+		if err != nil {
+			return events, resp, err
+		}
+		events = append(events, list.Events...)
+		if !list.HasNextPage || len(list.Events) == 0 {
+			return events, resp, nil
+		}
+		pageNumber++
+	}
+}
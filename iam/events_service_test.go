@@ -0,0 +1,70 @@
+package iam
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEvents(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	userID := "7dbfe5fc-1320-4bc6-92a7-2be5d7f07cac"
+	from := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	muxIDM.HandleFunc("/authorize/identity/Event", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, http.MethodGet, r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		qp := r.URL.Query()
+		assert.Equal(t, userID, qp.Get("userId"))
+		assert.Equal(t, EventTypeLoginFailed, qp.Get("type"))
+		assert.Equal(t, from.Format(time.RFC3339), qp.Get("from"))
+		assert.Equal(t, to.Format(time.RFC3339), qp.Get("to"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if qp.Get("pageNumber") == "2" {
+			_, _ = io.WriteString(w, `{"total":2,"entry":[
+				{"id":"2","type":"LOGIN_FAILED","timestamp":"2022-06-01T11:00:00Z","userId":"`+userID+`","ipAddress":"10.0.0.2"}
+			]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"total":2,"entry":[
+			{"id":"1","type":"LOGIN_FAILED","timestamp":"2022-06-01T10:00:00Z","userId":"`+userID+`","ipAddress":"10.0.0.1","details":{"reason":"invalid_password"}}
+		],"link":[{"relation":"next","url":"/authorize/identity/Event?pageNumber=2"}]}`)
+	})
+
+	opts := &GetEventsOptions{
+		From:   &from,
+		To:     &to,
+		Type:   String(EventTypeLoginFailed),
+		UserID: &userID,
+	}
+	list, resp, err := client.Events.GetEvents(opts)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, list.HasNextPage)
+	if !assert.Len(t, list.Events, 1) {
+		return
+	}
+	assert.Equal(t, "invalid_password", list.Events[0].Details["reason"])
+	assert.Equal(t, 10, list.Events[0].Timestamp.Hour())
+
+	events, _, err := client.Events.GetAllEvents(opts)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, events, 2)
+	assert.Nil(t, opts.PageNumber)
+
+	_, _, err = client.Events.GetEvents(&GetEventsOptions{From: &to, To: &from})
+	assert.ErrorIs(t, err, ErrMalformedInputValue)
+}
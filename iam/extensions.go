@@ -0,0 +1,121 @@
+package iam
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Extensions holds custom attributes of a resource which are not part of its
+// struct definition. They are serialized as top level attributes of the resource
+type Extensions map[string]interface{}
+
+// Set sets the extension attribute key to value
+func (e *Extensions) Set(key string, value interface{}) {
+	if *e == nil {
+		*e = make(Extensions)
+	}
+	(*e)[key] = value
+}
+
+// Get returns the raw value of extension attribute key
+func (e Extensions) Get(key string) (interface{}, bool) {
+	v, ok := e[key]
+	return v, ok
+}
+
+// String returns the extension attribute key if it is a string
+func (e Extensions) String(key string) (string, bool) {
+	v, ok := e[key].(string)
+	return v, ok
+}
+
+// Bool returns the extension attribute key if it is a boolean
+func (e Extensions) Bool(key string) (bool, bool) {
+	v, ok := e[key].(bool)
+	return v, ok
+}
+
+// Float64 returns the extension attribute key if it is a number
+func (e Extensions) Float64(key string) (float64, bool) {
+	switch v := e[key].(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// Decode decodes extension attribute key into v, which should be a pointer
+func (e Extensions) Decode(key string, v interface{}) error {
+	value, ok := e[key]
+	if !ok {
+		return ErrNotFound
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// jsonFieldNames returns the JSON attribute names of the fields of struct type t
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[name] = true
+	}
+	return names
+}
+
+// marshalWithExtensions adds the extensions to the JSON object data. Extensions
+// never override attributes defined by the struct type t
+func marshalWithExtensions(data []byte, t reflect.Type, extensions Extensions) ([]byte, error) {
+	if len(extensions) == 0 {
+		return data, nil
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	known := jsonFieldNames(t)
+	for key, value := range extensions {
+		if known[key] {
+			continue
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		object[key] = raw
+	}
+	return json.Marshal(object)
+}
+
+// unmarshalExtensions returns the attributes of the JSON object data which are
+// not defined by the struct type t
+func unmarshalExtensions(data []byte, t reflect.Type) (Extensions, error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	known := jsonFieldNames(t)
+	var extensions Extensions
+	for key, value := range object {
+		if !known[key] {
+			extensions.Set(key, value)
+		}
+	}
+	return extensions, nil
+}
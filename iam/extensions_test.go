@@ -0,0 +1,77 @@
+package iam
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonExtensions(t *testing.T) {
+	person := Person{
+		LoginID:      "ron",
+		ResourceType: "Person",
+		Name:         Name{Family: "Swanson", Given: "Ron"},
+	}
+	person.Extensions.Set("employeeNumber", "E1234")
+	person.Extensions.Set("loginId", "ignored")
+	person.Extensions.Set("badge", map[string]interface{}{"level": 3, "active": true})
+
+	data, err := json.Marshal(person)
+	if !assert.Nil(t, err) {
+		return
+	}
+	var raw map[string]interface{}
+	_ = json.Unmarshal(data, &raw)
+	assert.Equal(t, "E1234", raw["employeeNumber"])
+	assert.Equal(t, "ron", raw["loginId"], "extensions must not override defined attributes")
+
+	var decoded Person
+	if !assert.Nil(t, json.Unmarshal(data, &decoded)) {
+		return
+	}
+	assert.Equal(t, "ron", decoded.LoginID)
+	assert.Equal(t, "Swanson", decoded.Name.Family)
+	employeeNumber, ok := decoded.Extensions.String("employeeNumber")
+	assert.True(t, ok)
+	assert.Equal(t, "E1234", employeeNumber)
+	_, ok = decoded.Extensions.Get("loginId")
+	assert.False(t, ok)
+
+	var badge struct {
+		Level  int  `json:"level"`
+		Active bool `json:"active"`
+	}
+	assert.Nil(t, decoded.Extensions.Decode("badge", &badge))
+	assert.Equal(t, 3, badge.Level)
+	assert.True(t, badge.Active)
+	assert.Equal(t, ErrNotFound, decoded.Extensions.Decode("missing", &badge))
+
+	plain, _ := json.Marshal(Person{LoginID: "ron"})
+	var plainDecoded Person
+	_ = json.Unmarshal(plain, &plainDecoded)
+	assert.Nil(t, plainDecoded.Extensions)
+}
+
+func TestUserExtensions(t *testing.T) {
+	var user User
+	err := json.Unmarshal([]byte(`{
+		"id": "f5fe538f-c3b5-4454-8774-cd3789f59b9f",
+		"loginId": "ron",
+		"emailAddress": "ron@example.com",
+		"costCenter": 4711,
+		"clinician": true
+	}`), &user)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "ron", user.LoginID)
+	costCenter, ok := user.Extensions.Float64("costCenter")
+	assert.True(t, ok)
+	assert.Equal(t, float64(4711), costCenter)
+	clinician, ok := user.Extensions.Bool("clinician")
+	assert.True(t, ok)
+	assert.True(t, clinician)
+	_, ok = user.Extensions.String("clinician")
+	assert.False(t, ok)
+}
@@ -0,0 +1,253 @@
+package iam
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	autoconf "github.com/philips-software/go-hsdp-api/config"
+	"github.com/philips-software/go-hsdp-api/internal"
+)
+
+const (
+	defaultFailoverRecoveryInterval = 1 * time.Minute
+)
+
+type failoverEndpoint struct {
+	url      *url.URL
+	healthy  bool
+	failedAt time.Time
+}
+
+// failoverTransport sends requests for the primary IAM or IDM host to the first
+// healthy endpoint of its group. Endpoints are marked unhealthy on connection
+// errors or 5xx responses and are only used again after a successful health check
+// once the recovery interval has passed. Requests keep the path prefix of the endpoint
+// they are sent to. Non-idempotent requests only fail over on connection errors,
+// a 5xx response may mean the request was already processed
+type failoverTransport struct {
+	next     http.RoundTripper
+	recovery time.Duration
+	iamURLs  []string
+	idmURLs  []string
+
+	mu     sync.Mutex
+	groups map[string][]*failoverEndpoint
+}
+
+func newFailoverTransport(next http.RoundTripper, recovery time.Duration, iamURLs, idmURLs []string) *failoverTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if recovery <= 0 {
+		recovery = defaultFailoverRecoveryInterval
+	}
+	return &failoverTransport{
+		next:     next,
+		recovery: recovery,
+		iamURLs:  iamURLs,
+		idmURLs:  idmURLs,
+		groups:   make(map[string][]*failoverEndpoint),
+	}
+}
+
+// setPrimaries rebuilds the groups for the given primary IAM and IDM URLs. Either may be nil
+func (t *failoverTransport) setPrimaries(iamURL, idmURL *url.URL) error {
+	groups := make(map[string][]*failoverEndpoint)
+	if iamURL != nil {
+		if err := addGroup(groups, iamURL, t.iamURLs); err != nil {
+			return err
+		}
+	}
+	if idmURL != nil {
+		if err := addGroup(groups, idmURL, t.idmURLs); err != nil {
+			return err
+		}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.groups = groups
+	return nil
+}
+
+func addGroup(groups map[string][]*failoverEndpoint, primary *url.URL, secondaries []string) error {
+	endpoints := []*failoverEndpoint{{url: primary, healthy: true}}
+	for _, s := range secondaries {
+		u, err := url.Parse(s)
+		if err != nil {
+			return err
+		}
+		if u.Host == "" || u.Host == primary.Host {
+			continue
+		}
+		endpoints = append(endpoints, &failoverEndpoint{url: u, healthy: true})
+	}
+	if len(endpoints) > 1 {
+		groups[primary.Host] = endpoints
+	}
+	return nil
+}
+
+// candidates returns the endpoints to try in order: usable endpoints first,
+// followed by the unhealthy ones as a last resort
+func (t *failoverTransport) candidates(endpoints []*failoverEndpoint) []*failoverEndpoint {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var usable, unhealthy []*failoverEndpoint
+	for _, e := range endpoints {
+		if e.healthy || time.Since(e.failedAt) >= t.recovery {
+			usable = append(usable, e)
+		} else {
+			unhealthy = append(unhealthy, e)
+		}
+	}
+	return append(usable, unhealthy...)
+}
+
+func (t *failoverTransport) mark(e *failoverEndpoint, healthy bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e.healthy = healthy
+	if !healthy {
+		e.failedAt = time.Now()
+	}
+}
+
+func (t *failoverTransport) isRecovering(e *failoverEndpoint) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return !e.healthy && time.Since(e.failedAt) >= t.recovery
+}
+
+func (t *failoverTransport) healthCheck(e *failoverEndpoint) bool {
+	req, err := http.NewRequest(http.MethodHead, e.url.Scheme+"://"+e.url.Host+"/", nil)
+	if err != nil {
+		return false
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode < http.StatusInternalServerError
+}
+
+func failed(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// endpointURL returns u rewritten from the primary endpoint to e, keeping the
+// path prefix of e. The path of u is relative to the path of the primary
+func endpointURL(u *url.URL, primary, e *failoverEndpoint) {
+	u.Scheme = e.url.Scheme
+	u.Host = e.url.Host
+	if primary == e {
+		return
+	}
+	rebase := func(path string) string {
+		path = strings.TrimPrefix(path, strings.TrimSuffix(primary.url.Path, "/"))
+		return strings.TrimSuffix(e.url.Path, "/") + path
+	}
+	// Request paths are sent as opaque data
+	if u.Opaque != "" {
+		u.Opaque = rebase(u.Opaque)
+		return
+	}
+	u.Path = rebase(u.Path)
+	u.RawPath = ""
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	endpoints, ok := t.groups[req.URL.Host]
+	t.mu.Unlock()
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	candidates := t.candidates(endpoints)
+	var resp *http.Response
+	var err error
+	for _, e := range candidates {
+		if t.isRecovering(e) && !t.healthCheck(e) {
+			t.mark(e, false)
+			continue
+		}
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		attempt := req.Clone(req.Context())
+		endpointURL(attempt.URL, endpoints[0], e)
+		attempt.Host = e.url.Host
+		if body != nil {
+			attempt.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		resp, err = t.next.RoundTrip(attempt)
+		if !failed(resp, err) {
+			t.mark(e, true)
+			return resp, nil
+		}
+		if req.Context().Err() != nil {
+			return resp, err
+		}
+		t.mark(e, false)
+		if err == nil && !internal.IsIdempotent(req) {
+			return resp, nil
+		}
+	}
+	if resp == nil && err == nil {
+		err = ErrNoHealthyEndpoint
+	}
+	return resp, err
+}
+
+func failoverURLs(urls, regions []string, environment, service string) []string {
+	result := append([]string{}, urls...)
+	for _, region := range regions {
+		c, err := autoconf.New(
+			autoconf.WithRegion(region),
+			autoconf.WithEnv(environment))
+		if err != nil {
+			continue
+		}
+		if u := c.Service(service).URL; u != "" {
+			result = append(result, u)
+		}
+	}
+	return result
+}
+
+func (c *Client) setupFailover(httpClient *http.Client) error {
+	iamURLs := failoverURLs(c.config.FailoverIAMURLs, c.config.FailoverRegions, c.config.Environment, "iam")
+	idmURLs := failoverURLs(c.config.FailoverIDMURLs, c.config.FailoverRegions, c.config.Environment, "idm")
+	if len(iamURLs) == 0 && len(idmURLs) == 0 {
+		return nil
+	}
+	next := httpClient.Transport
+	if existing, ok := next.(*failoverTransport); ok { // Cloned client
+		next = existing.next
+	}
+	transport := newFailoverTransport(next, c.config.FailoverRecoveryInterval, iamURLs, idmURLs)
+	if err := transport.setPrimaries(c.baseIAMURL, c.baseIDMURL); err != nil {
+		return err
+	}
+	// Leave the client of the caller untouched, it may be shared
+	private := *httpClient
+	private.Transport = transport
+	c.Client = &private
+	c.failover = transport
+	return nil
+}
@@ -0,0 +1,148 @@
+package iam
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailover(t *testing.T) {
+	var primaryDown int32 = 1
+	var primaryHits, secondaryHits int32
+
+	handler := func(hits *int32, down *int32) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if down != nil && atomic.LoadInt32(down) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			atomic.AddInt32(hits, 1)
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(body)
+		}
+	}
+	primary := httptest.NewServer(handler(&primaryHits, &primaryDown))
+	defer primary.Close()
+	secondary := httptest.NewServer(handler(&secondaryHits, nil))
+	defer secondary.Close()
+
+	httpClient := &http.Client{Transport: http.DefaultTransport}
+	client, err := NewClient(httpClient, &Config{
+		OAuth2ClientID:           "TestClient",
+		OAuth2Secret:             "Secret",
+		IAMURL:                   primary.URL,
+		IDMURL:                   primary.URL,
+		FailoverIAMURLs:          []string{secondary.URL},
+		FailoverIDMURLs:          []string{secondary.URL},
+		FailoverRecoveryInterval: 50 * time.Millisecond,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, http.DefaultTransport, httpClient.Transport, "the client of the caller must not be modified")
+	type echo struct {
+		ID string `json:"id" url:"-"`
+	}
+	send := func(method string) (string, error) {
+		req, err := client.newRequest(IDM, method, "authorize/identity/Echo", &echo{ID: "payload"}, nil)
+		if err != nil {
+			return "", err
+		}
+		var result echo
+		_, err = client.do(req, &result)
+		return result.ID, err
+	}
+
+	// A 5xx on a non-idempotent request may have been processed, so it is not resent
+	_, err = send("POST")
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&secondaryHits))
+
+	// Primary is skipped while unhealthy
+	_, err = send("POST")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&secondaryHits))
+
+	time.Sleep(60 * time.Millisecond)
+	id, err := send("PUT")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "payload", id, "body should be replayed on the secondary")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&secondaryHits))
+
+	// Primary recovers after the interval and a successful health check
+	atomic.StoreInt32(&primaryDown, 0)
+	time.Sleep(60 * time.Millisecond)
+	_, err = send("POST")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&primaryHits))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&secondaryHits))
+
+	// Groups follow a changed base URL
+	other := httptest.NewServer(handler(new(int32), nil))
+	defer other.Close()
+	assert.Nil(t, client.SetBaseIDMURL(other.URL))
+	transport := client.HttpClient().Transport.(*failoverTransport)
+	transport.mu.Lock()
+	_, oldGroup := transport.groups[primary.Listener.Addr().String()]
+	_, newGroup := transport.groups[other.Listener.Addr().String()]
+	transport.mu.Unlock()
+	assert.True(t, oldGroup, "IAM group is kept")
+	assert.True(t, newGroup)
+	assert.Nil(t, client.SetBaseIAMURL(other.URL))
+	transport.mu.Lock()
+	_, oldGroup = transport.groups[primary.Listener.Addr().String()]
+	transport.mu.Unlock()
+	assert.False(t, oldGroup)
+
+	// Cloned clients do not stack transports
+	clone := client.WithToken("foo")
+	_, ok := clone.HttpClient().Transport.(*failoverTransport)
+	assert.True(t, ok)
+	_, ok = clone.HttpClient().Transport.(*failoverTransport).next.(*failoverTransport)
+	assert.False(t, ok)
+}
+
+func TestFailoverPathPrefix(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+	var paths []string
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer secondary.Close()
+
+	client, err := NewClient(nil, &Config{
+		OAuth2ClientID:  "TestClient",
+		OAuth2Secret:    "Secret",
+		IAMURL:          primary.URL,
+		IDMURL:          primary.URL,
+		FailoverIDMURLs: []string{secondary.URL + "/idm/"},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	// DELETE is idempotent, so it is resent after a 5xx
+	req, err := client.newRequest(IDM, "DELETE", "authorize/identity/Echo/1", nil, nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = client.do(req, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"DELETE /idm/authorize/identity/Echo/1"}, paths)
+}
@@ -0,0 +1,210 @@
+package iam
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	federationAPIVersion = "1"
+)
+
+// Identity provider types
+const (
+	IdentityProviderSAML = "SAML"
+	IdentityProviderOIDC = "OIDC"
+)
+
+// FederationService provides operations on the external identity providers of an organization
+type FederationService struct {
+	client *Client
+
+	validate *validator.Validate
+}
+
+// AttributeMapping maps an attribute or claim asserted by the identity provider to an IAM user attribute
+type AttributeMapping struct {
+	IdPAttribute string `json:"idpAttribute" validate:"required"`
+	IAMAttribute string `json:"iamAttribute" validate:"required"`
+}
+
+// IdentityProvider describes an external SAML or OIDC identity provider
+type IdentityProvider struct {
+	ID                string             `json:"id,omitempty"`
+	Name              string             `json:"name" validate:"required,min=1,max=255"`
+	Description       string             `json:"description,omitempty" validate:"max=250"`
+	Type              string             `json:"type" validate:"required,oneof=SAML OIDC"`
+	OrganizationID    string             `json:"organizationId" validate:"required"`
+	Enabled           bool               `json:"enabled"`
+	MetadataURL       string             `json:"metadataUrl,omitempty" validate:"omitempty,url"`
+	Issuer            string             `json:"issuer,omitempty" validate:"required_if=Type OIDC"`
+	ClientID          string             `json:"clientId,omitempty" validate:"required_if=Type OIDC"`
+	ClientSecret      string             `json:"clientSecret,omitempty"`
+	Scopes            []string           `json:"scopes,omitempty"`
+	AttributeMappings []AttributeMapping `json:"attributeMappings,omitempty" validate:"dive"`
+	Meta              *Meta              `json:"meta,omitempty"`
+}
+
+// GetIdentityProvidersOptions describes the criteria for looking up identity providers
+type GetIdentityProvidersOptions struct {
+	ID             *string `url:"_id,omitempty"`
+	Name           *string `url:"name,omitempty"`
+	Type           *string `url:"type,omitempty"`
+	OrganizationID *string `url:"organizationId,omitempty"`
+}
+
+// CreateIdentityProvider registers an identity provider
+func (f *FederationService) CreateIdentityProvider(idp IdentityProvider, options ...OptionFunc) (*IdentityProvider, *Response, error) {
+	if err := f.validate.Struct(idp); err != nil {
+		return nil, nil, err
+	}
+	req, err := f.client.newRequest(IDM, "POST", "authorize/identity/IdentityProvider", &idp, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", federationAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var createdIdP IdentityProvider
+
+	resp, err := f.client.do(req, &createdIdP)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &createdIdP, resp, nil
+}
+
+// GetIdentityProviderByID retrieves an identity provider by ID
+func (f *FederationService) GetIdentityProviderByID(id string, options ...OptionFunc) (*IdentityProvider, *Response, error) {
+	req, err := f.client.newRequest(IDM, "GET", "authorize/identity/IdentityProvider/"+id, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", federationAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var idp IdentityProvider
+
+	resp, err := f.client.do(req, &idp)
+	if err != nil {
+		return nil, resp, err
+	}
+	if idp.ID != id {
+		return nil, resp, ErrNotFound
+	}
+	return &idp, resp, nil
+}
+
+// GetIdentityProviders looks up identity providers based on GetIdentityProvidersOptions
+func (f *FederationService) GetIdentityProviders(opt *GetIdentityProvidersOptions, options ...OptionFunc) (*[]IdentityProvider, *Response, error) {
+	req, err := f.client.newRequest(IDM, "GET", "authorize/identity/IdentityProvider", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", federationAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var bundleResponse struct {
+		Total int                `json:"total"`
+		Entry []IdentityProvider `json:"entry"`
+	}
+
+	resp, err := f.client.do(req, &bundleResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &bundleResponse.Entry, resp, nil
+}
+
+// UpdateIdentityProvider updates an identity provider
+func (f *FederationService) UpdateIdentityProvider(idp IdentityProvider, options ...OptionFunc) (*IdentityProvider, *Response, error) {
+	if idp.Meta == nil {
+		return nil, nil, ErrMissingEtagInformation
+	}
+	if err := f.validate.Struct(idp); err != nil {
+		return nil, nil, err
+	}
+	req, err := f.client.newRequest(IDM, "PUT", "authorize/identity/IdentityProvider/"+idp.ID, &idp, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", federationAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", idp.Meta.Version)
+
+	var updatedIdP IdentityProvider
+
+	resp, err := f.client.do(req, &updatedIdP)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &updatedIdP, resp, nil
+}
+
+// DeleteIdentityProvider deletes the given identity provider
+func (f *FederationService) DeleteIdentityProvider(idp IdentityProvider, options ...OptionFunc) (bool, *Response, error) {
+	req, err := f.client.newRequest(IDM, "DELETE", "authorize/identity/IdentityProvider/"+idp.ID, nil, options)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("api-version", federationAPIVersion)
+
+	var deleteResponse bytes.Buffer
+
+	resp, err := f.client.do(req, &deleteResponse)
+	if resp == nil || resp.StatusCode != http.StatusNoContent {
+		return false, resp, err
+	}
+	return true, resp, nil
+}
+
+// UploadMetadata uploads the SAML metadata XML document of the identity provider
+func (f *FederationService) UploadMetadata(idp IdentityProvider, metadata []byte, options ...OptionFunc) (bool, *Response, error) {
+	if len(metadata) == 0 {
+		return false, nil, ErrMissingMetadata
+	}
+	req, err := f.client.newRequest(IDM, "PUT", "authorize/identity/IdentityProvider/"+idp.ID+"/$metadata", nil, options)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("api-version", federationAPIVersion)
+	req.Header.Set("Content-Type", "application/samlmetadata+xml")
+	req.Body = io.NopCloser(bytes.NewReader(metadata))
+	req.ContentLength = int64(len(metadata))
+
+	var putResponse bytes.Buffer
+
+	resp, err := f.client.do(req, &putResponse)
+	if err != nil {
+		return false, resp, err
+	}
+	return resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusOK, resp, nil
+}
+
+// SetAttributeMappings replaces the attribute mappings of the identity provider
+func (f *FederationService) SetAttributeMappings(idp IdentityProvider, mappings []AttributeMapping, options ...OptionFunc) (bool, *Response, error) {
+	for _, m := range mappings {
+		if err := f.validate.Struct(m); err != nil {
+			return false, nil, err
+		}
+	}
+	body := struct {
+		AttributeMappings []AttributeMapping `json:"attributeMappings"`
+	}{mappings}
+	req, err := f.client.newRequest(IDM, "PUT", "authorize/identity/IdentityProvider/"+idp.ID+"/$attribute-mappings", &body, options)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("api-version", federationAPIVersion)
+
+	var putResponse bytes.Buffer
+
+	resp, err := f.client.do(req, &putResponse)
+	if err != nil {
+		return false, resp, err
+	}
+	return resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusOK, resp, nil
+}
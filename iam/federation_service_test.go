@@ -0,0 +1,127 @@
+package iam
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testIdentityProviderJSON(id string) string {
+	return `{
+  "id": "` + id + `",
+  "name": "Hospital AD FS",
+  "type": "SAML",
+  "organizationId": "c57b2625-eda3-4b27-a8e6-86f0a0e76afc",
+  "enabled": true,
+  "attributeMappings": [{"idpAttribute": "emailaddress", "iamAttribute": "email"}],
+  "meta": {"version": "W/\"1\""}
+}`
+}
+
+func TestFederation(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	idpID := "6a1c1b5e-89b0-4a6e-8f0e-9a4f6a1b8c2d"
+	metadata := `<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://adfs.example.com"/>`
+
+	muxIDM.HandleFunc("/authorize/identity/IdentityProvider", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "POST":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, testIdentityProviderJSON(idpID))
+		case "GET":
+			assert.Equal(t, IdentityProviderSAML, r.URL.Query().Get("type"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{"total":1,"entry":[`+testIdentityProviderJSON(idpID)+`]}`)
+		}
+	})
+	muxIDM.HandleFunc("/authorize/identity/IdentityProvider/"+idpID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, testIdentityProviderJSON(idpID))
+		case "PUT":
+			assert.Equal(t, `W/"1"`, r.Header.Get("If-Match"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, testIdentityProviderJSON(idpID))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	muxIDM.HandleFunc("/authorize/identity/IdentityProvider/"+idpID+"/$metadata", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("Content-Type") != "application/samlmetadata+xml" || string(body) != metadata {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"resourceType": "OperationOutcome","issue": [{"severity": "error","code": "invalid"}]}`)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	muxIDM.HandleFunc("/authorize/identity/IdentityProvider/"+idpID+"/$attribute-mappings", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			AttributeMappings []AttributeMapping `json:"attributeMappings"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.AttributeMappings) != 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"resourceType": "OperationOutcome","issue": [{"severity": "error","code": "invalid"}]}`)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, _, err := client.Federation.CreateIdentityProvider(IdentityProvider{
+		Name:           "Hospital Okta",
+		Type:           IdentityProviderOIDC,
+		OrganizationID: "c57b2625-eda3-4b27-a8e6-86f0a0e76afc",
+	})
+	assert.NotNil(t, err, "OIDC provider without issuer should fail validation")
+
+	idp := IdentityProvider{
+		Name:           "Hospital AD FS",
+		Type:           IdentityProviderSAML,
+		OrganizationID: "c57b2625-eda3-4b27-a8e6-86f0a0e76afc",
+		Enabled:        true,
+	}
+	created, resp, err := client.Federation.CreateIdentityProvider(idp)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, idpID, created.ID)
+
+	ok, _, err := client.Federation.UploadMetadata(*created, []byte(metadata))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	_, _, err = client.Federation.UploadMetadata(*created, nil)
+	assert.Equal(t, ErrMissingMetadata, err)
+
+	ok, _, err = client.Federation.SetAttributeMappings(*created, []AttributeMapping{
+		{IdPAttribute: "emailaddress", IAMAttribute: "email"},
+		{IdPAttribute: "upn", IAMAttribute: "loginId"},
+	})
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	samlType := IdentityProviderSAML
+	list, _, err := client.Federation.GetIdentityProviders(&GetIdentityProvidersOptions{Type: &samlType})
+	if assert.Nil(t, err) {
+		assert.Len(t, *list, 1)
+	}
+	found, _, err := client.Federation.GetIdentityProviderByID(idpID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	updated, _, err := client.Federation.UpdateIdentityProvider(*found)
+	assert.Nil(t, err)
+	assert.NotNil(t, updated)
+
+	ok, _, err = client.Federation.DeleteIdentityProvider(*found)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
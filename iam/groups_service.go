@@ -1,15 +1,22 @@
 package iam
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/philips-software/go-hsdp-api/internal"
 )
 
 const (
-	groupAPIVersion = "1"
+	groupAPIVersion      = "1"
+	groupMemberBatchSize = 10
+
+	defaultMemberRetryDelay = time.Second
 )
 
 // GetGroupOptions describes the fields on which you can search for Groups
@@ -33,8 +40,8 @@ type Membership struct {
 }
 
 // GetGroupByID retrieves a Group based on the ID
-func (g *GroupsService) GetGroupByID(id string) (*Group, *Response, error) {
-	req, err := g.client.newRequest(IDM, "GET", "authorize/identity/Group/"+id, nil, nil)
+func (g *GroupsService) GetGroupByID(id string, options ...OptionFunc) (*Group, *Response, error) {
+	req, err := g.client.newRequest(IDM, "GET", "authorize/identity/Group/"+id, nil, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -75,11 +82,11 @@ func (g *GroupsService) GetGroups(opt *GetGroupOptions, options ...OptionFunc) (
 }
 
 // CreateGroup creates a Group
-func (g *GroupsService) CreateGroup(group Group) (*Group, *Response, error) {
+func (g *GroupsService) CreateGroup(group Group, options ...OptionFunc) (*Group, *Response, error) {
 	if err := g.client.validate.Struct(group); err != nil {
 		return nil, nil, err
 	}
-	req, err := g.client.newRequest(IDM, "POST", "authorize/identity/Group", &group, nil)
+	req, err := g.client.newRequest(IDM, "POST", "authorize/identity/Group", &group, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -96,12 +103,12 @@ func (g *GroupsService) CreateGroup(group Group) (*Group, *Response, error) {
 }
 
 // UpdateGroup updates the Group
-func (g *GroupsService) UpdateGroup(group Group) (*Group, *Response, error) {
+func (g *GroupsService) UpdateGroup(group Group, options ...OptionFunc) (*Group, *Response, error) {
 	var updateRequest struct {
 		Description string `json:"description"`
 	}
 	updateRequest.Description = group.Description
-	req, err := g.client.newRequest(IDM, "PUT", "authorize/identity/Group/"+group.ID, &updateRequest, nil)
+	req, err := g.client.newRequest(IDM, "PUT", "authorize/identity/Group/"+group.ID, &updateRequest, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -118,8 +125,8 @@ func (g *GroupsService) UpdateGroup(group Group) (*Group, *Response, error) {
 }
 
 // DeleteGroup deletes the given Group
-func (g *GroupsService) DeleteGroup(group Group) (bool, *Response, error) {
-	req, err := g.client.newRequest(IDM, "DELETE", "authorize/identity/Group/"+group.ID, nil, nil)
+func (g *GroupsService) DeleteGroup(group Group, options ...OptionFunc) (bool, *Response, error) {
+	req, err := g.client.newRequest(IDM, "DELETE", "authorize/identity/Group/"+group.ID, nil, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -135,12 +142,40 @@ func (g *GroupsService) DeleteGroup(group Group) (bool, *Response, error) {
 
 }
 
+// GetMembers retrieves the users which are a member of the group with the given ID.
+// All pages are retrieved and the profile of each member is looked up
+func (g *GroupsService) GetMembers(groupID string, options ...OptionFunc) ([]Person, *Response, error) {
+	userIDs, resp, err := g.client.Users.GetAllUsers(&GetUserOptions{GroupID: &groupID}, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	members := make([]Person, 0, len(userIDs))
+	for _, userID := range userIDs {
+		user, resp, err := g.client.Users.GetUserByID(userID, options...)
+		if err != nil {
+			return members, resp, &UserError{User: userID, Err: err}
+		}
+		members = append(members, user.person())
+	}
+	return members, resp, nil
+}
+
+// CountMembers returns the number of users which are a member of the group with the given ID
+// without looking up their profiles
+func (g *GroupsService) CountMembers(groupID string, options ...OptionFunc) (int, *Response, error) {
+	userIDs, resp, err := g.client.Users.GetAllUsers(&GetUserOptions{GroupID: &groupID}, options...)
+	if err != nil {
+		return 0, resp, err
+	}
+	return len(userIDs), resp, nil
+}
+
 // GetRoles returns the roles assigned to this group
-func (g *GroupsService) GetRoles(group Group) (*[]Role, *Response, error) {
+func (g *GroupsService) GetRoles(group Group, options ...OptionFunc) (*[]Role, *Response, error) {
 	opt := &GetRolesOptions{
 		GroupID: &group.ID,
 	}
-	req, err := g.client.newRequest(IDM, "GET", "authorize/identity/Role", opt, nil)
+	req, err := g.client.newRequest(IDM, "GET", "authorize/identity/Role", opt, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -159,11 +194,11 @@ func (g *GroupsService) GetRoles(group Group) (*[]Role, *Response, error) {
 	return &responseStruct.Entry, resp, err
 }
 
-func (g *GroupsService) roleAction(group Group, role Role, action string) (bool, *Response, error) {
+func (g *GroupsService) roleAction(group Group, role Role, action string, options []OptionFunc) (bool, *Response, error) {
 	var assignRequest = groupRequest{
 		Roles: []string{role.ID},
 	}
-	req, err := g.client.newRequest(IDM, "POST", "authorize/identity/Group/"+group.ID+"/"+action, assignRequest, nil)
+	req, err := g.client.newRequest(IDM, "POST", "authorize/identity/Group/"+group.ID+"/"+action, assignRequest, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -183,13 +218,13 @@ func (g *GroupsService) roleAction(group Group, role Role, action string) (bool,
 }
 
 // AssignRole adds a role to a group
-func (g *GroupsService) AssignRole(group Group, role Role) (bool, *Response, error) {
-	return g.roleAction(group, role, "$assign-role")
+func (g *GroupsService) AssignRole(group Group, role Role, options ...OptionFunc) (bool, *Response, error) {
+	return g.roleAction(group, role, "$assign-role", options)
 }
 
 // RemoveRole removes a role from a group
-func (g *GroupsService) RemoveRole(group Group, role Role) (bool, *Response, error) {
-	return g.roleAction(group, role, "$remove-role")
+func (g *GroupsService) RemoveRole(group Group, role Role, options ...OptionFunc) (bool, *Response, error) {
+	return g.roleAction(group, role, "$remove-role", options)
 }
 
 // Reference holds a reference
@@ -215,9 +250,15 @@ type groupRequest struct {
 }
 
 func (g *GroupsService) memberAction(group Group, action string, opt interface{}, options []OptionFunc) (map[string]interface{}, *Response, error) {
+	memberResponse, resp, _, err := g.memberActionContext(group, action, opt, options)
+	return memberResponse, resp, err
+}
+
+// memberActionContext is memberAction which also returns the context of the request
+func (g *GroupsService) memberActionContext(group Group, action string, opt interface{}, options []OptionFunc) (map[string]interface{}, *Response, context.Context, error) {
 	req, err := g.client.newRequest(IDM, "POST", "authorize/identity/Group/"+group.ID+"/"+action, opt, options)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, context.Background(), err
 	}
 	req.Header.Set("api-version", groupAPIVersion)
 	req.Header.Set("Content-Type", "application/json")
@@ -227,9 +268,9 @@ func (g *GroupsService) memberAction(group Group, action string, opt interface{}
 	resp, err := g.client.do(req, &memberResponse)
 
 	if err != nil && err != io.EOF { // EOF is valid
-		return memberResponse, resp, err
+		return memberResponse, resp, req.Context(), err
 	}
-	return memberResponse, resp, nil
+	return memberResponse, resp, req.Context(), nil
 }
 
 func memberRequestBody(memberType string, identities ...string) memberRequest {
@@ -258,20 +299,147 @@ func groupRequestBody(users ...string) groupRequest {
 
 type MemberResponse map[string]interface{}
 
-// AddMembers adds users to the given Group
+// AddMembers adds users to the given Group. It stops at the first failing batch;
+// use AddMembersWithResults for the outcome of each individual user
 func (g *GroupsService) AddMembers(group Group, users ...string) (MemberResponse, *Response, error) {
-	return perSlice(users, 10, func(chunk []string) (MemberResponse, *Response, error) {
-		return g.memberAction(group, "$add-members", groupRequestBody(chunk...), nil)
+	return g.AddMembersWithOptions(group, users)
+}
+
+// AddMembersWithOptions is AddMembers with request options
+func (g *GroupsService) AddMembersWithOptions(group Group, users []string, options ...OptionFunc) (MemberResponse, *Response, error) {
+	return perSlice(users, groupMemberBatchSize, func(chunk []string) (MemberResponse, *Response, error) {
+		return g.memberAction(group, "$add-members", groupRequestBody(chunk...), options)
 	})
 }
 
-// RemoveMembers removes users from the given Group
+// RemoveMembers removes users from the given Group. It stops at the first failing batch;
+// use RemoveMembersWithResults for the outcome of each individual user
 func (g *GroupsService) RemoveMembers(group Group, users ...string) (MemberResponse, *Response, error) {
-	return perSlice(users, 10, func(chunk []string) (MemberResponse, *Response, error) {
-		return g.memberAction(group, "$remove-members", groupRequestBody(chunk...), nil)
+	return g.RemoveMembersWithOptions(group, users)
+}
+
+// RemoveMembersWithOptions is RemoveMembers with request options
+func (g *GroupsService) RemoveMembersWithOptions(group Group, users []string, options ...OptionFunc) (MemberResponse, *Response, error) {
+	return perSlice(users, groupMemberBatchSize, func(chunk []string) (MemberResponse, *Response, error) {
+		return g.memberAction(group, "$remove-members", groupRequestBody(chunk...), options)
 	})
 }
 
+// MemberBatchOptions configures AddMembersWithResults and RemoveMembersWithResults
+type MemberBatchOptions struct {
+	// BatchSize is the number of members sent per request. Defaults to
+	// and is capped at the IAM maximum of 10
+	BatchSize int
+	// Retries is the number of times a batch failing with a transient error is
+	// retried before its members are retried one by one
+	Retries int
+	// RetryDelay is the initial delay between retries, defaults to 1 second
+	RetryDelay time.Duration
+}
+
+// MemberResults maps each member to the outcome of its update. A nil error means success
+type MemberResults map[string]error
+
+// Failed returns the members whose update failed
+func (m MemberResults) Failed() []string {
+	var failed []string
+	for member, err := range m {
+		if err != nil {
+			failed = append(failed, member)
+		}
+	}
+	return failed
+}
+
+// AddMembersWithResults adds users to the given Group in batches. A batch failing with a
+// transient error is retried with backoff and finally split up, as is a batch rejected
+// because of one of its members, so a single problematic user does not fail the others.
+// Other errors, such as 400, 401 or 403, are reported for the whole batch. When the request
+// context is done while waiting for a retry, its error is reported for all remaining users
+func (g *GroupsService) AddMembersWithResults(group Group, users []string, opts MemberBatchOptions, options ...OptionFunc) (MemberResults, *Response, error) {
+	return g.memberBatchAction(group, "$add-members", users, opts, options)
+}
+
+// RemoveMembersWithResults removes users from the given Group in batches. See AddMembersWithResults
+func (g *GroupsService) RemoveMembersWithResults(group Group, users []string, opts MemberBatchOptions, options ...OptionFunc) (MemberResults, *Response, error) {
+	return g.memberBatchAction(group, "$remove-members", users, opts, options)
+}
+
+func (g *GroupsService) memberBatchAction(group Group, action string, users []string, opts MemberBatchOptions, options []OptionFunc) (MemberResults, *Response, error) {
+	if opts.BatchSize <= 0 || opts.BatchSize > groupMemberBatchSize {
+		opts.BatchSize = groupMemberBatchSize
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = defaultMemberRetryDelay
+	}
+	results := make(MemberResults, len(users))
+	var resp *Response
+	var err error
+
+	for i := 0; i < len(users); i += opts.BatchSize {
+		end := i + opts.BatchSize
+		if end > len(users) {
+			end = len(users)
+		}
+		batch := users[i:end]
+		b := backoff.NewExponentialBackOff()
+		b.InitialInterval = opts.RetryDelay
+		b.MaxElapsedTime = 0
+	retries:
+		for attempt := 0; ; attempt++ {
+			var ctx context.Context
+			_, resp, ctx, err = g.memberActionContext(group, action, groupRequestBody(batch...), options)
+			if err == nil || attempt >= opts.Retries || !isTransientResponse(resp) {
+				break
+			}
+			timer := time.NewTimer(b.NextBackOff())
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				err = ctx.Err()
+				break retries
+			case <-timer.C:
+			}
+		}
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			for _, user := range users[i:] {
+				results[user] = err
+			}
+			return results, resp, nil
+		}
+		if err == nil {
+			for _, user := range batch {
+				results[user] = nil
+			}
+			continue
+		}
+		if len(batch) == 1 || !(isTransientResponse(resp) || isMemberError(resp)) {
+			for _, user := range batch {
+				results[user] = err
+			}
+			continue
+		}
+		for _, user := range batch {
+			_, resp, results[user] = g.memberAction(group, action, groupRequestBody(user), options)
+		}
+	}
+	return results, resp, nil
+}
+
+// isTransientResponse reports whether a request which got resp may succeed when retried
+func isTransientResponse(resp *Response) bool {
+	return resp == nil || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// isMemberError reports whether resp rejects a member rather than the request as a whole
+func isMemberError(resp *Response) bool {
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity:
+		return true
+	}
+	return false
+}
+
 func perSlice(slice []string, chunkSize int, fn func([]string) (MemberResponse, *Response, error)) (MemberResponse, *Response, error) {
 	var data map[string]interface{}
 	var resp *Response
@@ -298,52 +466,82 @@ func addIfMatchHeader(version string) OptionFunc {
 
 // AddIdentities adds services to the given Group
 func (g *GroupsService) AddIdentities(group Group, memberType string, identities ...string) (MemberResponse, *Response, error) {
-	_, resp, err := g.GetGroupByID(group.ID)
+	return g.AddIdentitiesWithOptions(group, memberType, identities)
+}
+
+// AddIdentitiesWithOptions is AddIdentities with request options
+func (g *GroupsService) AddIdentitiesWithOptions(group Group, memberType string, identities []string, options ...OptionFunc) (MemberResponse, *Response, error) {
+	_, resp, err := g.GetGroupByID(group.ID, options...)
 	if err != nil {
 		return nil, resp, err
 	}
 	version := resp.Header.Get("ETag")
-	return perSlice(identities, 10, func(chunk []string) (MemberResponse, *Response, error) {
-		return g.memberAction(group, "$assign", memberRequestBody(memberType, chunk...), []OptionFunc{addIfMatchHeader(version)})
+	return perSlice(identities, groupMemberBatchSize, func(chunk []string) (MemberResponse, *Response, error) {
+		return g.memberAction(group, "$assign", memberRequestBody(memberType, chunk...), append([]OptionFunc{addIfMatchHeader(version)}, options...))
 	})
 }
 
 // RemoveIdentities removes services from the given Group
 func (g *GroupsService) RemoveIdentities(group Group, memberType string, identities ...string) (MemberResponse, *Response, error) {
-	_, resp, err := g.GetGroupByID(group.ID)
+	return g.RemoveIdentitiesWithOptions(group, memberType, identities)
+}
+
+// RemoveIdentitiesWithOptions is RemoveIdentities with request options
+func (g *GroupsService) RemoveIdentitiesWithOptions(group Group, memberType string, identities []string, options ...OptionFunc) (MemberResponse, *Response, error) {
+	_, resp, err := g.GetGroupByID(group.ID, options...)
 	if err != nil {
 		return nil, resp, err
 	}
 	version := resp.Header.Get("ETag")
-	return perSlice(identities, 10, func(slice []string) (MemberResponse, *Response, error) {
-		return g.memberAction(group, "$remove", memberRequestBody(memberType, slice...), []OptionFunc{addIfMatchHeader(version)})
+	return perSlice(identities, groupMemberBatchSize, func(slice []string) (MemberResponse, *Response, error) {
+		return g.memberAction(group, "$remove", memberRequestBody(memberType, slice...), append([]OptionFunc{addIfMatchHeader(version)}, options...))
 	})
 }
 
 // AddDevices adds services to the given Group
 func (g *GroupsService) AddDevices(group Group, devices ...string) (MemberResponse, *Response, error) {
-	return perSlice(devices, 10, func(chunk []string) (MemberResponse, *Response, error) {
-		return g.AddIdentities(group, "DEVICE", chunk...)
+	return g.AddDevicesWithOptions(group, devices)
+}
+
+// AddDevicesWithOptions is AddDevices with request options
+func (g *GroupsService) AddDevicesWithOptions(group Group, devices []string, options ...OptionFunc) (MemberResponse, *Response, error) {
+	return perSlice(devices, groupMemberBatchSize, func(chunk []string) (MemberResponse, *Response, error) {
+		return g.AddIdentitiesWithOptions(group, "DEVICE", chunk, options...)
 	})
 }
 
 // RemoveDevices removes services from the given Group
 func (g *GroupsService) RemoveDevices(group Group, devices ...string) (MemberResponse, *Response, error) {
-	return perSlice(devices, 10, func(chunk []string) (MemberResponse, *Response, error) {
-		return g.RemoveIdentities(group, "DEVICE", chunk...)
+	return g.RemoveDevicesWithOptions(group, devices)
+}
+
+// RemoveDevicesWithOptions is RemoveDevices with request options
+func (g *GroupsService) RemoveDevicesWithOptions(group Group, devices []string, options ...OptionFunc) (MemberResponse, *Response, error) {
+	return perSlice(devices, groupMemberBatchSize, func(chunk []string) (MemberResponse, *Response, error) {
+		return g.RemoveIdentitiesWithOptions(group, "DEVICE", chunk, options...)
 	})
 }
 
 // AddServices adds services to the given Group
 func (g *GroupsService) AddServices(group Group, services ...string) (MemberResponse, *Response, error) {
-	return perSlice(services, 10, func(chunk []string) (MemberResponse, *Response, error) {
-		return g.AddIdentities(group, "SERVICE", chunk...)
+	return g.AddServicesWithOptions(group, services)
+}
+
+// AddServicesWithOptions is AddServices with request options
+func (g *GroupsService) AddServicesWithOptions(group Group, services []string, options ...OptionFunc) (MemberResponse, *Response, error) {
+	return perSlice(services, groupMemberBatchSize, func(chunk []string) (MemberResponse, *Response, error) {
+		return g.AddIdentitiesWithOptions(group, "SERVICE", chunk, options...)
 	})
 }
 
 // RemoveServices removes services from the given Group
 func (g *GroupsService) RemoveServices(group Group, services ...string) (MemberResponse, *Response, error) {
-	return perSlice(services, 10, func(chunk []string) (MemberResponse, *Response, error) {
-		return g.RemoveIdentities(group, "SERVICE", chunk...)
+	return g.RemoveServicesWithOptions(group, services)
+}
+
+// RemoveServicesWithOptions is RemoveServices with request options
+func (g *GroupsService) RemoveServicesWithOptions(group Group, services []string, options ...OptionFunc) (MemberResponse, *Response, error) {
+	return perSlice(services, groupMemberBatchSize, func(chunk []string) (MemberResponse, *Response, error) {
+		return g.RemoveIdentitiesWithOptions(group, "SERVICE", chunk, options...)
 	})
 }
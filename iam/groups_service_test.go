@@ -1,11 +1,16 @@
 package iam
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -253,6 +258,12 @@ func TestAddMembers(t *testing.T) {
 	assert.Len(t, assignedTotal, 28)
 	assert.Nil(t, ok)
 	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = client.Groups.AddMembersWithOptions(group, users, WithContext(ctx))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, assignedTotal, 28)
 }
 
 func TestRemoveMembers(t *testing.T) {
@@ -664,3 +675,166 @@ func TestRemoveServicesAndDevices(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Nil(t, ok)
 }
+
+func TestAddMembersWithResults(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	groupID := "dbf1d779-ab9f-4c27-b4aa-ea75f9efbbc0"
+	badUser := "bad-user"
+	flakyUser := "flaky-user"
+	forbiddenUser := "forbidden-user"
+	flaky := 0
+	var assigned []string
+	requests := 0
+
+	muxIDM.HandleFunc("/authorize/identity/Group/"+groupID+"/$add-members", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var addRequest groupRequest
+		if err := json.NewDecoder(r.Body).Decode(&addRequest); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		refs := addRequest.Parameter[0].References
+		if len(refs) > groupMemberBatchSize {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, ref := range refs {
+			switch ref.Reference {
+			case badUser:
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = io.WriteString(w, `{"resourceType":"OperationOutcome","issue":[{"severity":"error","code":"invalid"}]}`)
+				return
+			case forbiddenUser:
+				w.WriteHeader(http.StatusForbidden)
+				return
+			case flakyUser:
+				if flaky++; flaky == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+			}
+		}
+		for _, ref := range refs {
+			assigned = append(assigned, ref.Reference)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	var users []string
+	for i := 0; i < 14; i++ {
+		users = append(users, fmt.Sprintf("%s%02d", "f5fe538f-c3b5-4454-8774-cd3789f59b", i))
+	}
+	users = append(users, badUser)
+
+	opts := MemberBatchOptions{BatchSize: 50, Retries: 1, RetryDelay: time.Millisecond}
+	results, resp, err := client.Groups.AddMembersWithResults(Group{ID: groupID}, users, opts)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotNil(t, resp)
+	assert.Len(t, results, 15)
+	assert.Equal(t, []string{badUser}, results.Failed())
+	assert.Len(t, assigned, 14)
+	// 1 ok batch of 10, failing batch of 5 is not retried but split into 5 single requests
+	assert.Equal(t, 7, requests)
+
+	// A transient error is retried
+	requests = 0
+	results, _, err = client.Groups.AddMembersWithResults(Group{ID: groupID}, []string{users[0], flakyUser}, opts)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Empty(t, results.Failed())
+	assert.Equal(t, 2, requests)
+
+	// A forbidden batch is not split up
+	requests = 0
+	results, _, err = client.Groups.AddMembersWithResults(Group{ID: groupID}, []string{users[0], forbiddenUser}, opts)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, results.Failed(), 2)
+	assert.Equal(t, 1, requests)
+
+	// Waiting for a retry stops when the context is done
+	requests = 0
+	flaky = 0
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	slow := MemberBatchOptions{Retries: 1, RetryDelay: time.Minute}
+	start := time.Now()
+	results, _, err = client.Groups.AddMembersWithResults(Group{ID: groupID}, []string{flakyUser, users[0]}, slow, WithContext(ctx))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Less(t, time.Since(start), 10*time.Second)
+	assert.Equal(t, 1, requests)
+	assert.Len(t, results.Failed(), 2)
+	assert.True(t, errors.Is(results[flakyUser], context.DeadlineExceeded))
+}
+
+func TestGetMembers(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	groupID := "1eec7b01-1417-4546-9c5e-088dea0a9e8b"
+	pages := [][]string{
+		{"7dbfe5fc-1320-4bc6-92a7-2be5d7f07cac", "5620b687-7f67-4222-b7c2-91ff312b3066"},
+		{"41c79d7f-c078-4288-8f6d-459292858f00"},
+	}
+	muxIDM.HandleFunc("/security/users", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, groupID, r.URL.Query().Get("groupId"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("pageNumber"))
+		if page < 1 || page > len(pages) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var users []string
+		for _, id := range pages[page-1] {
+			users = append(users, `{"userUUID": "`+id+`"}`)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{
+			"exchange": {
+				"users": [`+strings.Join(users, ",")+`],
+				"nextPageExists": `+strconv.FormatBool(page < len(pages))+`
+			},
+			"responseCode": "200",
+			"responseMessage": "Success"
+		}`)
+	})
+	muxIDM.HandleFunc("/authorize/identity/User", func(w http.ResponseWriter, r *http.Request) {
+		userID := r.URL.Query().Get("userId")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{
+			"total": 1,
+			"entry": [{
+				"id": "`+userID+`",
+				"loginId": "user-`+userID[:4]+`",
+				"emailAddress": "`+userID[:4]+`@example.com",
+				"name": {"given": "Test", "family": "User"},
+				"accountStatus": {"disabled": false}
+			}]
+		}`)
+	})
+
+	count, _, err := client.Groups.CountMembers(groupID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, 3, count)
+
+	members, _, err := client.Groups.GetMembers(groupID)
+	if !assert.Nil(t, err) || !assert.Len(t, members, 3) {
+		return
+	}
+	assert.Equal(t, "41c79d7f-c078-4288-8f6d-459292858f00", members[2].ID)
+	assert.Equal(t, "user-7dbf", members[0].LoginID)
+	assert.Equal(t, []TelecomEntry{{System: "email", Value: "7dbf@example.com"}}, members[0].Telecom)
+	assert.True(t, members[0].Loaded)
+}
@@ -0,0 +1,314 @@
+// Package iamtest provides a mock HSDP IAM and IDM server for testing code which
+// uses the iam package, without having to duplicate token and introspect fixtures.
+package iamtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/philips-software/go-hsdp-api/iam"
+)
+
+// Defaults of a Server
+const (
+	DefaultClientID     = "TestClient"
+	DefaultClientSecret = "Secret"
+	DefaultAccessToken  = "44d20214-7879-4e35-923d-f9d4e01c9746"
+	DefaultRefreshToken = "31f1a449-ef8e-4bfc-a227-4f2353fde547"
+	DefaultUserID       = "e7fecbb2-af8c-47c9-a662-5b046e048bc5"
+	DefaultOrgID        = "48a0183d-a588-41c2-9979-737d15e9e860"
+	DefaultScope        = "auth_iam_introspect mail openid profile cn"
+)
+
+// Server is a pair of httptest servers mocking the IAM and IDM endpoints.
+// Additional handlers can be registered on MuxIAM and MuxIDM
+type Server struct {
+	IAM    *httptest.Server
+	IDM    *httptest.Server
+	MuxIAM *http.ServeMux
+	MuxIDM *http.ServeMux
+
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int64
+	Scope        string
+
+	mu         sync.Mutex
+	introspect iam.IntrospectResponse
+	users      map[string]iam.User
+	groups     map[string]iam.Group
+	members    map[string][]string
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithAccessToken sets the access token issued by the token endpoint
+func WithAccessToken(token string) Option {
+	return func(s *Server) {
+		s.AccessToken = token
+	}
+}
+
+// WithOrganization adds an organization with the given permissions to the introspect response.
+// The first organization added becomes the managing organization
+func WithOrganization(orgID string, permissions ...string) Option {
+	return func(s *Server) {
+		s.addOrganization(orgID, permissions)
+	}
+}
+
+// WithIntrospectResponse replaces the introspect response
+func WithIntrospectResponse(response iam.IntrospectResponse) Option {
+	return func(s *Server) {
+		s.introspect = response
+	}
+}
+
+// NewServer starts a mock IAM and IDM server. Call Close when done
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		MuxIAM:       http.NewServeMux(),
+		MuxIDM:       http.NewServeMux(),
+		AccessToken:  DefaultAccessToken,
+		RefreshToken: DefaultRefreshToken,
+		ExpiresIn:    1799,
+		Scope:        DefaultScope,
+		users:        make(map[string]iam.User),
+		groups:       make(map[string]iam.Group),
+		members:      make(map[string][]string),
+	}
+	s.introspect = iam.IntrospectResponse{
+		Active:       true,
+		Username:     "testuser",
+		Sub:          DefaultUserID,
+		ClientID:     DefaultClientID,
+		TokenType:    "Bearer",
+		IdentityType: "user",
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if len(s.introspect.Organizations.OrganizationList) == 0 {
+		s.addOrganization(DefaultOrgID, nil)
+	}
+	s.IAM = httptest.NewServer(s.MuxIAM)
+	s.IDM = httptest.NewServer(s.MuxIDM)
+
+	s.MuxIAM.HandleFunc("/authorize/oauth2/token", s.handleToken)
+	s.MuxIAM.HandleFunc("/authorize/oauth2/introspect", s.handleIntrospect)
+	s.MuxIAM.HandleFunc("/authorize/oauth2/revoke", s.handleRevoke)
+	s.MuxIDM.HandleFunc("/authorize/identity/User", s.handleUser)
+	s.MuxIDM.HandleFunc("/authorize/identity/Group", s.handleGroups)
+	s.MuxIDM.HandleFunc("/authorize/identity/Group/", s.handleGroup)
+	s.MuxIDM.HandleFunc("/security/users", s.handleUserSearch)
+	return s
+}
+
+// Close shuts down the servers
+func (s *Server) Close() {
+	s.IAM.Close()
+	s.IDM.Close()
+}
+
+// Config returns a client configuration pointing to the mock servers
+func (s *Server) Config() *iam.Config {
+	return &iam.Config{
+		OAuth2ClientID: DefaultClientID,
+		OAuth2Secret:   DefaultClientSecret,
+		IAMURL:         s.IAM.URL,
+		IDMURL:         s.IDM.URL,
+	}
+}
+
+// NewClient returns a client which is logged in to the mock servers
+func (s *Server) NewClient() (*iam.Client, error) {
+	client, err := iam.NewClient(nil, s.Config())
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Login("testuser", "password"); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// AddUser registers a user which can be looked up by ID or login ID
+func (s *Server) AddUser(user iam.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[user.ID] = user
+}
+
+// AddGroup registers a group with the given member user IDs
+func (s *Server) AddGroup(group iam.Group, memberIDs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.groups[group.ID] = group
+	s.members[group.ID] = append(s.members[group.ID], memberIDs...)
+}
+
+func (s *Server) addOrganization(orgID string, permissions []string) {
+	if s.introspect.Organizations.ManagingOrganization == "" {
+		s.introspect.Organizations.ManagingOrganization = orgID
+	}
+	s.introspect.Organizations.OrganizationList = append(s.introspect.Organizations.OrganizationList, iam.IntrospectOrganization{
+		OrganizationID:       orgID,
+		Permissions:          permissions,
+		EffectivePermissions: permissions,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	return r.Header.Get("Authorization") == "Bearer "+s.AccessToken
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if clientID, secret, ok := r.BasicAuth(); ok && (clientID != DefaultClientID || secret != DefaultClientSecret) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"scope":         s.Scope,
+		"access_token":  s.AccessToken,
+		"refresh_token": s.RefreshToken,
+		"expires_in":    s.ExpiresIn,
+		"token_type":    "Bearer",
+	})
+}
+
+func (s *Server) handleIntrospect(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if r.Form.Get("token") != s.AccessToken {
+		writeJSON(w, http.StatusOK, map[string]bool{"active": false})
+		return
+	}
+	s.mu.Lock()
+	response := s.introspect
+	s.mu.Unlock()
+	response.Scope = s.Scope
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleRevoke(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleUser(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	userID := r.URL.Query().Get("userId")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := []iam.User{}
+	for _, user := range s.users {
+		if user.ID == userID || user.LoginID == userID {
+			entry = append(entry, user)
+			break
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"total": len(entry),
+		"entry": entry,
+	})
+}
+
+func (s *Server) handleUserSearch(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var userIDs []string
+	if groupID := r.URL.Query().Get("groupId"); groupID != "" {
+		userIDs = s.members[groupID]
+	} else {
+		for id := range s.users {
+			userIDs = append(userIDs, id)
+		}
+	}
+	users := make([]map[string]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		users = append(users, map[string]string{"userUUID": id})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"exchange": map[string]interface{}{
+			"users":          users,
+			"nextPageExists": false,
+		},
+		"responseCode":    "200",
+		"responseMessage": "Success",
+	})
+}
+
+func (s *Server) handleGroups(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	name := r.URL.Query().Get("name")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := []map[string]interface{}{}
+	for _, group := range s.groups {
+		if name != "" && group.Name != name {
+			continue
+		}
+		entry = append(entry, map[string]interface{}{
+			"resource": iam.GroupResource{
+				ID:               group.ID,
+				ResourceType:     "Group",
+				GroupName:        group.Name,
+				OrgID:            group.ManagingOrganization,
+				GroupDescription: group.Description,
+			},
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"total": len(entry),
+		"entry": entry,
+	})
+}
+
+func (s *Server) handleGroup(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/authorize/identity/Group/")
+	s.mu.Lock()
+	group, ok := s.groups[id]
+	s.mu.Unlock()
+	if !ok || r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, group)
+}
@@ -0,0 +1,75 @@
+package iamtest_test
+
+import (
+	"testing"
+
+	"github.com/philips-software/go-hsdp-api/iam"
+	"github.com/philips-software/go-hsdp-api/iam/iamtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer(t *testing.T) {
+	orgID := "c57b2625-eda3-4b27-a8e6-86f0a0e76afc"
+	server := iamtest.NewServer(iamtest.WithOrganization(orgID, "USER.READ", "GROUP.WRITE"))
+	defer server.Close()
+
+	userID := "f5fe538f-c3b5-4454-8774-cd3789f59b9f"
+	groupID := "dbf1d779-ab9f-4c27-b4aa-ea75f9efbbc0"
+	server.AddUser(iam.User{
+		ID:                   userID,
+		LoginID:              "ron",
+		EmailAddress:         "ron@example.com",
+		Name:                 iam.Name{Given: "Ron", Family: "Swanson"},
+		ManagingOrganization: orgID,
+	})
+	server.AddGroup(iam.Group{ID: groupID, Name: "Parks", ManagingOrganization: orgID}, userID)
+
+	client, err := server.NewClient()
+	if !assert.Nil(t, err) {
+		return
+	}
+	token, err := client.Token()
+	assert.Nil(t, err)
+	assert.Equal(t, iamtest.DefaultAccessToken, token)
+
+	introspect, _, err := client.Introspect()
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.True(t, introspect.Active)
+	assert.Equal(t, orgID, introspect.Organizations.ManagingOrganization)
+	assert.True(t, introspect.HasPermissionInOrg(orgID, "GROUP.WRITE"))
+
+	user, _, err := client.Users.GetUserByID(userID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "ron", user.LoginID)
+	uuid, _, err := client.Users.GetUserIDByLoginID("ron")
+	assert.Nil(t, err)
+	assert.Equal(t, userID, uuid)
+
+	group, _, err := client.Groups.GetGroupByID(groupID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "Parks", group.Name)
+	groups, _, err := client.Groups.GetGroups(&iam.GetGroupOptions{Name: iam.String("Parks")})
+	if assert.Nil(t, err) && assert.Len(t, *groups, 1) {
+		assert.Equal(t, groupID, (*groups)[0].ID)
+	}
+	members, _, err := client.Groups.GetMembers(groupID)
+	if assert.Nil(t, err) && assert.Len(t, members, 1) {
+		assert.Equal(t, userID, members[0].ID)
+	}
+
+	_, err = client.RevokeToken(token)
+	assert.Nil(t, err)
+
+	cfg := server.Config()
+	cfg.OAuth2Secret = "wrong"
+	badClient, err := iam.NewClient(nil, cfg)
+	if assert.Nil(t, err) {
+		assert.NotNil(t, badClient.Login("testuser", "password"))
+	}
+}
@@ -1,16 +1,28 @@
 package iam
 
 import (
+	"bytes"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 const (
 	introspectAPIVersion = "4"
 )
 
+// IntrospectOrganization describes the permissions of the introspected identity in an organization
+type IntrospectOrganization struct {
+	OrganizationID       string   `json:"organizationId"`
+	Permissions          []string `json:"permissions"`
+	EffectivePermissions []string `json:"effectivePermissions"`
+	OrganizationName     string   `json:"organizationName"`
+	Groups               []string `json:"groups"`
+	Roles                []string `json:"roles"`
+}
+
 // IntrospectResponse contains details of the introspect on a profile
 type IntrospectResponse struct {
 	Active        bool   `json:"active"`
@@ -20,53 +32,77 @@ type IntrospectResponse struct {
 	Sub           string `json:"sub"`
 	ISS           string `json:"iss"`
 	Organizations struct {
-		ManagingOrganization string `json:"managingOrganization"`
-		OrganizationList     []struct {
-			OrganizationID       string   `json:"organizationId"`
-			Permissions          []string `json:"permissions"`
-			EffectivePermissions []string `json:"effectivePermissions"`
-			OrganizationName     string   `json:"organizationName"`
-			Groups               []string `json:"groups"`
-			Roles                []string `json:"roles"`
-		} `json:"organizationList"`
+		ManagingOrganization string                   `json:"managingOrganization"`
+		OrganizationList     []IntrospectOrganization `json:"organizationList"`
 	} `json:"organizations"`
 	ClientID     string `json:"client_id"`
 	TokenType    string `json:"token_type"`
 	IdentityType string `json:"identity_type"`
 }
 
-func WithOrgContext(organizationId string) OptionFunc {
-	return func(req *http.Request) error {
-		err := req.ParseForm()
-		if err != nil {
-			return err
+// Organization returns the entry of the given organization, or nil if the identity has no access to it
+func (i *IntrospectResponse) Organization(orgID string) *IntrospectOrganization {
+	for n := range i.Organizations.OrganizationList {
+		if i.Organizations.OrganizationList[n].OrganizationID == orgID {
+			return &i.Organizations.OrganizationList[n]
 		}
-		token := req.Form.Get("token")
-		form := url.Values{}
-		form.Add("token", token)
-		form.Add("org_ctx", organizationId)
-		req.Body = io.NopCloser(strings.NewReader(form.Encode()))
-		req.ContentLength = int64(len(form.Encode()))
+	}
+	return nil
+}
+
+// EffectivePermissions returns the effective permissions in the given organization
+func (i *IntrospectResponse) EffectivePermissions(orgID string) []string {
+	org := i.Organization(orgID)
+	if org == nil {
 		return nil
 	}
+	return org.EffectivePermissions
+}
+
+// HasPermissionInOrg returns true if permission is one of the effective permissions in the given organization
+func (i *IntrospectResponse) HasPermissionInOrg(orgID, permission string) bool {
+	for _, p := range i.EffectivePermissions(orgID) {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// OrgIDsWithPermission returns the IDs of the organizations in which permission is effective
+func (i *IntrospectResponse) OrgIDsWithPermission(permission string) []string {
+	var orgIDs []string
+	for _, org := range i.Organizations.OrganizationList {
+		if i.HasPermissionInOrg(org.OrganizationID, permission) {
+			orgIDs = append(orgIDs, org.OrganizationID)
+		}
+	}
+	return orgIDs
 }
 
 // Introspect introspects the current logged-in user
 func (c *Client) Introspect(opts ...OptionFunc) (*IntrospectResponse, *Response, error) {
+	start := time.Now()
+	val, resp, cached, err := c.introspect(opts...)
+	c.metrics().ObserveIntrospect(time.Since(start), cached, err)
+	return val, resp, err
+}
+
+func (c *Client) introspect(opts ...OptionFunc) (*IntrospectResponse, *Response, bool, error) {
 	var val IntrospectResponse
 
 	req, err := c.newRequest(IAM, "POST", "authorize/oauth2/introspect", nil, nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, false, err
 	}
 	form := url.Values{}
-	form.Add("token", c.token)
+	form.Add("token", c.TokenInfo().AccessToken)
 	req.Body = io.NopCloser(strings.NewReader(form.Encode()))
 	req.ContentLength = int64(len(form.Encode()))
 	if !c.HasOAuth2Credentials() {
-		return nil, nil, ErrMissingOAuth2Credentials
+		return nil, nil, false, ErrMissingOAuth2Credentials
 	}
-	req.SetBasicAuth(c.config.OAuth2ClientID, c.config.OAuth2Secret)
+	req.SetBasicAuth(c.config.OAuth2ClientID, c.oauth2Secret())
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Api-Version", introspectAPIVersion)
 
@@ -76,11 +112,30 @@ func (c *Client) Introspect(opts ...OptionFunc) (*IntrospectResponse, *Response,
 			continue
 		}
 		if err := fn(req); err != nil {
-			return nil, nil, err
+			return nil, nil, false, err
 		}
 	}
 
-	resp, err := c.do(req, &val)
+	cache := c.getIntrospectCache()
+	if cache == nil {
+		resp, err := c.do(req, &val)
+		return &val, resp, false, err
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	key := introspectCacheKey(string(body))
+	if !bypassIntrospectCache(req) {
+		if cached, resp, ok := cache.get(key); ok {
+			return cached, resp, true, nil
+		}
+	}
 
-	return &val, resp, err
+	resp, err := c.do(req, &val)
+	if err == nil && resp != nil && resp.StatusCode == http.StatusOK {
+		cache.put(key, val, resp)
+	}
+	return &val, resp, false, err
 }
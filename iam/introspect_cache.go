@@ -0,0 +1,138 @@
+package iam
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultIntrospectCacheTTL        = 60 * time.Second
+	defaultIntrospectCacheMaxEntries = 1000
+)
+
+// IntrospectCacheOptions configures caching of Introspect results
+type IntrospectCacheOptions struct {
+	// TTL is the maximum time a result is served from the cache. Results are never
+	// cached beyond the expiry time of the token itself. Defaults to 60 seconds
+	TTL time.Duration
+	// MaxEntries limits the number of cached results. The least recently used
+	// entry is evicted when the limit is reached. Defaults to 1000
+	MaxEntries int
+}
+
+type introspectCacheBypassKey struct{}
+
+type introspectCacheEntry struct {
+	key       string
+	val       IntrospectResponse
+	resp      *Response
+	expiresAt time.Time
+}
+
+type introspectCache struct {
+	sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+// EnableIntrospectCache caches successful Introspect results keyed by a hash of
+// the token and org context. Calling it again replaces the cache
+func (c *Client) EnableIntrospectCache(opts IntrospectCacheOptions) {
+	if opts.TTL <= 0 {
+		opts.TTL = defaultIntrospectCacheTTL
+	}
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = defaultIntrospectCacheMaxEntries
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.introspectCache = &introspectCache{
+		ttl:        opts.TTL,
+		maxEntries: opts.MaxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// DisableIntrospectCache disables and clears the Introspect cache
+func (c *Client) DisableIntrospectCache() {
+	c.Lock()
+	defer c.Unlock()
+	c.introspectCache = nil
+}
+
+// WithIntrospectCacheBypass forces Introspect to call IAM. The fresh result
+// still replaces the cached one
+func WithIntrospectCacheBypass() OptionFunc {
+	return func(req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), introspectCacheBypassKey{}, true))
+		return nil
+	}
+}
+
+func (c *Client) getIntrospectCache() *introspectCache {
+	c.Lock()
+	defer c.Unlock()
+	return c.introspectCache
+}
+
+func introspectCacheKey(form string) string {
+	sum := sha256.Sum256([]byte(form))
+	return hex.EncodeToString(sum[:])
+}
+
+func bypassIntrospectCache(req *http.Request) bool {
+	bypass, _ := req.Context().Value(introspectCacheBypassKey{}).(bool)
+	return bypass
+}
+
+func (ic *introspectCache) get(key string) (*IntrospectResponse, *Response, bool) {
+	ic.Lock()
+	defer ic.Unlock()
+	elem, ok := ic.entries[key]
+	if !ok {
+		return nil, nil, false
+	}
+	entry := elem.Value.(*introspectCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		ic.order.Remove(elem)
+		delete(ic.entries, key)
+		return nil, nil, false
+	}
+	ic.order.MoveToFront(elem)
+	val := entry.val
+	return &val, entry.resp, true
+}
+
+func (ic *introspectCache) put(key string, val IntrospectResponse, resp *Response) {
+	expiresAt := time.Now().Add(ic.ttl)
+	if val.Expires > 0 {
+		if tokenExpiry := time.Unix(val.Expires, 0); tokenExpiry.Before(expiresAt) {
+			expiresAt = tokenExpiry
+		}
+	}
+	ic.Lock()
+	defer ic.Unlock()
+	if elem, ok := ic.entries[key]; ok {
+		ic.order.Remove(elem)
+		delete(ic.entries, key)
+	}
+	for ic.order.Len() >= ic.maxEntries {
+		oldest := ic.order.Back()
+		ic.order.Remove(oldest)
+		delete(ic.entries, oldest.Value.(*introspectCacheEntry).key)
+	}
+	ic.entries[key] = ic.order.PushFront(&introspectCacheEntry{
+		key:       key,
+		val:       val,
+		resp:      resp,
+		expiresAt: expiresAt,
+	})
+}
@@ -3,7 +3,9 @@ package iam
 import (
 	"io"
 	"net/http"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -183,6 +185,14 @@ func TestIntrospect(t *testing.T) {
 	assert.False(t, client.HasPermissions("bogus", "SERVICE.SCOPE"))
 	assert.Equal(t, 6, len(introspectResponse.Organizations.OrganizationList))
 	assert.Equal(t, "SecondOrg", introspectResponse.Organizations.OrganizationList[5].OrganizationName)
+
+	assert.True(t, introspectResponse.HasPermissionInOrg(orgID, "ORGANIZATION.MFA"))
+	assert.False(t, introspectResponse.HasPermissionInOrg(orgID, "BOGUS.PERMISSION"))
+	assert.False(t, introspectResponse.HasPermissionInOrg("bogus", "ORGANIZATION.MFA"))
+	assert.Contains(t, introspectResponse.EffectivePermissions(orgID), "SERVICE.SCOPE")
+	assert.Nil(t, introspectResponse.EffectivePermissions("bogus"))
+	assert.Equal(t, []string{orgID}, introspectResponse.OrgIDsWithPermission("ORGANIZATION.MFA"))
+	assert.Nil(t, introspectResponse.OrgIDsWithPermission("BOGUS.PERMISSION"))
 }
 
 func TestIntrospectWithOrgContext(t *testing.T) {
@@ -269,3 +279,63 @@ func TestIntrospectWithOrgContext(t *testing.T) {
 	assert.Equal(t, 1, len(introspectResponse.Organizations.OrganizationList))
 	assert.False(t, client.HasPermissions("bogus", "SERVICE.SCOPE"))
 }
+
+func TestIntrospectCache(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	orgID := "46323bb4-ebba-4387-a339-252b5aa0755f"
+	expires := time.Now().Add(time.Hour).Unix()
+	calls := 0
+
+	muxIAM.HandleFunc("/authorize/oauth2/introspect", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{
+			"active": true,
+			"username": "foo.bar@philips.com",
+			"exp": `+strconv.FormatInt(expires, 10)+`,
+			"organizations": {
+				"managingOrganization": "`+r.Form.Get("org_ctx")+`"
+			}
+		}`)
+	})
+
+	client.EnableIntrospectCache(IntrospectCacheOptions{MaxEntries: 1})
+	defer client.DisableIntrospectCache()
+
+	resp1, _, err := client.Introspect()
+	if !assert.Nil(t, err) {
+		return
+	}
+	resp2, _, err := client.Introspect()
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, resp1.Username, resp2.Username)
+
+	_, _, _ = client.Introspect(WithIntrospectCacheBypass())
+	assert.Equal(t, 2, calls)
+
+	// Org context is part of the key and evicts the only entry
+	orgResp, _, err := client.Introspect(WithOrgContext(orgID))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, orgID, orgResp.Organizations.ManagingOrganization)
+	_, _, _ = client.Introspect(WithOrgContext(orgID))
+	assert.Equal(t, 3, calls)
+	_, _, _ = client.Introspect()
+	assert.Equal(t, 4, calls)
+
+	client.DisableIntrospectCache()
+	_, _, _ = client.Introspect()
+	assert.Equal(t, 5, calls)
+}
@@ -0,0 +1,186 @@
+package iam
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	jwksRefreshInterval    = 24 * time.Hour
+	jwksMinRefreshInterval = 1 * time.Minute
+)
+
+// JWK is a JSON Web Key used by IAM to sign tokens
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+}
+
+// JWKS is the JSON Web Key Set published by IAM
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// Key returns the key with the given key ID
+func (s JWKS) Key(kid string) (*JWK, bool) {
+	for i := range s.Keys {
+		if s.Keys[i].KeyID == kid {
+			return &s.Keys[i], true
+		}
+	}
+	return nil, false
+}
+
+// PublicKey returns the RSA public key described by the JWK
+func (k JWK) PublicKey() (*rsa.PublicKey, error) {
+	if k.KeyType != "RSA" {
+		return nil, fmt.Errorf("unsupported key type '%s'", k.KeyType)
+	}
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+// AccessTokenClaims holds the claims of an access token verified by VerifyAccessToken
+type AccessTokenClaims struct {
+	Subject   string
+	Issuer    string
+	ClientID  string
+	Audience  []string
+	Scopes    []string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// JWKS retrieves the JSON Web Key Set IAM uses to sign access tokens
+func (c *Client) JWKS(options ...OptionFunc) (*JWKS, *Response, error) {
+	req, err := c.newRequest(IAM, "GET", "authorize/oauth2/jwks", nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Api-Version", loginAPIVersion)
+
+	var jwks JWKS
+
+	resp, err := c.do(req, &jwks)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &jwks, resp, nil
+}
+
+// VerifyAccessToken validates the signature and expiry of a JWT access token
+// locally using the IAM JWKS, which is cached and refreshed when a token signed
+// with an unknown key is encountered. When audience is not empty the token must
+// be issued for it. This saves resource servers an Introspect call per request
+func (c *Client) VerifyAccessToken(accessToken, audience string, options ...OptionFunc) (*AccessTokenClaims, error) {
+	parser := &jwt.Parser{ValidMethods: []string{"RS256", "RS384", "RS512"}}
+	claims := jwt.MapClaims{}
+	_, err := parser.ParseWithClaims(accessToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := c.signingKey(kid, options)
+		if err != nil {
+			return nil, err
+		}
+		return key.PublicKey()
+	})
+	if ve, ok := err.(*jwt.ValidationError); ok {
+		switch {
+		case ve.Errors&jwt.ValidationErrorExpired != 0:
+			err = ErrTokenExpired
+		case ve.Inner != nil:
+			err = ve.Inner
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("VerifyAccessToken: %w", err)
+	}
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, fmt.Errorf("VerifyAccessToken: %w", ErrTokenExpired)
+	}
+	if audience != "" && !claims.VerifyAudience(audience, true) {
+		return nil, fmt.Errorf("VerifyAccessToken: %w", ErrInvalidAudience)
+	}
+	return accessTokenClaims(claims), nil
+}
+
+// signingKey returns the JWK with the given key ID, fetching the JWKS when it is
+// not cached yet, too old or does not contain the key
+func (c *Client) signingKey(kid string, options []OptionFunc) (*JWK, error) {
+	c.Lock()
+	jwks, fetchedAt := c.jwks, c.jwksFetchedAt
+	c.Unlock()
+	if jwks != nil && time.Since(fetchedAt) < jwksRefreshInterval {
+		if key, ok := jwks.Key(kid); ok {
+			return key, nil
+		}
+		if time.Since(fetchedAt) < jwksMinRefreshInterval {
+			return nil, ErrUnknownSigningKey
+		}
+	}
+	jwks, _, err := c.JWKS(options...)
+	if err != nil {
+		return nil, err
+	}
+	c.Lock()
+	c.jwks, c.jwksFetchedAt = jwks, time.Now()
+	c.Unlock()
+	if key, ok := jwks.Key(kid); ok {
+		return key, nil
+	}
+	return nil, ErrUnknownSigningKey
+}
+
+func accessTokenClaims(claims jwt.MapClaims) *AccessTokenClaims {
+	result := &AccessTokenClaims{}
+	result.Subject, _ = claims["sub"].(string)
+	result.Issuer, _ = claims["iss"].(string)
+	result.ClientID, _ = claims["client_id"].(string)
+	switch aud := claims["aud"].(type) {
+	case string:
+		result.Audience = []string{aud}
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok {
+				result.Audience = append(result.Audience, s)
+			}
+		}
+	}
+	switch scope := claims["scope"].(type) {
+	case string:
+		result.Scopes = strings.Fields(scope)
+	case []interface{}:
+		for _, s := range scope {
+			if str, ok := s.(string); ok {
+				result.Scopes = append(result.Scopes, str)
+			}
+		}
+	}
+	if iat, ok := claims["iat"].(float64); ok {
+		result.IssuedAt = time.Unix(int64(iat), 0)
+	}
+	if exp, ok := claims["exp"].(float64); ok {
+		result.ExpiresAt = time.Unix(int64(exp), 0)
+	}
+	return result
+}
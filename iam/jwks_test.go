@@ -0,0 +1,83 @@
+package iam
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyAccessToken(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if !assert.Nil(t, err) {
+		return
+	}
+	kid := "iam-signing-key-1"
+	fetches := 0
+
+	muxIAM.HandleFunc("/authorize/oauth2/jwks", func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(JWKS{Keys: []JWK{{
+			KeyType:   "RSA",
+			KeyID:     kid,
+			Use:       "sig",
+			Algorithm: "RS256",
+			N:         base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+
+	sign := func(kid string, claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		assert.Nil(t, err)
+		return signed
+	}
+	now := time.Now()
+
+	accessToken := sign(kid, jwt.MapClaims{
+		"sub":       "f5fe538f-c3b5-4454-8774-cd3789f59b9f",
+		"client_id": "TestClient",
+		"aud":       []string{"TestClient", "tdr"},
+		"scope":     "mail tdr.contract",
+		"iat":       now.Unix(),
+		"exp":       now.Add(time.Hour).Unix(),
+	})
+	claims, err := client.VerifyAccessToken(accessToken, "tdr")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "f5fe538f-c3b5-4454-8774-cd3789f59b9f", claims.Subject)
+	assert.Equal(t, "TestClient", claims.ClientID)
+	assert.Equal(t, []string{"mail", "tdr.contract"}, claims.Scopes)
+	assert.Equal(t, now.Add(time.Hour).Unix(), claims.ExpiresAt.Unix())
+
+	_, err = client.VerifyAccessToken(accessToken, "cdr")
+	assert.ErrorIs(t, err, ErrInvalidAudience)
+	assert.Equal(t, 1, fetches)
+
+	expired := sign(kid, jwt.MapClaims{"sub": "foo", "exp": now.Add(-time.Minute).Unix()})
+	_, err = client.VerifyAccessToken(expired, "")
+	assert.ErrorIs(t, err, ErrTokenExpired)
+
+	unknown := sign("rotated-key", jwt.MapClaims{"sub": "foo", "exp": now.Add(time.Hour).Unix()})
+	_, err = client.VerifyAccessToken(unknown, "")
+	assert.ErrorIs(t, err, ErrUnknownSigningKey)
+	assert.Equal(t, 1, fetches, "recently fetched JWKS should not be refetched")
+
+	_, err = client.VerifyAccessToken(accessToken[:len(accessToken)-4]+"AAAA", "")
+	assert.NotNil(t, err)
+}
@@ -11,6 +11,21 @@ import (
 
 // CodeLogin uses the authorization_code grant type to fetch tokens
 func (c *Client) CodeLogin(code string, redirectURI string) error {
+	return c.codeLogin(code, redirectURI, "")
+}
+
+// CodeLoginWithPKCE exchanges an authorization code obtained through an
+// AuthorizationURL with a PKCE challenge. codeVerifier must be the Verifier of
+// the PKCE used to build the URL. When no OAuth2 secret is configured the
+// client is treated as a public client and its ID is sent in the form instead
+func (c *Client) CodeLoginWithPKCE(code, redirectURI, codeVerifier string) error {
+	if codeVerifier == "" {
+		return ErrMissingCodeVerifier
+	}
+	return c.codeLogin(code, redirectURI, codeVerifier)
+}
+
+func (c *Client) codeLogin(code, redirectURI, codeVerifier string) error {
 	// Authorize
 	u := *c.baseIAMURL
 	u.Opaque = c.baseIAMURL.Path + "authorize/oauth2/token"
@@ -30,8 +45,15 @@ func (c *Client) CodeLogin(code string, redirectURI string) error {
 	if len(redirectURI) > 0 {
 		form.Add("redirect_uri", redirectURI)
 	}
+	if codeVerifier != "" {
+		form.Add("code_verifier", codeVerifier)
+	}
+	if codeVerifier != "" && c.oauth2Secret() == "" {
+		form.Add("client_id", c.config.OAuth2ClientID)
+	} else {
+		req.SetBasicAuth(c.config.OAuth2ClientID, c.oauth2Secret())
+	}
 	body := form.Encode()
-	req.SetBasicAuth(c.config.OAuth2ClientID, c.config.OAuth2Secret)
 	req.Body = io.NopCloser(strings.NewReader(body))
 	req.ContentLength = int64(len(body))
 
@@ -74,12 +96,15 @@ func (c *Client) ServiceLogin(service Service) error {
 
 	req.Body = io.NopCloser(strings.NewReader(body))
 	req.ContentLength = int64(len(body))
+	c.tokenLock.Lock()
 	c.service = service // Save service so we can refresh later!
+	c.tokenLock.Unlock()
 
 	return c.doTokenRequest(req)
 }
 
-// Login logs in a user with `username` and `password`
+// Login logs in a user with `username` and `password`. When the user must provide
+// a second factor an *MFARequiredError is returned, see LoginWithOTP
 func (c *Client) Login(username, password string) error {
 	// Authorize
 	u := *c.baseIAMURL
@@ -102,10 +127,12 @@ func (c *Client) Login(username, password string) error {
 		scopes := strings.Join(c.config.Scopes, " ")
 		form.Add("scope", scopes)
 	}
-	req.SetBasicAuth(c.config.OAuth2ClientID, c.config.OAuth2Secret)
+	req.SetBasicAuth(c.config.OAuth2ClientID, c.oauth2Secret())
 	req.Body = io.NopCloser(strings.NewReader(form.Encode()))
 	req.ContentLength = int64(len(form.Encode()))
+	c.tokenLock.Lock()
 	c.service = Service{} // reset
+	c.tokenLock.Unlock()
 
 	return c.doTokenRequest(req)
 }
@@ -132,7 +159,7 @@ func (c *Client) ClientCredentialsLogin() error {
 		scopes := strings.Join(c.config.Scopes, " ")
 		form.Add("scope", scopes)
 	}
-	req.SetBasicAuth(c.config.OAuth2ClientID, c.config.OAuth2Secret)
+	req.SetBasicAuth(c.config.OAuth2ClientID, c.oauth2Secret())
 	req.Body = io.NopCloser(strings.NewReader(form.Encode()))
 	req.ContentLength = int64(len(form.Encode()))
 
@@ -141,12 +168,49 @@ func (c *Client) ClientCredentialsLogin() error {
 
 // RevokeAccessToken revokes the access and refresh token
 func (c *Client) RevokeAccessToken() error {
-	return c.revokeToken(c.token)
+	return c.revokeToken(c.TokenInfo().AccessToken)
 }
 
 // RevokeRefreshAccessToken revokes the access and refresh token
 func (c *Client) RevokeRefreshAccessToken() error {
-	return c.revokeToken(c.refreshToken)
+	return c.revokeToken(c.RefreshToken())
+}
+
+// RevokeToken revokes the given access or refresh token, e.g. a token of a user
+// whose credentials were compromised. The tokens of the client are not changed
+func (c *Client) RevokeToken(token string, options ...OptionFunc) (*Response, error) {
+	if token == "" {
+		return nil, ErrMissingToken
+	}
+	if !c.HasOAuth2Credentials() {
+		return nil, ErrMissingOAuth2Credentials
+	}
+	req, err := c.newRequest(IAM, "POST", "authorize/oauth2/revoke", nil, options)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{}
+	form.Add("token", token)
+	req.SetBasicAuth(c.config.OAuth2ClientID, c.oauth2Secret())
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Api-Version", loginAPIVersion)
+	req.Body = io.NopCloser(strings.NewReader(form.Encode()))
+	req.ContentLength = int64(len(form.Encode()))
+
+	var revokeResponse interface{}
+
+	resp, err := c.do(req, &revokeResponse)
+	if err == io.EOF { // Empty body on success
+		err = nil
+	}
+	if err != nil {
+		return resp, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp, fmt.Errorf("revoke failed: %d", resp.StatusCode)
+	}
+	return resp, nil
 }
 
 type endSessionOptions struct {
@@ -156,7 +220,7 @@ type endSessionOptions struct {
 // EndSession ends the current active session
 func (c *Client) EndSession() error {
 	req, err := c.newRequest(IAM, "GET", "authorize/oauth2/endsession", &endSessionOptions{
-		IDTokenHint: &c.idToken,
+		IDTokenHint: String(c.IDToken()),
 	}, nil)
 	if err != nil {
 		return err
@@ -166,7 +230,7 @@ func (c *Client) EndSession() error {
 		scopes := strings.Join(c.config.Scopes, " ")
 		form.Add("scope", scopes)
 	}
-	req.SetBasicAuth(c.config.OAuth2ClientID, c.config.OAuth2Secret)
+	req.SetBasicAuth(c.config.OAuth2ClientID, c.oauth2Secret())
 	req.Body = io.NopCloser(strings.NewReader(form.Encode()))
 	req.ContentLength = int64(len(form.Encode()))
 
@@ -184,7 +248,7 @@ func (c *Client) revokeToken(token string) error {
 		scopes := strings.Join(c.config.Scopes, " ")
 		form.Add("scope", scopes)
 	}
-	req.SetBasicAuth(c.config.OAuth2ClientID, c.config.OAuth2Secret)
+	req.SetBasicAuth(c.config.OAuth2ClientID, c.oauth2Secret())
 	req.Body = io.NopCloser(strings.NewReader(form.Encode()))
 	req.ContentLength = int64(len(form.Encode()))
 
@@ -197,17 +261,28 @@ func (c *Client) doTokenRequest(req *http.Request) error {
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Api-Version", loginAPIVersion)
+	grantType := c.tokenRequestGrantType(req)
 	resp, err := c.do(req, &tokenResponse)
 
 	if err != nil {
+		if challenge := mfaChallengeFromResponse(resp); challenge != nil {
+			return &MFARequiredError{Challenge: *challenge}
+		}
+		c.metrics().IncLoginFailures(grantType)
+		if isInvalidClient(resp) {
+			return &invalidClientError{err: err}
+		}
 		return err
 	}
 	if resp.StatusCode != http.StatusOK {
+		c.metrics().IncLoginFailures(grantType)
 		return fmt.Errorf("login failed: %d", resp.StatusCode)
 	}
 	if tokenResponse.AccessToken == "" {
+		c.metrics().IncLoginFailures(grantType)
 		return ErrNotAuthorized
 	}
+	c.tokenLock.Lock()
 	c.tokenType = OAuthToken
 	c.token = tokenResponse.AccessToken
 	if tokenResponse.RefreshToken != "" { // Doesn't always contain new refresh token
@@ -218,5 +293,7 @@ func (c *Client) doTokenRequest(req *http.Request) error {
 	}
 	c.expiresAt = time.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
 	c.scopes = strings.Split(tokenResponse.Scope, " ")
+	c.tokenLock.Unlock()
+	c.saveTokens()
 	return nil
 }
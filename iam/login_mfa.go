@@ -0,0 +1,105 @@
+package iam
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	grantTypeMFAOTP  = "urn:ietf:params:oauth:grant-type:mfa-otp"
+	errorMFARequired = "mfa_required"
+)
+
+// MFAChallenge is issued by IAM when a login requires a second factor
+type MFAChallenge struct {
+	Token       string `json:"mfa_token"`
+	Type        string `json:"challenge_type,omitempty"`
+	Description string `json:"error_description,omitempty"`
+}
+
+// MFARequiredError is returned by Login when the user must complete the login
+// with a one-time password. Pass the Challenge to LoginWithOTP to finish the login
+type MFARequiredError struct {
+	Challenge MFAChallenge
+}
+
+func (e *MFARequiredError) Error() string {
+	if e.Challenge.Description != "" {
+		return ErrMFARequired.Error() + ": " + e.Challenge.Description
+	}
+	return ErrMFARequired.Error()
+}
+
+func (e *MFARequiredError) Is(target error) bool { return target == ErrMFARequired }
+
+// LoginWithOTP completes a login which returned an MFARequiredError using the
+// one-time password of the second factor
+func (c *Client) LoginWithOTP(challenge MFAChallenge, otp string) error {
+	if challenge.Token == "" {
+		return ErrMissingToken
+	}
+	if otp == "" {
+		return ErrMissingOTP
+	}
+	u := *c.baseIAMURL
+	u.Opaque = c.baseIAMURL.Path + "authorize/oauth2/token"
+
+	req := &http.Request{
+		Method:     "POST",
+		URL:        &u,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Host:       u.Host,
+	}
+	form := url.Values{}
+	form.Add("grant_type", grantTypeMFAOTP)
+	form.Add("mfa_token", challenge.Token)
+	form.Add("otp", otp)
+	if len(c.config.Scopes) > 0 {
+		scopes := strings.Join(c.config.Scopes, " ")
+		form.Add("scope", scopes)
+	}
+	req.SetBasicAuth(c.config.OAuth2ClientID, c.oauth2Secret())
+	req.Body = io.NopCloser(strings.NewReader(form.Encode()))
+	req.ContentLength = int64(len(form.Encode()))
+
+	return c.doTokenRequest(req)
+}
+
+// mfaChallengeFromResponse returns the MFA challenge of a failed token request, if any
+func mfaChallengeFromResponse(resp *Response) *MFAChallenge {
+	if resp == nil || resp.Response == nil || resp.Body == nil {
+		return nil
+	}
+	if resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusBadRequest && resp.StatusCode != http.StatusForbidden {
+		return nil
+	}
+	var errResponse struct {
+		MFAChallenge
+		Error string `json:"error"`
+	}
+	if err := decodePreservingBody(resp, &errResponse); err != nil {
+		return nil
+	}
+	if errResponse.Error != errorMFARequired || errResponse.Token == "" {
+		return nil
+	}
+	return &errResponse.MFAChallenge
+}
+
+// decodePreservingBody decodes the JSON body of resp into v and restores the
+// body so it can be inspected again
+func decodePreservingBody(resp *Response, v interface{}) error {
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return json.Unmarshal(data, v)
+}
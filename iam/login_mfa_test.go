@@ -0,0 +1,93 @@
+package iam
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginWithOTP(t *testing.T) {
+	muxIAM = http.NewServeMux()
+	serverIAM = httptest.NewServer(muxIAM)
+	muxIDM = http.NewServeMux()
+	serverIDM = httptest.NewServer(muxIDM)
+
+	defer serverIAM.Close()
+	defer serverIDM.Close()
+
+	mfaToken := "d3b07384-d9a7-4d4a-9c1f-6f2b5e3a2c11"
+	accessToken := "44d20214-7879-4e35-923d-f9d4e01c9746"
+
+	muxIAM.HandleFunc("/authorize/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Nil(t, r.ParseForm()) {
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Form.Get("grant_type") {
+		case "password":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{
+				"error": "mfa_required",
+				"error_description": "Multi-factor authentication required",
+				"mfa_token": "`+mfaToken+`",
+				"challenge_type": "otp"
+			}`)
+		case grantTypeMFAOTP:
+			if r.Form.Get("mfa_token") != mfaToken || r.Form.Get("otp") != "123456" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = io.WriteString(w, `{"error": "invalid_grant"}`)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{
+				"scope": "mail",
+				"access_token": "`+accessToken+`",
+				"refresh_token": "31f1a449-ef8e-4bfc-a227-4f2353fde547",
+				"expires_in": 1799,
+				"token_type": "Bearer"
+			}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	client, err := NewClient(nil, &Config{
+		OAuth2ClientID: "TestClient",
+		OAuth2Secret:   "Secret",
+		IAMURL:         serverIAM.URL,
+		IDMURL:         serverIDM.URL,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	err = client.Login("username", "password")
+	if !assert.True(t, errors.Is(err, ErrMFARequired)) {
+		return
+	}
+	var mfaErr *MFARequiredError
+	if !assert.True(t, errors.As(err, &mfaErr)) {
+		return
+	}
+	assert.Equal(t, mfaToken, mfaErr.Challenge.Token)
+	assert.Equal(t, "otp", mfaErr.Challenge.Type)
+
+	assert.Equal(t, ErrMissingOTP, client.LoginWithOTP(mfaErr.Challenge, ""))
+	assert.Equal(t, ErrMissingToken, client.LoginWithOTP(MFAChallenge{}, "123456"))
+
+	err = client.LoginWithOTP(mfaErr.Challenge, "000000")
+	assert.NotNil(t, err)
+	assert.False(t, errors.Is(err, ErrMFARequired))
+
+	err = client.LoginWithOTP(mfaErr.Challenge, "123456")
+	if !assert.Nil(t, err) {
+		return
+	}
+	token, err := client.Token()
+	assert.Nil(t, err)
+	assert.Equal(t, accessToken, token)
+}
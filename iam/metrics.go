@@ -0,0 +1,75 @@
+package iam
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Metrics receives measurements of the IAM operations of a client so they can
+// be exported to a monitoring system such as Prometheus. Implementations must
+// be safe for concurrent use
+type Metrics interface {
+	// ObserveRequest is called after each API request. The endpoint is the
+	// request path with resource IDs replaced by {id}. The statusCode is 0
+	// when no response was received
+	ObserveRequest(endpoint, method string, statusCode int, duration time.Duration)
+	// ObserveTokenRefresh is called after each token refresh
+	ObserveTokenRefresh(duration time.Duration, err error)
+	// ObserveIntrospect is called after each introspect. Cached is true
+	// when the result was served from the introspect cache
+	ObserveIntrospect(duration time.Duration, cached bool, err error)
+	// IncLoginFailures is called when a token request fails
+	IncLoginFailures(grantType string)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveRequest(string, string, int, time.Duration) {}
+func (noopMetrics) ObserveTokenRefresh(time.Duration, error)          {}
+func (noopMetrics) ObserveIntrospect(time.Duration, bool, error)      {}
+func (noopMetrics) IncLoginFailures(string)                           {}
+
+func (c *Client) metrics() Metrics {
+	if c.config == nil || c.config.Metrics == nil {
+		return noopMetrics{}
+	}
+	return c.config.Metrics
+}
+
+var metricsIDSegment = regexp.MustCompile(`^[0-9a-fA-F-]{16,}$`)
+
+// metricsEndpoint returns the path of u with resource IDs replaced so
+// the number of distinct endpoints stays bounded
+func metricsEndpoint(u *url.URL) string {
+	path := u.Opaque
+	if path == "" {
+		path = u.Path
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if metricsIDSegment.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// tokenRequestGrantType returns the grant_type of a token request. The body is
+// only inspected when Metrics are configured
+func (c *Client) tokenRequestGrantType(req *http.Request) string {
+	if c.config == nil || c.config.Metrics == nil || req.Body == nil {
+		return ""
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return ""
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	form, _ := url.ParseQuery(string(body))
+	return form.Get("grant_type")
+}
@@ -0,0 +1,104 @@
+package iam
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingMetrics struct {
+	sync.Mutex
+	requests      []string
+	refreshes     int
+	introspects   int
+	cached        int
+	loginFailures []string
+}
+
+func (m *recordingMetrics) ObserveRequest(endpoint, method string, statusCode int, _ time.Duration) {
+	m.Lock()
+	defer m.Unlock()
+	m.requests = append(m.requests, method+" "+endpoint)
+}
+
+func (m *recordingMetrics) ObserveTokenRefresh(_ time.Duration, err error) {
+	m.Lock()
+	defer m.Unlock()
+	if err == nil {
+		m.refreshes++
+	}
+}
+
+func (m *recordingMetrics) ObserveIntrospect(_ time.Duration, cached bool, err error) {
+	m.Lock()
+	defer m.Unlock()
+	m.introspects++
+	if cached {
+		m.cached++
+	}
+}
+
+func (m *recordingMetrics) IncLoginFailures(grantType string) {
+	m.Lock()
+	defer m.Unlock()
+	m.loginFailures = append(m.loginFailures, grantType)
+}
+
+func TestMetrics(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	muxIAM.HandleFunc("/authorize/oauth2/introspect", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"active": true, "username": "foo"}`)
+	})
+
+	metrics := &recordingMetrics{}
+	c, err := NewClient(nil, &Config{
+		OAuth2ClientID: "TestClient",
+		OAuth2Secret:   "Secret",
+		IAMURL:         serverIAM.URL,
+		IDMURL:         serverIDM.URL,
+		Metrics:        metrics,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Nil(t, c.Login("username", "password"))
+	assert.Nil(t, c.TokenRefresh())
+	_, _, err = c.Introspect()
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, metrics.refreshes)
+	assert.Equal(t, 1, metrics.introspects)
+	assert.Equal(t, 0, metrics.cached)
+	assert.Contains(t, metrics.requests, "POST /authorize/oauth2/token")
+	assert.Contains(t, metrics.requests, "POST /authorize/oauth2/introspect")
+	assert.Empty(t, metrics.loginFailures)
+
+	failing, err := NewClient(nil, &Config{
+		OAuth2ClientID: "TestClient",
+		OAuth2Secret:   "Secret",
+		IAMURL:         serverIDM.URL,
+		IDMURL:         serverIDM.URL,
+		Metrics:        metrics,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotNil(t, failing.Login("username", "password"))
+	assert.Equal(t, []string{"password"}, metrics.loginFailures)
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	u, _ := url.Parse("https://idm.example.com/authorize/identity/User/f5fe538f-c3b5-4454-8774-cd3789f59b9f/$mfa")
+	assert.Equal(t, "/authorize/identity/User/{id}/$mfa", metricsEndpoint(u))
+	u = &url.URL{Opaque: "/authorize/identity/Group/dbf1d779ab9f4c27b4aaea75f9efbbc0"}
+	assert.Equal(t, "/authorize/identity/Group/{id}", metricsEndpoint(u))
+}
@@ -20,8 +20,8 @@ type MFAPoliciesService struct {
 }
 
 // GetMFAPolicyByID retrieves a MFAPolicy by ID
-func (p *MFAPoliciesService) GetMFAPolicyByID(MFAPolicyID string) (*MFAPolicy, *Response, error) {
-	req, err := p.client.newRequest(IDM, "GET", scimBasePath+"MFAPolicies/"+MFAPolicyID, nil, nil)
+func (p *MFAPoliciesService) GetMFAPolicyByID(MFAPolicyID string, options ...OptionFunc) (*MFAPolicy, *Response, error) {
+	req, err := p.client.newRequest(IDM, "GET", scimBasePath+"MFAPolicies/"+MFAPolicyID, nil, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -41,14 +41,16 @@ func (p *MFAPoliciesService) GetMFAPolicyByID(MFAPolicyID string) (*MFAPolicy, *
 }
 
 // UpdateMFAPolicy updates a MFAPolicy
-func (p *MFAPoliciesService) UpdateMFAPolicy(policy *MFAPolicy) (*MFAPolicy, *Response, error) {
-
-	req, _ := p.client.newRequest(IDM, "PUT", scimBasePath+"MFAPolicies/"+policy.ID, policy, nil)
-	req.Header.Set("api-version", mfaPoliciesAPIVersion)
-	req.Header.Set("Content-Type", "application/scim+json")
+func (p *MFAPoliciesService) UpdateMFAPolicy(policy *MFAPolicy, options ...OptionFunc) (*MFAPolicy, *Response, error) {
 	if policy.Meta == nil {
 		return nil, nil, ErrMissingEtagInformation
 	}
+	req, err := p.client.newRequest(IDM, "PUT", scimBasePath+"MFAPolicies/"+policy.ID, policy, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", mfaPoliciesAPIVersion)
+	req.Header.Set("Content-Type", "application/scim+json")
 	req.Header.Set("If-Match", policy.Meta.Version)
 
 	var updatedMFAPolicy MFAPolicy
@@ -62,14 +64,14 @@ func (p *MFAPoliciesService) UpdateMFAPolicy(policy *MFAPolicy) (*MFAPolicy, *Re
 }
 
 // CreateMFAPolicy creates a MFAPolicy
-func (p *MFAPoliciesService) CreateMFAPolicy(policy MFAPolicy) (*MFAPolicy, *Response, error) {
+func (p *MFAPoliciesService) CreateMFAPolicy(policy MFAPolicy, options ...OptionFunc) (*MFAPolicy, *Response, error) {
 	policy.Schemas = append(policy.Schemas, "urn:ietf:params:scim:schemas:core:philips:hsdp:2.0:MFAPolicy")
 	policy.SetActive(true)
 
 	if err := p.validate.Struct(policy); err != nil {
 		return nil, nil, err
 	}
-	req, _ := p.client.newRequest(IDM, "POST", scimBasePath+"MFAPolicies", &policy, nil)
+	req, _ := p.client.newRequest(IDM, "POST", scimBasePath+"MFAPolicies", &policy, options)
 	req.Header.Set("api-version", mfaPoliciesAPIVersion)
 	req.Header.Set("Content-Type", "application/scim+json")
 	req.Header.Set("Accept", "application/scim+json")
@@ -84,8 +86,8 @@ func (p *MFAPoliciesService) CreateMFAPolicy(policy MFAPolicy) (*MFAPolicy, *Res
 }
 
 // DeleteMFAPolicy deletes the given MFAPolicy
-func (p *MFAPoliciesService) DeleteMFAPolicy(policy MFAPolicy) (bool, *Response, error) {
-	req, err := p.client.newRequest(IDM, "DELETE", scimBasePath+"MFAPolicies/"+policy.ID, nil, nil)
+func (p *MFAPoliciesService) DeleteMFAPolicy(policy MFAPolicy, options ...OptionFunc) (bool, *Response, error) {
+	req, err := p.client.newRequest(IDM, "DELETE", scimBasePath+"MFAPolicies/"+policy.ID, nil, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -16,6 +16,7 @@ func TestCreateMFAPolicy(t *testing.T) {
 	policyID := "400f1adb-bba6-4f52-8d04-f78ecd3833da"
 	userID := "ad8a7c6a-231e-452c-8e89-9863c1005982"
 	orgID := "b23e7a82-f3b4-40b9-aaef-8111cb788ef9"
+	var sentTypes []string
 	muxIDM.HandleFunc("/authorize/scim/v2/MFAPolicies", func(w http.ResponseWriter, r *http.Request) {
 		if ok := assert.Equal(t, "POST", r.Method); !ok {
 			w.WriteHeader(http.StatusMethodNotAllowed)
@@ -31,6 +32,7 @@ func TestCreateMFAPolicy(t *testing.T) {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+		sentTypes = newPolicy.Types
 		newID := policyID
 		newType := "User"
 		newValue := userID
@@ -102,6 +104,25 @@ func TestCreateMFAPolicy(t *testing.T) {
 		assert.Equal(t, "Organization", newPolicy.Resource.Type)
 		assert.True(t, *newPolicy.Active)
 	}
+
+	var serverPolicy MFAPolicy
+	serverPolicy.SetResourceOrganization(orgID)
+	serverPolicy.SetType(MFAPolicyTypeServerOTP)
+	serverPolicy.SetActive(true)
+	_, resp, err = client.MFAPolicies.CreateMFAPolicy(serverPolicy)
+	if !assert.Nil(t, err) {
+		return
+	}
+	if ok := assert.NotNil(t, resp); ok {
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	assert.Equal(t, []string{"SERVER_OTP"}, sentTypes)
+
+	var badPolicy MFAPolicy
+	badPolicy.SetResourceOrganization(orgID)
+	badPolicy.SetType("HARD_OTP")
+	_, _, err = client.MFAPolicies.CreateMFAPolicy(badPolicy)
+	assert.NotNil(t, err)
 }
 
 func TestGetMFAPolicyByID(t *testing.T) {
@@ -1,5 +1,20 @@
 package iam
 
+// MFA policy types
+const (
+	// MFAPolicyTypeSoftOTP requires a time based OTP generated by an authenticator app
+	MFAPolicyTypeSoftOTP = "SOFT_OTP"
+	// MFAPolicyTypeServerOTP requires an OTP sent by IAM via email or SMS
+	MFAPolicyTypeServerOTP = "SERVER_OTP"
+)
+
+// MFA policy resource types
+const (
+	MFAPolicyResourceUser         = "User"
+	MFAPolicyResourceOrganization = "Organization"
+)
+
+// MFAPolicy represents an IAM multi-factor authentication policy
 type MFAPolicy struct {
 	Schemas     []string          `json:"schemas" validate:"min=1"`
 	ID          string            `json:"id,omitempty" validate:"omitempty,min=1,max=256"`
@@ -7,7 +22,7 @@ type MFAPolicy struct {
 	Description string            `json:"description,omitempty"`
 	Resource    MFAPolicyResource `json:"resource,omitempty"`
 	ExternalID  string            `json:"externalId,omitempty"`
-	Types       []string          `json:"types" validate:"min=1"`
+	Types       []string          `json:"types" validate:"min=1,dive,oneof=SOFT_OTP SERVER_OTP"`
 	Active      *bool             `json:"active,omitempty"`
 	CreatedBy   *struct {
 		Value string `json:"value,omitempty"`
@@ -21,7 +36,7 @@ type MFAPolicy struct {
 }
 
 type MFAPolicyResource struct {
-	Type  string `json:"type" validate:"required"`
+	Type  string `json:"type" validate:"required,oneof=User Organization"`
 	Value string `json:"value" validate:"required"`
 	Ref   string `json:"$ref,omitempty"`
 }
@@ -34,20 +49,24 @@ type MFAPolicyMeta struct {
 	Version      string `json:"version,omitempty"`
 }
 
+// SetActive sets the active state of the policy
 func (p *MFAPolicy) SetActive(val bool) {
 	p.Active = &val
 }
 
+// SetResourceUser scopes the policy to the user with the given UUID
 func (p *MFAPolicy) SetResourceUser(uuid string) {
-	p.Resource.Type = "User"
+	p.Resource.Type = MFAPolicyResourceUser
 	p.Resource.Value = uuid
 }
 
+// SetResourceOrganization scopes the policy to the organization with the given UUID
 func (p *MFAPolicy) SetResourceOrganization(uuid string) {
-	p.Resource.Type = "Organization"
+	p.Resource.Type = MFAPolicyResourceOrganization
 	p.Resource.Value = uuid
 }
 
+// SetType sets the single OTP type of the policy, e.g. MFAPolicyTypeSoftOTP
 func (p *MFAPolicy) SetType(val string) {
 	p.Types = append([]string{}, val)
 }
@@ -0,0 +1,190 @@
+package iam
+
+import (
+	"sync"
+)
+
+const (
+	defaultOrganizationWalkerConcurrency = 4
+	organizationChildrenPageSize         = 100
+)
+
+// OrganizationWalkerOptions configures an OrganizationWalker
+type OrganizationWalkerOptions struct {
+	// Concurrency is the maximum number of parallel requests issued
+	// while walking the hierarchy. Defaults to 4
+	Concurrency int
+	// MaxDepth limits the number of levels Descendants descends. Zero means no limit
+	MaxDepth int
+}
+
+// OrganizationWalker traverses the organization hierarchy. Organizations and
+// child lists are cached for the lifetime of the walker, so create a new walker
+// when changes to the hierarchy should be picked up
+type OrganizationWalker struct {
+	service *OrganizationsService
+	opts    OrganizationWalkerOptions
+	options []OptionFunc
+
+	mu       sync.Mutex
+	orgs     map[string]*Organization
+	children map[string][]string
+}
+
+// NewWalker returns an OrganizationWalker. The options are passed on to every request
+func (o *OrganizationsService) NewWalker(opts OrganizationWalkerOptions, options ...OptionFunc) *OrganizationWalker {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultOrganizationWalkerConcurrency
+	}
+	return &OrganizationWalker{
+		service:  o,
+		opts:     opts,
+		options:  options,
+		orgs:     make(map[string]*Organization),
+		children: make(map[string][]string),
+	}
+}
+
+// GetChildOrganizationIDs returns the IDs of the direct children of an organization
+func (o *OrganizationsService) GetChildOrganizationIDs(parentID string, options ...OptionFunc) ([]string, *Response, error) {
+	var ids []string
+	var resp *Response
+	startIndex := 1
+	count := organizationChildrenPageSize
+
+	for {
+		opt := FilterParentEq(parentID)
+		opt.StartIndex = &startIndex
+		opt.Count = &count
+		req, err := o.client.newRequest(IDM, "GET", "authorize/scim/v2/Organizations", opt, options)
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Header.Set("api-version", organizationAPIVersion)
+
+		var bundleResponse struct {
+			TotalResults int `json:"totalResults"`
+			Resources    []struct {
+				ID string `json:"id"`
+			}
+		}
+		resp, err = o.client.do(req, &bundleResponse)
+		if err != nil {
+			return nil, resp, err
+		}
+		for _, r := range bundleResponse.Resources {
+			ids = append(ids, r.ID)
+		}
+		if len(bundleResponse.Resources) == 0 || len(ids) >= bundleResponse.TotalResults {
+			break
+		}
+		startIndex += len(bundleResponse.Resources)
+	}
+	return ids, resp, nil
+}
+
+// Organization returns the organization with the given ID
+func (w *OrganizationWalker) Organization(id string) (*Organization, error) {
+	w.mu.Lock()
+	org, ok := w.orgs[id]
+	w.mu.Unlock()
+	if ok {
+		return org, nil
+	}
+	org, _, err := w.service.GetOrganizationByID(id, w.options...)
+	if err != nil {
+		return nil, err
+	}
+	if org.ID != id {
+		return nil, ErrNotFound
+	}
+	w.mu.Lock()
+	w.orgs[id] = org
+	w.mu.Unlock()
+	return org, nil
+}
+
+// Children returns the IDs of the direct children of an organization
+func (w *OrganizationWalker) Children(id string) ([]string, error) {
+	w.mu.Lock()
+	children, ok := w.children[id]
+	w.mu.Unlock()
+	if ok {
+		return children, nil
+	}
+	children, _, err := w.service.GetChildOrganizationIDs(id, w.options...)
+	if err != nil {
+		return nil, err
+	}
+	w.mu.Lock()
+	w.children[id] = children
+	w.mu.Unlock()
+	return children, nil
+}
+
+// Descendants returns the IDs of all organizations below the given organization.
+// Each level of the hierarchy is fetched in parallel. The result is ordered by level
+func (w *OrganizationWalker) Descendants(id string) ([]string, error) {
+	var descendants []string
+	seen := map[string]bool{id: true}
+	level := []string{id}
+
+	for depth := 0; len(level) > 0; depth++ {
+		if w.opts.MaxDepth > 0 && depth >= w.opts.MaxDepth {
+			break
+		}
+		results := make([][]string, len(level))
+		errs := make([]error, len(level))
+		sem := make(chan struct{}, w.opts.Concurrency)
+		var wg sync.WaitGroup
+		for i, parentID := range level {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i int, parentID string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				results[i], errs[i] = w.Children(parentID)
+			}(i, parentID)
+		}
+		wg.Wait()
+
+		var next []string
+		for i := range level {
+			if errs[i] != nil {
+				return nil, errs[i]
+			}
+			for _, child := range results[i] {
+				if seen[child] {
+					continue
+				}
+				seen[child] = true
+				next = append(next, child)
+			}
+		}
+		descendants = append(descendants, next...)
+		level = next
+	}
+	return descendants, nil
+}
+
+// PathToRoot returns the organizations from the given organization up to the
+// root organization by following the parent links. The first element is the
+// organization itself
+func (w *OrganizationWalker) PathToRoot(id string) ([]Organization, error) {
+	var path []Organization
+	seen := make(map[string]bool)
+
+	for id != "" {
+		if seen[id] {
+			return nil, ErrOrganizationCycle
+		}
+		seen[id] = true
+		org, err := w.Organization(id)
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, *org)
+		id = org.Parent.Value
+	}
+	return path, nil
+}
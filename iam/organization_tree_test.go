@@ -0,0 +1,102 @@
+package iam
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizationWalker(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	// root -> a, b; a -> c; c -> d
+	parents := map[string]string{
+		"root": "",
+		"a":    "root",
+		"b":    "root",
+		"c":    "a",
+		"d":    "c",
+	}
+	var mu sync.Mutex
+	calls := map[string]int{}
+
+	muxIDM.HandleFunc("/authorize/scim/v2/Organizations", func(w http.ResponseWriter, r *http.Request) {
+		filter := r.URL.Query().Get("filter")
+		parentID := strings.TrimSuffix(strings.TrimPrefix(filter, `parent.value eq "`), `"`)
+		mu.Lock()
+		calls["children:"+parentID]++
+		mu.Unlock()
+		var resources []map[string]string
+		for id, parent := range parents {
+			if parent == parentID && id != "root" {
+				resources = append(resources, map[string]string{"id": id})
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"totalResults": len(resources),
+			"Resources":    resources,
+		})
+	})
+	for id := range parents {
+		id := id
+		muxIDM.HandleFunc("/authorize/scim/v2/Organizations/"+id, func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			calls["org:"+id]++
+			mu.Unlock()
+			org := Organization{ID: id, Name: id, Parent: Attribute{Value: parents[id]}}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(org)
+		})
+	}
+
+	walker := client.Organizations.NewWalker(OrganizationWalkerOptions{Concurrency: 2})
+
+	children, err := walker.Children("root")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.ElementsMatch(t, []string{"a", "b"}, children)
+
+	descendants, err := walker.Descendants("root")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, descendants, 4)
+	assert.ElementsMatch(t, []string{"a", "b"}, descendants[:2])
+	assert.Equal(t, []string{"c", "d"}, descendants[2:])
+	assert.Equal(t, 1, calls["children:root"], "children should be cached")
+
+	limited, err := client.Organizations.NewWalker(OrganizationWalkerOptions{MaxDepth: 2}).Descendants("root")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, limited, 3)
+
+	path, err := walker.PathToRoot("d")
+	if !assert.Nil(t, err) {
+		return
+	}
+	if !assert.Len(t, path, 4) {
+		return
+	}
+	assert.Equal(t, "d", path[0].ID)
+	assert.Equal(t, "root", path[3].ID)
+
+	_, err = walker.PathToRoot("c")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, calls["org:c"], "organizations should be cached")
+
+	mu.Lock()
+	parents["root"] = "d"
+	mu.Unlock()
+	_, err = client.Organizations.NewWalker(OrganizationWalkerOptions{}).PathToRoot("d")
+	assert.ErrorIs(t, err, ErrOrganizationCycle)
+}
@@ -20,6 +20,8 @@ type GetOrganizationOptions struct {
 	Filter             *string `url:"filter,omitempty"`
 	Attributes         *string `url:"attributes,omitempty"`
 	ExcludedAttributes *string `url:"excludedAttributes,omitempty"`
+	StartIndex         *int    `url:"startIndex,omitempty"`
+	Count              *int    `url:"count,omitempty"`
 }
 
 type OrganizationStatus struct {
@@ -64,12 +66,12 @@ func FilterNameEq(name string) *GetOrganizationOptions {
 }
 
 // CreateOrganization creates a (sub) organization in IAM
-func (o *OrganizationsService) CreateOrganization(organization Organization) (*Organization, *Response, error) {
+func (o *OrganizationsService) CreateOrganization(organization Organization, options ...OptionFunc) (*Organization, *Response, error) {
 	organization.Schemas = []string{
 		"urn:ietf:params:scim:schemas:core:philips:hsdp:2.0:Organization",
 	}
 
-	req, err := o.client.newRequest(IDM, "POST", "authorize/scim/v2/Organizations", &organization, nil)
+	req, err := o.client.newRequest(IDM, "POST", "authorize/scim/v2/Organizations", &organization, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -89,8 +91,8 @@ func (o *OrganizationsService) CreateOrganization(organization Organization) (*O
 }
 
 // DeleteOrganization deletes the organization
-func (o *OrganizationsService) DeleteOrganization(org Organization) (bool, *Response, error) {
-	req, err := o.client.newRequest(IDM, "DELETE", "authorize/scim/v2/Organizations/"+org.ID, nil, nil)
+func (o *OrganizationsService) DeleteOrganization(org Organization, options ...OptionFunc) (bool, *Response, error) {
+	req, err := o.client.newRequest(IDM, "DELETE", "authorize/scim/v2/Organizations/"+org.ID, nil, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -108,8 +110,8 @@ func (o *OrganizationsService) DeleteOrganization(org Organization) (bool, *Resp
 }
 
 // UpdateOrganization updates the description of the organization.
-func (o *OrganizationsService) UpdateOrganization(org Organization) (*Organization, *Response, error) {
-	req, err := o.client.newRequest(IDM, "PUT", "authorize/scim/v2/Organizations/"+org.ID, &org, nil)
+func (o *OrganizationsService) UpdateOrganization(org Organization, options ...OptionFunc) (*Organization, *Response, error) {
+	req, err := o.client.newRequest(IDM, "PUT", "authorize/scim/v2/Organizations/"+org.ID, &org, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -128,10 +130,10 @@ func (o *OrganizationsService) UpdateOrganization(org Organization) (*Organizati
 }
 
 // GetOrganizationByID retrieves an organization by ID
-func (o *OrganizationsService) GetOrganizationByID(id string) (*Organization, *Response, error) {
+func (o *OrganizationsService) GetOrganizationByID(id string, options ...OptionFunc) (*Organization, *Response, error) {
 	var foundOrg Organization
 
-	req, err := o.client.newRequest(IDM, "GET", "authorize/scim/v2/Organizations/"+id, nil, nil)
+	req, err := o.client.newRequest(IDM, "GET", "authorize/scim/v2/Organizations/"+id, nil, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -166,12 +168,12 @@ func (o *OrganizationsService) GetOrganization(opt *GetOrganizationOptions, opti
 		return nil, resp, ErrNotFound
 	}
 
-	return o.GetOrganizationByID(bundleResponse.Resources[0].ID)
+	return o.GetOrganizationByID(bundleResponse.Resources[0].ID, options...)
 }
 
 // DeleteStatus returns the status of a delete operation on an organization
-func (o *OrganizationsService) DeleteStatus(id string) (*OrganizationStatus, *Response, error) {
-	req, err := o.client.newRequest(IDM, "GET", "authorize/scim/v2/Organizations/"+id+"/deleteStatus", nil, nil)
+func (o *OrganizationsService) DeleteStatus(id string, options ...OptionFunc) (*OrganizationStatus, *Response, error) {
+	req, err := o.client.newRequest(IDM, "GET", "authorize/scim/v2/Organizations/"+id+"/deleteStatus", nil, options)
 	if err != nil {
 		return nil, nil, err
 	}
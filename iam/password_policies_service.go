@@ -17,34 +17,39 @@ type PasswordPoliciesService struct {
 	validate *validator.Validate
 }
 
+// ChallengePolicy describes the knowledge based challenges a user must answer during password reset
 type ChallengePolicy struct {
 	DefaultQuestions     []string `json:"defaultQuestions"`
-	MinQuestionCount     int      `json:"minQuestionCount"`
-	MinAnswerCount       int      `json:"minAnswerCount"`
-	MaxIncorrectAttempts int      `json:"maxIncorrectAttempts"`
+	MinQuestionCount     int      `json:"minQuestionCount" validate:"min=0"`
+	MinAnswerCount       int      `json:"minAnswerCount" validate:"min=0"`
+	MaxIncorrectAttempts int      `json:"maxIncorrectAttempts" validate:"min=0"`
 }
 
+// PasswordComplexity describes the complexity rules a password must satisfy
+type PasswordComplexity struct {
+	MinLength       int `json:"minLength" validate:"min=0"`
+	MaxLength       int `json:"maxLength" validate:"omitempty,gtefield=MinLength"`
+	MinNumerics     int `json:"minNumerics" validate:"min=0"`
+	MinUpperCase    int `json:"minUpperCase" validate:"min=0"`
+	MinLowerCase    int `json:"minLowerCase" validate:"min=0"`
+	MinSpecialChars int `json:"minSpecialChars" validate:"min=0"`
+}
+
+// PasswordPolicy represents an IAM password policy of an organization
 type PasswordPolicy struct {
-	ID                   string `json:"id,omitempty"`
-	ManagingOrganization string `json:"managingOrganization"`
-	ExpiryPeriodInDays   int    `json:"expiryPeriodInDays"`
-	HistoryCount         int    `json:"historyCount"`
-	Complexity           struct {
-		MinLength       int `json:"minLength"`
-		MaxLength       int `json:"maxLength"`
-		MinNumerics     int `json:"minNumerics"`
-		MinUpperCase    int `json:"minUpperCase"`
-		MinLowerCase    int `json:"minLowerCase"`
-		MinSpecialChars int `json:"minSpecialChars"`
-	} `json:"complexity"`
-	ChallengesEnabled bool             `json:"challengesEnabled"`
-	ChallengePolicy   *ChallengePolicy `json:"challengePolicy,omitempty"`
-	Meta              *Meta            `json:"meta,omitempty"`
+	ID                   string             `json:"id,omitempty"`
+	ManagingOrganization string             `json:"managingOrganization" validate:"required"`
+	ExpiryPeriodInDays   int                `json:"expiryPeriodInDays" validate:"min=0"`
+	HistoryCount         int                `json:"historyCount" validate:"min=0"`
+	Complexity           PasswordComplexity `json:"complexity"`
+	ChallengesEnabled    bool               `json:"challengesEnabled"`
+	ChallengePolicy      *ChallengePolicy   `json:"challengePolicy,omitempty" validate:"required_if=ChallengesEnabled true"`
+	Meta                 *Meta              `json:"meta,omitempty"`
 }
 
 // GetPasswordPolicyByID retrieves a Password policy by ID
-func (p *PasswordPoliciesService) GetPasswordPolicyByID(id string) (*PasswordPolicy, *Response, error) {
-	req, err := p.client.newRequest(IDM, "GET", "authorize/identity/PasswordPolicy/"+id, nil, nil)
+func (p *PasswordPoliciesService) GetPasswordPolicyByID(id string, options ...OptionFunc) (*PasswordPolicy, *Response, error) {
+	req, err := p.client.newRequest(IDM, "GET", "authorize/identity/PasswordPolicy/"+id, nil, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -64,14 +69,19 @@ func (p *PasswordPoliciesService) GetPasswordPolicyByID(id string) (*PasswordPol
 }
 
 // UpdatePasswordPolicy updates a password policy
-func (p *PasswordPoliciesService) UpdatePasswordPolicy(policy PasswordPolicy) (*PasswordPolicy, *Response, error) {
-
-	req, _ := p.client.newRequest(IDM, "PUT", "authorize/identity/PasswordPolicy/"+policy.ID, policy, nil)
-	req.Header.Set("api-version", passwordPolicyAPIVersion)
-	req.Header.Set("Content-Type", "application/json")
+func (p *PasswordPoliciesService) UpdatePasswordPolicy(policy PasswordPolicy, options ...OptionFunc) (*PasswordPolicy, *Response, error) {
 	if policy.Meta == nil {
 		return nil, nil, ErrMissingEtagInformation
 	}
+	if err := p.validate.Struct(policy); err != nil {
+		return nil, nil, err
+	}
+	req, err := p.client.newRequest(IDM, "PUT", "authorize/identity/PasswordPolicy/"+policy.ID, policy, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", passwordPolicyAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("If-Match", policy.Meta.Version)
 
 	var updatedPolicy PasswordPolicy
@@ -85,11 +95,11 @@ func (p *PasswordPoliciesService) UpdatePasswordPolicy(policy PasswordPolicy) (*
 }
 
 // CreatePasswordPolicy creates a password policy
-func (p *PasswordPoliciesService) CreatePasswordPolicy(policy PasswordPolicy) (*PasswordPolicy, *Response, error) {
+func (p *PasswordPoliciesService) CreatePasswordPolicy(policy PasswordPolicy, options ...OptionFunc) (*PasswordPolicy, *Response, error) {
 	if err := p.validate.Struct(policy); err != nil {
 		return nil, nil, err
 	}
-	req, _ := p.client.newRequest(IDM, "POST", "authorize/identity/PasswordPolicy", &policy, nil)
+	req, _ := p.client.newRequest(IDM, "POST", "authorize/identity/PasswordPolicy", &policy, options)
 	req.Header.Set("api-version", passwordPolicyAPIVersion)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
@@ -104,8 +114,8 @@ func (p *PasswordPoliciesService) CreatePasswordPolicy(policy PasswordPolicy) (*
 }
 
 // DeletePasswordPolicy deletes the given password policy
-func (p *PasswordPoliciesService) DeletePasswordPolicy(policy PasswordPolicy) (bool, *Response, error) {
-	req, err := p.client.newRequest(IDM, "DELETE", "authorize/identity/PasswordPolicy/"+policy.ID, nil, nil)
+func (p *PasswordPoliciesService) DeletePasswordPolicy(policy PasswordPolicy, options ...OptionFunc) (bool, *Response, error) {
+	req, err := p.client.newRequest(IDM, "DELETE", "authorize/identity/PasswordPolicy/"+policy.ID, nil, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -126,7 +136,7 @@ type GetPasswordPolicyOptions struct {
 	OrganizationID *string `url:"organizationId,omitempty"`
 }
 
-// GetPasswordPolicies looks up clients based on GetClientsOptions
+// GetPasswordPolicies looks up password policies based on GetPasswordPolicyOptions
 func (p *PasswordPoliciesService) GetPasswordPolicies(opt *GetPasswordPolicyOptions, options ...OptionFunc) (*[]PasswordPolicy, *Response, error) {
 	req, err := p.client.newRequest(IDM, "GET", "authorize/identity/PasswordPolicy", opt, options)
 	if err != nil {
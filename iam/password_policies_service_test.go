@@ -155,6 +155,19 @@ func TestPasswordPolicyCrud(t *testing.T) {
 		return
 	}
 
+	invalidPolicy := *foundPolicy
+	invalidPolicy.Complexity = PasswordComplexity{MinLength: 12, MaxLength: 8}
+	_, _, err = client.PasswordPolicies.UpdatePasswordPolicy(invalidPolicy)
+	assert.NotNil(t, err)
+	invalidPolicy = *foundPolicy
+	invalidPolicy.ChallengesEnabled = true
+	invalidPolicy.ChallengePolicy = nil
+	_, _, err = client.PasswordPolicies.UpdatePasswordPolicy(invalidPolicy)
+	assert.NotNil(t, err)
+	invalidPolicy.Meta = nil
+	_, _, err = client.PasswordPolicies.UpdatePasswordPolicy(invalidPolicy)
+	assert.Equal(t, ErrMissingEtagInformation, err)
+
 	ok, resp, err := client.PasswordPolicies.DeletePasswordPolicy(*foundPolicy)
 	if !assert.NotNil(t, resp) {
 		return
@@ -0,0 +1,142 @@
+package iam
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	permissionCatalogTTL = 1 * time.Hour
+)
+
+// PermissionCatalog is a snapshot of all permissions known to IAM
+type PermissionCatalog struct {
+	Permissions []Permission `json:"permissions"`
+	FetchedAt   time.Time    `json:"fetchedAt"`
+}
+
+type permissionCatalogCache struct {
+	sync.Mutex
+	catalog *PermissionCatalog
+}
+
+// Names returns the sorted permission names in the catalog
+func (c PermissionCatalog) Names() []string {
+	names := make([]string, 0, len(c.Permissions))
+	for _, p := range c.Permissions {
+		names = append(names, p.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Categories returns the sorted permission names of the catalog grouped by category
+func (c PermissionCatalog) Categories() map[string][]string {
+	categories := make(map[string][]string)
+	for _, p := range c.Permissions {
+		categories[p.Category] = append(categories[p.Category], p.Name)
+	}
+	for _, names := range categories {
+		sort.Strings(names)
+	}
+	return categories
+}
+
+// Contains returns true if the permission exists in the catalog
+func (c PermissionCatalog) Contains(name string) bool {
+	for _, p := range c.Permissions {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Unknown returns the names which do not exist in the catalog, e.g. to validate role definitions
+func (c PermissionCatalog) Unknown(names []string) []string {
+	known := make(map[string]bool, len(c.Permissions))
+	for _, p := range c.Permissions {
+		known[p.Name] = true
+	}
+	var unknown []string
+	for _, name := range names {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
+// Save writes the catalog as JSON so it can be loaded later with LoadPermissionCatalog
+func (c PermissionCatalog) Save(w io.Writer) error {
+	return json.NewEncoder(w).Encode(c)
+}
+
+// LoadPermissionCatalog reads a catalog snapshot written by Save
+func LoadPermissionCatalog(r io.Reader) (*PermissionCatalog, error) {
+	var catalog PermissionCatalog
+	if err := json.NewDecoder(r).Decode(&catalog); err != nil {
+		return nil, err
+	}
+	return &catalog, nil
+}
+
+// GetAllPermissions retrieves all pages of permissions matching GetPermissionOptions
+func (p *PermissionsService) GetAllPermissions(opt *GetPermissionOptions, options ...OptionFunc) ([]Permission, *Response, error) {
+	search := GetPermissionOptions{}
+	if opt != nil {
+		search = *opt
+	}
+	count := defaultIteratorPageSize
+	if search.Count != nil {
+		count = *search.Count
+	}
+	search.Count = &count
+	var permissions, last []Permission
+	for page := 1; ; page++ {
+		current := page
+		search.Page = &current
+		entries, resp, err := p.GetPermissions(&search, options...)
+		if err != nil {
+			return permissions, resp, err
+		}
+		// IAM may return fewer permissions than requested, so only an empty page,
+		// or the same page again when _page is ignored, ends the listing
+		if len(*entries) == 0 || (len(last) > 0 && (*entries)[0].ID == last[0].ID) {
+			return permissions, resp, nil
+		}
+		last = *entries
+		permissions = append(permissions, *entries...)
+	}
+}
+
+// Catalog returns a snapshot of all permissions. The snapshot is cached and
+// retrieved again from IAM once it is older than an hour. The returned
+// *Response is nil when the cached snapshot is used
+func (p *PermissionsService) Catalog(options ...OptionFunc) (*PermissionCatalog, *Response, error) {
+	p.cache.Lock()
+	defer p.cache.Unlock()
+	if p.cache.catalog != nil && time.Since(p.cache.catalog.FetchedAt) < permissionCatalogTTL {
+		return p.cache.catalog, nil, nil
+	}
+	permissions, resp, err := p.GetAllPermissions(nil, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	p.cache.catalog = &PermissionCatalog{
+		Permissions: permissions,
+		FetchedAt:   time.Now(),
+	}
+	return p.cache.catalog, resp, nil
+}
+
+// SetCatalog seeds the cache used by Catalog, e.g. with a snapshot loaded from disk.
+// A nil catalog clears the cache
+func (p *PermissionsService) SetCatalog(catalog *PermissionCatalog) {
+	p.cache.Lock()
+	defer p.cache.Unlock()
+	p.cache.catalog = catalog
+}
@@ -0,0 +1,101 @@
+package iam
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionCatalog(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	entries := []string{
+		`{"id": "f1c8b67a-e652-4a91-abb1-0b5d032948dd", "name": "SERVICE.SCOPE", "category": "IAM", "type": "GLOBAL"}`,
+		`{"id": "11615a64-34dd-4ada-be73-b30a0acb8769", "name": "ROLE.WRITE", "category": "IAM", "type": "GLOBAL"}`,
+		`{"id": "363f6953-158c-4122-af76-b997f259c4af", "name": "CDR.READ", "category": "CDR", "type": "GLOBAL"}`,
+	}
+	requests := 0
+	pageLimit := 100
+	ignorePage := false
+	muxIDM.HandleFunc("/authorize/identity/Permission", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		count, _ := strconv.Atoi(r.URL.Query().Get("_count"))
+		if count > pageLimit {
+			count = pageLimit
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("_page"))
+		if ignorePage {
+			page = 1
+		}
+		from, to := (page-1)*count, page*count
+		if from > len(entries) {
+			from = len(entries)
+		}
+		if to > len(entries) {
+			to = len(entries)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"total": 3, "entry": [`+strings.Join(entries[from:to], ",")+`]}`)
+	})
+
+	count := 2
+	all, _, err := client.Permissions.GetAllPermissions(&GetPermissionOptions{Count: &count})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, all, 3)
+
+	// A server capping the page size below _count
+	pageLimit = 1
+	all, _, err = client.Permissions.GetAllPermissions(&GetPermissionOptions{Count: &count})
+	assert.Nil(t, err)
+	assert.Len(t, all, 3)
+
+	// A server ignoring _page
+	ignorePage = true
+	all, _, err = client.Permissions.GetAllPermissions(&GetPermissionOptions{Count: &count})
+	assert.Nil(t, err)
+	assert.Len(t, all, 1)
+	pageLimit = 100
+	ignorePage = false
+
+	requests = 0
+	catalog, resp, err := client.Permissions.Catalog()
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotNil(t, resp)
+	assert.Equal(t, []string{"CDR.READ", "ROLE.WRITE", "SERVICE.SCOPE"}, catalog.Names())
+	assert.Equal(t, []string{"ROLE.WRITE", "SERVICE.SCOPE"}, catalog.Categories()["IAM"])
+	assert.True(t, catalog.Contains("CDR.READ"))
+	assert.Equal(t, []string{"CDR.WRITE"}, catalog.Unknown([]string{"CDR.READ", "CDR.WRITE"}))
+
+	cached, resp, err := client.Permissions.Catalog()
+	assert.Nil(t, err)
+	assert.Nil(t, resp)
+	assert.Equal(t, catalog, cached)
+	// One page with all permissions and an empty page ending the listing
+	assert.Equal(t, 2, requests)
+
+	var snapshot bytes.Buffer
+	if !assert.Nil(t, catalog.Save(&snapshot)) {
+		return
+	}
+	loaded, err := LoadPermissionCatalog(&snapshot)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, catalog.Names(), loaded.Names())
+
+	client.Permissions.SetCatalog(nil)
+	_, _, err = client.Permissions.Catalog()
+	assert.Nil(t, err)
+	assert.Equal(t, 4, requests)
+}
@@ -16,6 +16,8 @@ type Permission struct {
 // PermissionsService provides operations on IAM Permissions resources
 type PermissionsService struct {
 	client *Client
+
+	cache permissionCatalogCache
 }
 
 // GetPermissionOptions describes search criteria for looking up permissions
@@ -23,24 +25,26 @@ type GetPermissionOptions struct {
 	ID     *string `url:"_id,omitempty"`
 	Name   *string `url:"name,omitempty"`
 	RoleID *string `url:"roleId,omitempty"`
+	Count  *int    `url:"_count,omitempty"`
+	Page   *int    `url:"_page,omitempty"`
 }
 
 // GetPermissionByID looks up a permission by ID
-func (p *PermissionsService) GetPermissionByID(id string) (*Permission, *Response, error) {
-	return p.GetPermission(&GetPermissionOptions{ID: &id}, nil)
+func (p *PermissionsService) GetPermissionByID(id string, options ...OptionFunc) (*Permission, *Response, error) {
+	return p.GetPermission(&GetPermissionOptions{ID: &id}, options...)
 }
 
 // GetPermissionByName looks up a permission by name
-func (p *PermissionsService) GetPermissionByName(name string) (*Permission, *Response, error) {
-	return p.GetPermission(&GetPermissionOptions{Name: &name}, nil)
+func (p *PermissionsService) GetPermissionByName(name string, options ...OptionFunc) (*Permission, *Response, error) {
+	return p.GetPermission(&GetPermissionOptions{Name: &name}, options...)
 }
 
 // GetPermissionsByRoleID finds all permission which belong to the roleID
-func (p *PermissionsService) GetPermissionsByRoleID(roleID string) (*[]Permission, *Response, error) {
+func (p *PermissionsService) GetPermissionsByRoleID(roleID string, options ...OptionFunc) (*[]Permission, *Response, error) {
 	opt := &GetPermissionOptions{
 		RoleID: &roleID,
 	}
-	req, err := p.client.newRequest(IDM, "GET", "authorize/identity/Permission", opt, nil)
+	req, err := p.client.newRequest(IDM, "GET", "authorize/identity/Permission", opt, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,70 @@
+package iam
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"strings"
+)
+
+const (
+	// PKCEMethodS256 is the only code challenge method supported by the helpers
+	PKCEMethodS256 = "S256"
+
+	pkceVerifierBytes = 32
+)
+
+// PKCE holds a Proof Key for Code Exchange (RFC 7636) verifier and its challenge
+type PKCE struct {
+	Verifier  string
+	Challenge string
+	Method    string
+}
+
+// NewPKCE generates a random code verifier and the matching S256 challenge
+func NewPKCE() (*PKCE, error) {
+	b := make([]byte, pkceVerifierBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	verifier := base64.RawURLEncoding.EncodeToString(b)
+	return &PKCE{
+		Verifier:  verifier,
+		Challenge: pkceChallenge(verifier),
+		Method:    PKCEMethodS256,
+	}, nil
+}
+
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AuthorizationURL returns the IAM authorize URL a user should be sent to in order
+// to start the authorization_code flow. The configured scopes are requested.
+// When pkce is not nil its challenge is included. Exchange the resulting code using
+// CodeLogin or CodeLoginWithPKCE
+func (c *Client) AuthorizationURL(redirectURI, state string, pkce *PKCE) string {
+	u := *c.baseIAMURL
+	u.Path = c.baseIAMURL.Path + "authorize/oauth2/authorize"
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", c.config.OAuth2ClientID)
+	if len(redirectURI) > 0 {
+		query.Set("redirect_uri", redirectURI)
+	}
+	if len(state) > 0 {
+		query.Set("state", state)
+	}
+	if len(c.config.Scopes) > 0 {
+		query.Set("scope", strings.Join(c.config.Scopes, " "))
+	}
+	if pkce != nil {
+		query.Set("code_challenge", pkce.Challenge)
+		query.Set("code_challenge_method", pkce.Method)
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
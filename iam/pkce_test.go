@@ -0,0 +1,104 @@
+package iam
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPKCE(t *testing.T) {
+	pkce, err := NewPKCE()
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, PKCEMethodS256, pkce.Method)
+	assert.Len(t, pkce.Verifier, 43)
+	assert.Equal(t, pkceChallenge(pkce.Verifier), pkce.Challenge)
+
+	// RFC 7636 Appendix B
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", pkceChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
+
+func TestPKCELogin(t *testing.T) {
+	muxIAM = http.NewServeMux()
+	serverIAM = httptest.NewServer(muxIAM)
+	muxIDM = http.NewServeMux()
+	serverIDM = httptest.NewServer(muxIDM)
+
+	defer serverIAM.Close()
+	defer serverIDM.Close()
+
+	authorizationCode := "a0c26ee4-e5b2-40ab-964e-8af999db44d5"
+	redirectURI := "http://localhost:8080/callback"
+	token := "44d20214-7879-4e35-923d-f9d4e01c9746"
+
+	cfg := &Config{
+		OAuth2ClientID: "PublicClient",
+		IAMURL:         serverIAM.URL,
+		IDMURL:         serverIDM.URL,
+		Scopes:         []string{"openid", "cn"},
+	}
+	client, err := NewClient(nil, cfg)
+	if !assert.Nil(t, err) {
+		return
+	}
+	pkce, err := NewPKCE()
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	authURL, err := url.Parse(client.AuthorizationURL(redirectURI, "xyz", pkce))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "/authorize/oauth2/authorize", authURL.Path)
+	query := authURL.Query()
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, "PublicClient", query.Get("client_id"))
+	assert.Equal(t, redirectURI, query.Get("redirect_uri"))
+	assert.Equal(t, "xyz", query.Get("state"))
+	assert.Equal(t, "openid cn", query.Get("scope"))
+	assert.Equal(t, pkce.Challenge, query.Get("code_challenge"))
+	assert.Equal(t, PKCEMethodS256, query.Get("code_challenge_method"))
+
+	muxIAM.HandleFunc("/authorize/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); !assert.Nil(t, err) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _, hasBasicAuth := r.BasicAuth()
+		assert.False(t, hasBasicAuth)
+		assert.Equal(t, "authorization_code", r.Form.Get("grant_type"))
+		assert.Equal(t, authorizationCode, r.Form.Get("code"))
+		assert.Equal(t, "PublicClient", r.Form.Get("client_id"))
+		if pkceChallenge(r.Form.Get("code_verifier")) != query.Get("code_challenge") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{
+    		"scope": "openid cn",
+    		"access_token": "`+token+`",
+    		"expires_in": 1799,
+    		"token_type": "Bearer"
+		}`)
+	})
+
+	err = client.CodeLoginWithPKCE(authorizationCode, redirectURI, "")
+	assert.ErrorIs(t, err, ErrMissingCodeVerifier)
+
+	err = client.CodeLoginWithPKCE(authorizationCode, redirectURI, pkce.Verifier)
+	if !assert.Nil(t, err) {
+		return
+	}
+	accessToken, err := client.Token()
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, token, accessToken)
+}
@@ -0,0 +1,154 @@
+package iam
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	policyAPIVersion = "1"
+)
+
+// PoliciesService provides operations on IAM access policy resources
+type PoliciesService struct {
+	client *Client
+
+	validate *validator.Validate
+}
+
+// GetPolicyOptions describes the criteria for looking up access policies
+type GetPolicyOptions struct {
+	ID             *string `url:"_id,omitempty"`
+	Name           *string `url:"name,omitempty"`
+	OrganizationID *string `url:"organizationId,omitempty"`
+	SubjectID      *string `url:"subjectId,omitempty"`
+	Resource       *string `url:"resource,omitempty"`
+}
+
+// CreatePolicy creates an access policy
+func (p *PoliciesService) CreatePolicy(policy AccessPolicy, options ...OptionFunc) (*AccessPolicy, *Response, error) {
+	if err := p.validate.Struct(policy); err != nil {
+		return nil, nil, err
+	}
+	req, err := p.client.newRequest(IDM, "POST", "authorize/identity/Policy", &policy, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", policyAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var createdPolicy AccessPolicy
+
+	resp, err := p.client.do(req, &createdPolicy)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &createdPolicy, resp, nil
+}
+
+// GetPolicyByID retrieves an access policy by ID
+func (p *PoliciesService) GetPolicyByID(id string, options ...OptionFunc) (*AccessPolicy, *Response, error) {
+	req, err := p.client.newRequest(IDM, "GET", "authorize/identity/Policy/"+id, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", policyAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var policy AccessPolicy
+
+	resp, err := p.client.do(req, &policy)
+	if err != nil {
+		return nil, resp, err
+	}
+	if policy.ID != id {
+		return nil, resp, ErrNotFound
+	}
+	return &policy, resp, nil
+}
+
+// GetPolicies looks up access policies based on GetPolicyOptions
+func (p *PoliciesService) GetPolicies(opt *GetPolicyOptions, options ...OptionFunc) (*[]AccessPolicy, *Response, error) {
+	req, err := p.client.newRequest(IDM, "GET", "authorize/identity/Policy", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", policyAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var bundleResponse struct {
+		Total int            `json:"total"`
+		Entry []AccessPolicy `json:"entry"`
+	}
+
+	resp, err := p.client.do(req, &bundleResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &bundleResponse.Entry, resp, nil
+}
+
+// UpdatePolicy updates an access policy
+func (p *PoliciesService) UpdatePolicy(policy AccessPolicy, options ...OptionFunc) (*AccessPolicy, *Response, error) {
+	if policy.Meta == nil {
+		return nil, nil, ErrMissingEtagInformation
+	}
+	if err := p.validate.Struct(policy); err != nil {
+		return nil, nil, err
+	}
+	req, err := p.client.newRequest(IDM, "PUT", "authorize/identity/Policy/"+policy.ID, &policy, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", policyAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", policy.Meta.Version)
+
+	var updatedPolicy AccessPolicy
+
+	resp, err := p.client.do(req, &updatedPolicy)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &updatedPolicy, resp, nil
+}
+
+// DeletePolicy deletes the given access policy
+func (p *PoliciesService) DeletePolicy(policy AccessPolicy, options ...OptionFunc) (bool, *Response, error) {
+	req, err := p.client.newRequest(IDM, "DELETE", "authorize/identity/Policy/"+policy.ID, nil, options)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("api-version", policyAPIVersion)
+
+	var deleteResponse bytes.Buffer
+
+	resp, err := p.client.do(req, &deleteResponse)
+	if resp == nil || resp.StatusCode != http.StatusNoContent {
+		return false, resp, err
+	}
+	return true, resp, nil
+}
+
+// Evaluate evaluates an access request against the policies of the organization
+func (p *PoliciesService) Evaluate(request PolicyEvaluationRequest, options ...OptionFunc) (*PolicyEvaluationResult, *Response, error) {
+	if err := p.validate.Struct(request); err != nil {
+		return nil, nil, err
+	}
+	req, err := p.client.newRequest(IDM, "POST", "authorize/identity/Policy/$evaluate", &request, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", policyAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var result PolicyEvaluationResult
+
+	resp, err := p.client.do(req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &result, resp, nil
+}
@@ -0,0 +1,172 @@
+package iam
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testPolicyJSON(id string) string {
+	return `{
+  "id": "` + id + `",
+  "name": "ReadOwnRecords",
+  "description": "Users can read their own records",
+  "managingOrganization": "c57b2625-eda3-4b27-a8e6-86f0a0e76afc",
+  "effect": "Permit",
+  "subjects": [{"type": "Group", "value": "dbf1d779-ab9f-4c27-b4aa-ea75f9efbbc0"}],
+  "resources": ["cdr:Observation"],
+  "actions": ["read"],
+  "conditions": [{"attribute": "resource.owner", "operator": "eq", "values": ["${subject.id}"]}],
+  "meta": {"version": "W/\"1\""}
+}`
+}
+
+func TestPolicyCRUD(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	policyID := "e9a79a21-0ef3-4ce4-a0c2-a09a6a0f5c2e"
+
+	muxIDM.HandleFunc("/authorize/identity/Policy", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "POST":
+			var policy AccessPolicy
+			if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, testPolicyJSON(policyID))
+		case "GET":
+			assert.Equal(t, "ReadOwnRecords", r.URL.Query().Get("name"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{"total":1,"entry":[`+testPolicyJSON(policyID)+`]}`)
+		}
+	})
+	muxIDM.HandleFunc("/authorize/identity/Policy/"+policyID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, testPolicyJSON(policyID))
+		case "PUT":
+			assert.Equal(t, `W/"1"`, r.Header.Get("If-Match"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, testPolicyJSON(policyID))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	policy := AccessPolicy{
+		Name:                 "ReadOwnRecords",
+		ManagingOrganization: "c57b2625-eda3-4b27-a8e6-86f0a0e76afc",
+		Effect:               PolicyEffectPermit,
+		Subjects:             []PolicySubject{{Type: PolicySubjectGroup, Value: "dbf1d779-ab9f-4c27-b4aa-ea75f9efbbc0"}},
+		Resources:            []string{"cdr:Observation"},
+		Actions:              []string{"read"},
+		Conditions:           []PolicyCondition{{Attribute: "resource.owner", Operator: "eq", Values: []string{"${subject.id}"}}},
+	}
+	created, resp, err := client.Policies.CreatePolicy(policy)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, policyID, created.ID)
+
+	found, _, err := client.Policies.GetPolicyByID(policyID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, PolicyEffectPermit, found.Effect)
+	assert.Len(t, found.Conditions, 1)
+
+	policies, _, err := client.Policies.GetPolicies(&GetPolicyOptions{Name: String("ReadOwnRecords")})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, *policies, 1)
+
+	updated, _, err := client.Policies.UpdatePolicy(*found)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, policyID, updated.ID)
+
+	_, _, err = client.Policies.UpdatePolicy(policy)
+	assert.ErrorIs(t, err, ErrMissingEtagInformation)
+
+	ok, _, err := client.Policies.DeletePolicy(*found)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestPolicyValidation(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	policy := AccessPolicy{
+		Name:                 "Bad",
+		ManagingOrganization: "c57b2625-eda3-4b27-a8e6-86f0a0e76afc",
+		Effect:               "Maybe",
+		Subjects:             []PolicySubject{{Type: PolicySubjectUser, Value: "foo"}},
+		Resources:            []string{"cdr:Observation"},
+		Actions:              []string{"read"},
+	}
+	_, _, err := client.Policies.CreatePolicy(policy)
+	assert.NotNil(t, err)
+
+	policy.Effect = PolicyEffectDeny
+	policy.Subjects[0].Type = "Robot"
+	_, _, err = client.Policies.CreatePolicy(policy)
+	assert.NotNil(t, err)
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	muxIDM.HandleFunc("/authorize/identity/Policy/$evaluate", func(w http.ResponseWriter, r *http.Request) {
+		var request PolicyEvaluationRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); !assert.Nil(t, err) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		decision := PolicyDecisionDeny
+		if request.Action == "read" && request.Attributes["resource.owner"] == request.Subject.Value {
+			decision = PolicyDecisionPermit
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"decision":"`+decision+`","matchedPolicies":["e9a79a21-0ef3-4ce4-a0c2-a09a6a0f5c2e"]}`)
+	})
+
+	request := PolicyEvaluationRequest{
+		OrganizationID: "c57b2625-eda3-4b27-a8e6-86f0a0e76afc",
+		Subject:        PolicySubject{Type: PolicySubjectUser, Value: "user1"},
+		Resource:       "cdr:Observation",
+		Action:         "read",
+		Attributes:     map[string]string{"resource.owner": "user1"},
+	}
+	result, resp, err := client.Policies.Evaluate(request)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, result.Permitted())
+	assert.Len(t, result.MatchedPolicies, 1)
+
+	request.Action = "write"
+	result, _, err = client.Policies.Evaluate(request)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.False(t, result.Permitted())
+
+	_, _, err = client.Policies.Evaluate(PolicyEvaluationRequest{})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,70 @@
+package iam
+
+// Access policy effects
+const (
+	PolicyEffectPermit = "Permit"
+	PolicyEffectDeny   = "Deny"
+)
+
+// Access policy subject types
+const (
+	PolicySubjectUser    = "User"
+	PolicySubjectGroup   = "Group"
+	PolicySubjectRole    = "Role"
+	PolicySubjectService = "Service"
+	PolicySubjectDevice  = "Device"
+)
+
+// Access policy evaluation decisions
+const (
+	PolicyDecisionPermit        = "Permit"
+	PolicyDecisionDeny          = "Deny"
+	PolicyDecisionNotApplicable = "NotApplicable"
+)
+
+// PolicySubject identifies who a policy applies to
+type PolicySubject struct {
+	Type  string `json:"type" validate:"required,oneof=User Group Role Service Device"`
+	Value string `json:"value" validate:"required"`
+}
+
+// PolicyCondition restricts a policy to requests whose attribute matches the values
+type PolicyCondition struct {
+	Attribute string   `json:"attribute" validate:"required"`
+	Operator  string   `json:"operator" validate:"required,oneof=eq ne in notIn gt ge lt le"`
+	Values    []string `json:"values" validate:"min=1"`
+}
+
+// AccessPolicy represents an IAM fine-grained authorization policy
+type AccessPolicy struct {
+	ID                   string            `json:"id,omitempty"`
+	Name                 string            `json:"name" validate:"required,min=1,max=255"`
+	Description          string            `json:"description,omitempty" validate:"max=250"`
+	ManagingOrganization string            `json:"managingOrganization" validate:"required"`
+	Effect               string            `json:"effect" validate:"required,oneof=Permit Deny"`
+	Subjects             []PolicySubject   `json:"subjects" validate:"min=1,dive"`
+	Resources            []string          `json:"resources" validate:"min=1"`
+	Actions              []string          `json:"actions" validate:"min=1"`
+	Conditions           []PolicyCondition `json:"conditions,omitempty" validate:"dive"`
+	Meta                 *Meta             `json:"meta,omitempty"`
+}
+
+// PolicyEvaluationRequest describes an access request to evaluate against the policies
+type PolicyEvaluationRequest struct {
+	OrganizationID string            `json:"organizationId" validate:"required"`
+	Subject        PolicySubject     `json:"subject"`
+	Resource       string            `json:"resource" validate:"required"`
+	Action         string            `json:"action" validate:"required"`
+	Attributes     map[string]string `json:"attributes,omitempty"`
+}
+
+// PolicyEvaluationResult holds the decision of an evaluation and the policies which determined it
+type PolicyEvaluationResult struct {
+	Decision        string   `json:"decision"`
+	MatchedPolicies []string `json:"matchedPolicies,omitempty"`
+}
+
+// Permitted returns true if the evaluation resulted in a Permit decision
+func (r PolicyEvaluationResult) Permitted() bool {
+	return r.Decision == PolicyDecisionPermit
+}
@@ -1,6 +1,7 @@
 package iam
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,11 +13,12 @@ const (
 
 // Proposition represents an IAM Proposition entity
 type Proposition struct {
-	ID                string `json:"id,omitempty"`
-	Name              string `json:"name"`
-	Description       string `json:"description"`
-	OrganizationID    string `json:"organizationId"`
-	GlobalReferenceID string `json:"globalReferenceId"`
+	ID                string       `json:"id,omitempty"`
+	Name              string       `json:"name"`
+	Description       string       `json:"description"`
+	OrganizationID    string       `json:"organizationId"`
+	GlobalReferenceID string       `json:"globalReferenceId"`
+	Meta              *VersionMeta `json:"meta,omitempty"`
 }
 
 func (p *Proposition) validate() error {
@@ -49,9 +51,15 @@ type GetPropositionsOptions struct {
 	Name              *string `url:"name,omitempty"`
 }
 
+// DeletePropositionOptions specifies how a Proposition is deleted
+type DeletePropositionOptions struct {
+	// Force also deletes the applications, clients and services of the Proposition
+	Force bool `url:"force,omitempty"`
+}
+
 // GetPropositionByID retrieves an Proposition by its ID
-func (p *PropositionsService) GetPropositionByID(id string) (*Proposition, *Response, error) {
-	return p.GetProposition(&GetPropositionsOptions{ID: &id}, nil)
+func (p *PropositionsService) GetPropositionByID(id string, options ...OptionFunc) (*Proposition, *Response, error) {
+	return p.GetProposition(&GetPropositionsOptions{ID: &id}, options...)
 }
 
 // GetProposition find a Proposition based on the GetPropositions values
@@ -88,11 +96,11 @@ func (p *PropositionsService) GetPropositions(opt *GetPropositionsOptions, optio
 }
 
 // CreateProposition creates a Proposition
-func (p *PropositionsService) CreateProposition(prop Proposition) (*Proposition, *Response, error) {
+func (p *PropositionsService) CreateProposition(prop Proposition, options ...OptionFunc) (*Proposition, *Response, error) {
 	if err := prop.validate(); err != nil {
 		return nil, nil, err
 	}
-	req, err := p.client.newRequest(IDM, "POST", "authorize/identity/Proposition", &prop, nil)
+	req, err := p.client.newRequest(IDM, "POST", "authorize/identity/Proposition", &prop, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -117,5 +125,48 @@ func (p *PropositionsService) CreateProposition(prop Proposition) (*Proposition,
 	if count == 0 {
 		return nil, resp, fmt.Errorf("CreateProposition: %w", ErrCouldNoReadResourceAfterCreate)
 	}
-	return p.GetPropositionByID(id)
+	return p.GetPropositionByID(id, options...)
+}
+
+// UpdateProposition updates the name and description of a Proposition
+func (p *PropositionsService) UpdateProposition(prop Proposition, options ...OptionFunc) (*Proposition, *Response, error) {
+	if prop.Meta == nil {
+		return nil, nil, ErrMissingEtagInformation
+	}
+	if err := prop.validate(); err != nil {
+		return nil, nil, err
+	}
+	req, err := p.client.newRequest(IDM, "PUT", "authorize/identity/Proposition/"+prop.ID, &prop, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", propositionAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", prop.Meta.VersionID)
+
+	var updatedProp Proposition
+
+	resp, err := p.client.do(req, &updatedProp)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &updatedProp, resp, nil
+}
+
+// DeleteProposition deletes the given Proposition. Unless opt.Force is set IAM
+// rejects the request when the Proposition still has applications
+func (p *PropositionsService) DeleteProposition(prop Proposition, opt *DeletePropositionOptions, options ...OptionFunc) (bool, *Response, error) {
+	req, err := p.client.newRequest(IDM, "DELETE", "authorize/identity/Proposition/"+prop.ID, opt, options)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("api-version", propositionAPIVersion)
+
+	var deleteResponse bytes.Buffer
+
+	resp, err := p.client.do(req, &deleteResponse)
+	if resp == nil || (resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusAccepted) {
+		return false, resp, err
+	}
+	return true, resp, nil
 }
@@ -108,3 +108,75 @@ func TestGetPropositions(t *testing.T) {
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
 	}
 }
+
+func TestUpdateDeleteProposition(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	propID := "10dc5e2f-3940-4cd8-b0ef-297e12ad2f3c"
+	orgID := "3af7143e-de76-11e8-9681-6a0002b8cb70"
+	description := "Updated description"
+
+	muxIDM.HandleFunc("/authorize/identity/Proposition/"+propID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "PUT":
+			if r.Header.Get("If-Match") != "0" {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				_, _ = io.WriteString(w, `{"resourceType": "OperationOutcome","issue": [{"severity": "error","code": "conflict"}]}`)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{
+				"name": "TESTPROP",
+				"description": "`+description+`",
+				"organizationId": "`+orgID+`",
+				"globalReferenceId": "TESTPROP",
+				"id": "`+propID+`",
+				"meta": {
+					"versionId": "1",
+					"lastModified": "2018-11-02T05:48:41.042Z"
+				}
+			}`)
+		case "DELETE":
+			if r.URL.Query().Get("force") != "true" {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = io.WriteString(w, `{"resourceType": "OperationOutcome","issue": [{"severity": "error","code": "conflict"}]}`)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	prop := Proposition{
+		ID:                propID,
+		Name:              "TESTPROP",
+		Description:       description,
+		OrganizationID:    orgID,
+		GlobalReferenceID: "TESTPROP",
+	}
+	_, _, err := client.Propositions.UpdateProposition(prop)
+	assert.Equal(t, ErrMissingEtagInformation, err)
+
+	prop.Meta = &VersionMeta{VersionID: "0"}
+	updated, resp, err := client.Propositions.UpdateProposition(prop)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotNil(t, resp)
+	if assert.NotNil(t, updated) {
+		assert.Equal(t, description, updated.Description)
+		assert.Equal(t, "1", updated.Meta.VersionID)
+	}
+
+	ok, _, err := client.Propositions.DeleteProposition(prop, nil)
+	assert.NotNil(t, err)
+	assert.False(t, ok)
+
+	ok, resp, err = client.Propositions.DeleteProposition(prop, &DeletePropositionOptions{Force: true})
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	if assert.NotNil(t, resp) {
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+}
@@ -6,6 +6,10 @@ var (
 	roleAPIVersion = "1"
 )
 
+const (
+	rolePermissionBatchSize = 50
+)
+
 // Role represents an IAM resource
 type Role struct {
 	ID                   string `json:"id,omitempty"`
@@ -25,6 +29,8 @@ type GetRolesOptions struct {
 	GroupID        *string `url:"groupId,omitempty"`
 	OrganizationID *string `url:"organizationId,omitempty"`
 	RoleID         *string `url:"roleId,omitempty"`
+	Count          *int    `url:"_count,omitempty"`
+	Page           *int    `url:"_page,omitempty"`
 }
 
 // ListSharingPoliciesOptions describes search criteria for listing RoleSharingPolicy resources
@@ -48,8 +54,8 @@ type RoleSharingPolicy struct {
 }
 
 // GetRoles retries based on GetRolesOptions
-func (p *RolesService) GetRoles(opt *GetRolesOptions) (*[]Role, *Response, error) {
-	req, err := p.client.newRequest(IDM, http.MethodGet, "authorize/identity/Role", opt, nil)
+func (p *RolesService) GetRoles(opt *GetRolesOptions, options ...OptionFunc) (*[]Role, *Response, error) {
+	req, err := p.client.newRequest(IDM, http.MethodGet, "authorize/identity/Role", opt, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -68,17 +74,81 @@ func (p *RolesService) GetRoles(opt *GetRolesOptions) (*[]Role, *Response, error
 	return &responseStruct.Entry, resp, err
 }
 
-// GetRolesByGroupID retrieves Roles based on group ID
-func (p *RolesService) GetRolesByGroupID(groupID string) (*[]Role, *Response, error) {
+// GetRolesByGroupID retrieves Roles based on group ID. All pages are retrieved
+func (p *RolesService) GetRolesByGroupID(groupID string, options ...OptionFunc) (*[]Role, *Response, error) {
 	opt := &GetRolesOptions{
 		GroupID: &groupID,
 	}
-	return p.GetRoles(opt)
+	roles, resp, err := p.GetAllRoles(opt, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &roles, resp, nil
+}
+
+// GetAllRoles retrieves all pages of roles matching GetRolesOptions
+func (p *RolesService) GetAllRoles(opt *GetRolesOptions, options ...OptionFunc) ([]Role, *Response, error) {
+	search := GetRolesOptions{}
+	if opt != nil {
+		search = *opt
+	}
+	count := defaultIteratorPageSize
+	if search.Count != nil {
+		count = *search.Count
+	}
+	search.Count = &count
+	var roles, last []Role
+	for page := 1; ; page++ {
+		current := page
+		search.Page = &current
+		entries, resp, err := p.GetRoles(&search, options...)
+		if err != nil {
+			return roles, resp, err
+		}
+		// IAM may return fewer roles than requested, so only an empty page, or
+		// the same page again when _page is ignored, ends the listing
+		if len(*entries) == 0 || (len(last) > 0 && (*entries)[0].ID == last[0].ID) {
+			return roles, resp, nil
+		}
+		last = *entries
+		roles = append(roles, *entries...)
+	}
+}
+
+// GetEffectiveRolesForUser retrieves the roles the user holds in the organization
+// through the groups it is a member of. Roles assigned via multiple groups are returned once
+func (p *RolesService) GetEffectiveRolesForUser(userID, orgID string, options ...OptionFunc) ([]Role, *Response, error) {
+	memberType := "USER"
+	groups, resp, err := p.client.Groups.GetGroups(&GetGroupOptions{
+		OrganizationID: &orgID,
+		MemberType:     &memberType,
+		MemberID:       &userID,
+	}, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	var roles []Role
+	seen := make(map[string]bool)
+	for _, group := range *groups {
+		groupRoles, groupResp, err := p.GetAllRoles(&GetRolesOptions{GroupID: String(group.ID)}, options...)
+		if err != nil {
+			return nil, groupResp, err
+		}
+		resp = groupResp
+		for _, role := range groupRoles {
+			if seen[role.ID] {
+				continue
+			}
+			seen[role.ID] = true
+			roles = append(roles, role)
+		}
+	}
+	return roles, resp, nil
 }
 
 // GetRoleByID retrieves a role by ID
-func (p *RolesService) GetRoleByID(roleID string) (*Role, *Response, error) {
-	req, err := p.client.newRequest(IDM, http.MethodGet, "authorize/identity/Role/"+roleID, nil, nil)
+func (p *RolesService) GetRoleByID(roleID string, options ...OptionFunc) (*Role, *Response, error) {
+	req, err := p.client.newRequest(IDM, http.MethodGet, "authorize/identity/Role/"+roleID, nil, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -98,13 +168,13 @@ func (p *RolesService) GetRoleByID(roleID string) (*Role, *Response, error) {
 }
 
 // CreateRole creates a Role
-func (p *RolesService) CreateRole(name, description, managingOrganization string) (*Role, *Response, error) {
+func (p *RolesService) CreateRole(name, description, managingOrganization string, options ...OptionFunc) (*Role, *Response, error) {
 	role := &Role{
 		Name:                 name,
 		Description:          description,
 		ManagingOrganization: managingOrganization,
 	}
-	req, _ := p.client.newRequest(IDM, http.MethodPost, "authorize/identity/Role", role, nil)
+	req, _ := p.client.newRequest(IDM, http.MethodPost, "authorize/identity/Role", role, options)
 	req.Header.Set("api-version", roleAPIVersion)
 
 	var createdRole Role
@@ -119,8 +189,8 @@ func (p *RolesService) CreateRole(name, description, managingOrganization string
 type RoleResponse map[string]interface{}
 
 // DeleteRole deletes the given Role
-func (p *RolesService) DeleteRole(role Role) (RoleResponse, *Response, error) {
-	req, err := p.client.newRequest(IDM, http.MethodDelete, "authorize/identity/Role/"+role.ID, nil, nil)
+func (p *RolesService) DeleteRole(role Role, options ...OptionFunc) (RoleResponse, *Response, error) {
+	req, err := p.client.newRequest(IDM, http.MethodDelete, "authorize/identity/Role/"+role.ID, nil, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -134,10 +204,10 @@ func (p *RolesService) DeleteRole(role Role) (RoleResponse, *Response, error) {
 }
 
 // GetRolePermissions retrieves the permissions associated with the Role
-func (p *RolesService) GetRolePermissions(role Role) (*[]string, *Response, error) {
+func (p *RolesService) GetRolePermissions(role Role, options ...OptionFunc) (*[]string, *Response, error) {
 	opt := &GetRolesOptions{RoleID: &role.ID}
 
-	req, err := p.client.newRequest(IDM, http.MethodGet, "authorize/identity/Permission", opt, nil)
+	req, err := p.client.newRequest(IDM, http.MethodGet, "authorize/identity/Permission", opt, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -161,13 +231,13 @@ func (p *RolesService) GetRolePermissions(role Role) (*[]string, *Response, erro
 }
 
 // AddRolePermission adds a given permission to the Role
-func (p *RolesService) rolePermissionAction(role Role, permissions []string, action string) (RoleResponse, *Response, error) {
+func (p *RolesService) rolePermissionAction(role Role, permissions []string, action string, options []OptionFunc) (RoleResponse, *Response, error) {
 	var permissionRequest struct {
 		Permissions []string `json:"permissions"`
 	}
 	permissionRequest.Permissions = permissions
 
-	req, err := p.client.newRequest(IDM, http.MethodPost, "authorize/identity/Role/"+role.ID+"/"+action, &permissionRequest, nil)
+	req, err := p.client.newRequest(IDM, http.MethodPost, "authorize/identity/Role/"+role.ID+"/"+action, &permissionRequest, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -183,17 +253,85 @@ func (p *RolesService) rolePermissionAction(role Role, permissions []string, act
 
 }
 
-func (p *RolesService) AddRolePermission(role Role, permission string) (RoleResponse, *Response, error) {
-	return p.rolePermissionAction(role, []string{permission}, "$assign-permission")
+func (p *RolesService) AddRolePermission(role Role, permission string, options ...OptionFunc) (RoleResponse, *Response, error) {
+	return p.rolePermissionAction(role, []string{permission}, "$assign-permission", options)
 }
 
 // RemoveRolePermission removes the permission from the Role
-func (p *RolesService) RemoveRolePermission(role Role, permission string) (RoleResponse, *Response, error) {
-	return p.rolePermissionAction(role, []string{permission}, "$remove-permission")
+func (p *RolesService) RemoveRolePermission(role Role, permission string, options ...OptionFunc) (RoleResponse, *Response, error) {
+	return p.rolePermissionAction(role, []string{permission}, "$remove-permission", options)
+}
+
+// PermissionSyncResult lists the permissions changed by SyncPermissions
+type PermissionSyncResult struct {
+	Added   []string
+	Removed []string
+}
+
+// SyncPermissions reconciles the permissions of the Role with the desired set.
+// Missing permissions are assigned and permissions not in desired are removed,
+// in batches. The returned result lists the applied changes, also on error
+func (p *RolesService) SyncPermissions(role Role, desired []string, options ...OptionFunc) (*PermissionSyncResult, *Response, error) {
+	current, resp, err := p.GetRolePermissions(role, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	toAdd, toRemove := diffPermissions(*current, desired)
+
+	result := &PermissionSyncResult{}
+	for _, batch := range batchPermissions(toAdd, rolePermissionBatchSize) {
+		_, resp, err = p.rolePermissionAction(role, batch, "$assign-permission", options)
+		if err != nil {
+			return result, resp, err
+		}
+		result.Added = append(result.Added, batch...)
+	}
+	for _, batch := range batchPermissions(toRemove, rolePermissionBatchSize) {
+		_, resp, err = p.rolePermissionAction(role, batch, "$remove-permission", options)
+		if err != nil {
+			return result, resp, err
+		}
+		result.Removed = append(result.Removed, batch...)
+	}
+	return result, resp, nil
+}
+
+func diffPermissions(current, desired []string) (toAdd []string, toRemove []string) {
+	have := make(map[string]bool, len(current))
+	for _, c := range current {
+		have[c] = true
+	}
+	want := make(map[string]bool, len(desired))
+	for _, d := range desired {
+		if want[d] {
+			continue
+		}
+		want[d] = true
+		if !have[d] {
+			toAdd = append(toAdd, d)
+		}
+	}
+	for _, c := range current {
+		if !want[c] {
+			toRemove = append(toRemove, c)
+		}
+	}
+	return toAdd, toRemove
+}
+
+func batchPermissions(permissions []string, size int) [][]string {
+	var batches [][]string
+	for size < len(permissions) {
+		permissions, batches = permissions[size:], append(batches, permissions[:size])
+	}
+	if len(permissions) > 0 {
+		batches = append(batches, permissions)
+	}
+	return batches
 }
 
-func (p *RolesService) ApplySharingPolicy(role Role, policy RoleSharingPolicy) (*RoleSharingPolicy, *Response, error) {
-	req, err := p.client.newRequest(IDM, http.MethodPut, "authorize/identity/Role/"+role.ID+"/"+"$apply-sharing-policy", &policy, nil)
+func (p *RolesService) ApplySharingPolicy(role Role, policy RoleSharingPolicy, options ...OptionFunc) (*RoleSharingPolicy, *Response, error) {
+	req, err := p.client.newRequest(IDM, http.MethodPut, "authorize/identity/Role/"+role.ID+"/"+"$apply-sharing-policy", &policy, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -208,8 +346,8 @@ func (p *RolesService) ApplySharingPolicy(role Role, policy RoleSharingPolicy) (
 	return &roleResponse, resp, nil
 }
 
-func (p *RolesService) RemoveSharingPolicy(role Role, policy RoleSharingPolicy) (*RoleSharingPolicy, *Response, error) {
-	req, err := p.client.newRequest(IDM, http.MethodPost, "authorize/identity/Role/"+role.ID+"/"+"$remove-sharing-policy", &policy, nil)
+func (p *RolesService) RemoveSharingPolicy(role Role, policy RoleSharingPolicy, options ...OptionFunc) (*RoleSharingPolicy, *Response, error) {
+	req, err := p.client.newRequest(IDM, http.MethodPost, "authorize/identity/Role/"+role.ID+"/"+"$remove-sharing-policy", &policy, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -224,13 +362,13 @@ func (p *RolesService) RemoveSharingPolicy(role Role, policy RoleSharingPolicy)
 	return &roleResponse, resp, nil
 }
 
-func (p *RolesService) ListSharingPolicies(role Role, opt *ListSharingPoliciesOptions) (*[]RoleSharingPolicy, *Response, error) {
+func (p *RolesService) ListSharingPolicies(role Role, opt *ListSharingPoliciesOptions, options ...OptionFunc) (*[]RoleSharingPolicy, *Response, error) {
 	var listResponse struct {
 		Total int                 `json:"total"`
 		Entry []RoleSharingPolicy `json:"entry"`
 	}
 
-	req, err := p.client.newRequest(IDM, http.MethodGet, "authorize/identity/Role/"+role.ID+"/"+"$list-sharing-policies", opt, nil)
+	req, err := p.client.newRequest(IDM, http.MethodGet, "authorize/identity/Role/"+role.ID+"/"+"$list-sharing-policies", opt, options)
 	if err != nil {
 		return nil, nil, err
 	}
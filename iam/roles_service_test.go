@@ -1,8 +1,11 @@
 package iam
 
 import (
+	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -176,6 +179,100 @@ func TestGetRolesByGroupID(t *testing.T) {
 	}
 }
 
+func TestGetEffectiveRolesForUser(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	userID := "f5fe538f-c3b5-4454-8774-cd3789f59b9f"
+	orgID := "c57b2625-eda3-4b27-a8e6-86f0a0e76afc"
+	groupRoles := map[string][]string{
+		"3c7a0274-169e-4ea9-ad91-252cc4022605": {"ADMIN", "READER"},
+		"9e2a9f6e-0a5c-4b61-8d4e-7b3f0f6f7c2a": {"READER", "WRITER"},
+	}
+	pageLimit := 100
+	ignorePage := false
+
+	muxIDM.HandleFunc("/authorize/identity/Group", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("memberId") != userID || q.Get("memberType") != "USER" || q.Get("orgID") != orgID {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{
+			"total": 2,
+			"entry": [
+				{"resource": {"_id": "3c7a0274-169e-4ea9-ad91-252cc4022605", "groupName": "Admins", "orgId": "`+orgID+`"}},
+				{"resource": {"_id": "9e2a9f6e-0a5c-4b61-8d4e-7b3f0f6f7c2a", "groupName": "Editors", "orgId": "`+orgID+`"}}
+			]
+		}`)
+	})
+	muxIDM.HandleFunc("/authorize/identity/Role", func(w http.ResponseWriter, r *http.Request) {
+		names, ok := groupRoles[r.URL.Query().Get("groupId")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		count, _ := strconv.Atoi(r.URL.Query().Get("_count"))
+		if count > pageLimit {
+			count = pageLimit
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("_page"))
+		if ignorePage {
+			page = 1
+		}
+		start := (page - 1) * count
+		if start > len(names) {
+			start = len(names)
+		}
+		end := start + count
+		if end > len(names) {
+			end = len(names)
+		}
+		var entries []string
+		for _, name := range names[start:end] {
+			entries = append(entries, `{"id": "role-`+name+`", "name": "`+name+`", "managingOrganization": "`+orgID+`"}`)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"total": `+strconv.Itoa(len(names))+`, "entry": [`+strings.Join(entries, ",")+`]}`)
+	})
+
+	count := 1
+	paged, _, err := client.Roles.GetAllRoles(&GetRolesOptions{GroupID: String("3c7a0274-169e-4ea9-ad91-252cc4022605"), Count: &count})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, paged, 2)
+
+	// A server capping the page size below _count
+	pageLimit = 1
+	count = 2
+	paged, _, err = client.Roles.GetAllRoles(&GetRolesOptions{GroupID: String("3c7a0274-169e-4ea9-ad91-252cc4022605"), Count: &count})
+	assert.Nil(t, err)
+	assert.Len(t, paged, 2)
+
+	// A server ignoring _page
+	ignorePage = true
+	paged, _, err = client.Roles.GetAllRoles(&GetRolesOptions{GroupID: String("3c7a0274-169e-4ea9-ad91-252cc4022605"), Count: &count})
+	assert.Nil(t, err)
+	assert.Len(t, paged, 1)
+	pageLimit = 100
+	ignorePage = false
+
+	roles, resp, err := client.Roles.GetEffectiveRolesForUser(userID, orgID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotNil(t, resp)
+	var names []string
+	for _, role := range roles {
+		names = append(names, role.Name)
+	}
+	assert.Equal(t, []string{"ADMIN", "READER", "WRITER"}, names)
+}
+
 func TestGetRolePermissions(t *testing.T) {
 	teardown := setup(t)
 	defer teardown()
@@ -214,3 +311,51 @@ func TestGetRolePermissions(t *testing.T) {
 	}
 	assert.Contains(t, *permissions, permissionName)
 }
+
+func TestSyncPermissions(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	roleID := "dbf1d779-ab9f-4c27-b4aa-ea75f9efbbc0"
+	var assigned, removed []string
+
+	muxIDM.HandleFunc("/authorize/identity/Permission", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{
+		    "total": 2,
+		    "entry": [{"name": "GROUP.READ"},{"name": "USER.READ"}]
+		    }`)
+	})
+	permissionHandler := func(target *[]string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Permissions []string `json:"permissions"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); !assert.Nil(t, err) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			*target = append(*target, body.Permissions...)
+			roleActionSuccessHandler("OK")(w, r)
+		}
+	}
+	muxIDM.HandleFunc("/authorize/identity/Role/"+roleID+"/$assign-permission", permissionHandler(&assigned))
+	muxIDM.HandleFunc("/authorize/identity/Role/"+roleID+"/$remove-permission", permissionHandler(&removed))
+
+	result, resp, err := client.Roles.SyncPermissions(Role{ID: roleID}, []string{"GROUP.READ", "GROUP.WRITE", "GROUP.WRITE"})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotNil(t, resp)
+	assert.Equal(t, []string{"GROUP.WRITE"}, result.Added)
+	assert.Equal(t, []string{"USER.READ"}, result.Removed)
+	assert.Equal(t, []string{"GROUP.WRITE"}, assigned)
+	assert.Equal(t, []string{"USER.READ"}, removed)
+}
+
+func TestBatchPermissions(t *testing.T) {
+	assert.Nil(t, batchPermissions(nil, 2))
+	assert.Equal(t, [][]string{{"a", "b"}, {"c"}}, batchPermissions([]string{"a", "b", "c"}, 2))
+	assert.Equal(t, [][]string{{"a", "b"}}, batchPermissions([]string{"a", "b"}, 2))
+}
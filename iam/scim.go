@@ -0,0 +1,86 @@
+package iam
+
+const (
+	SCIMUserSchema     = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMGroupSchema    = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCIMPatchOpSchema  = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCIMListRespSchema = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+)
+
+// SCIMName holds the name components of a SCIM User
+type SCIMName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty" validate:"required"`
+	GivenName  string `json:"givenName,omitempty" validate:"required"`
+	MiddleName string `json:"middleName,omitempty"`
+}
+
+// SCIMMultiValued describes a multi-valued attribute such as an email or phone number
+type SCIMMultiValued struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// SCIMMember references a member of a SCIM Group or a group of a SCIM User
+type SCIMMember struct {
+	Value   string `json:"value"`
+	Ref     string `json:"$ref,omitempty"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+}
+
+// SCIMUser represents a SCIM v2 User resource
+type SCIMUser struct {
+	Schemas      []string          `json:"schemas"`
+	ID           string            `json:"id,omitempty"`
+	ExternalID   string            `json:"externalId,omitempty"`
+	UserName     string            `json:"userName" validate:"required"`
+	Name         SCIMName          `json:"name"`
+	DisplayName  string            `json:"displayName,omitempty"`
+	Emails       []SCIMMultiValued `json:"emails,omitempty" validate:"min=1"`
+	PhoneNumbers []SCIMMultiValued `json:"phoneNumbers,omitempty"`
+	Active       *bool             `json:"active,omitempty"`
+	Groups       []SCIMMember      `json:"groups,omitempty"`
+	Meta         *Meta             `json:"meta,omitempty"`
+}
+
+// SCIMGroup represents a SCIM v2 Group resource
+type SCIMGroup struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	DisplayName string       `json:"displayName" validate:"required"`
+	Members     []SCIMMember `json:"members,omitempty"`
+	Meta        *Meta        `json:"meta,omitempty"`
+}
+
+// SCIMPatchOperation describes a single operation of a SCIM PATCH request
+type SCIMPatchOperation struct {
+	Op    string      `json:"op" validate:"required,oneof=add remove replace"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+type scimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []SCIMPatchOperation `json:"Operations" validate:"min=1,dive"`
+}
+
+// SCIMUserList is a page of SCIM User resources
+type SCIMUserList struct {
+	Schemas      []string   `json:"schemas"`
+	TotalResults int        `json:"totalResults"`
+	StartIndex   int        `json:"startIndex"`
+	ItemsPerPage int        `json:"itemsPerPage"`
+	Resources    []SCIMUser `json:"Resources"`
+}
+
+// SCIMGroupList is a page of SCIM Group resources
+type SCIMGroupList struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    []SCIMGroup `json:"Resources"`
+}
@@ -0,0 +1,232 @@
+package iam
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	validator "github.com/go-playground/validator/v10"
+)
+
+const (
+	scimAPIVersion = "2"
+)
+
+// SCIMService provides operations on the SCIM v2 Users and Groups endpoints
+type SCIMService struct {
+	client *Client
+
+	validate *validator.Validate
+}
+
+// GetSCIMOptions describes the criteria for SCIM search queries
+type GetSCIMOptions struct {
+	Filter             *string `url:"filter,omitempty"`
+	Attributes         *string `url:"attributes,omitempty"`
+	ExcludedAttributes *string `url:"excludedAttributes,omitempty"`
+	SortBy             *string `url:"sortBy,omitempty"`
+	SortOrder          *string `url:"sortOrder,omitempty" enum:"ascending|descending"`
+	StartIndex         *int    `url:"startIndex,omitempty"`
+	Count              *int    `url:"count,omitempty"`
+}
+
+// scimStringEscaper escapes SCIM filter string values, which follow JSON string syntax
+var scimStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// SCIMFilterEq returns search options filtering on attribute equality.
+// Backslashes and quotes in value are escaped
+func SCIMFilterEq(attribute, value string) *GetSCIMOptions {
+	query := attribute + " eq \"" + scimStringEscaper.Replace(value) + "\""
+	return &GetSCIMOptions{
+		Filter: &query,
+	}
+}
+
+// SCIM PATCH operations
+const (
+	SCIMOpAdd     = "add"
+	SCIMOpRemove  = "remove"
+	SCIMOpReplace = "replace"
+)
+
+func (s *SCIMService) scimRequest(method, path string, opt interface{}, options []OptionFunc) (*http.Request, error) {
+	req, err := s.client.newRequest(IDM, method, scimBasePath+path, opt, options)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("api-version", scimAPIVersion)
+	req.Header.Set("Accept", "application/scim+json")
+	if req.Body != nil {
+		req.Header.Set("Content-Type", "application/scim+json")
+	}
+	return req, nil
+}
+
+func (s *SCIMService) patch(path string, operations []SCIMPatchOperation, v interface{}, options []OptionFunc) (*Response, error) {
+	body := scimPatchRequest{
+		Schemas:    []string{SCIMPatchOpSchema},
+		Operations: operations,
+	}
+	if err := s.validate.Struct(body); err != nil {
+		return nil, err
+	}
+	req, err := s.scimRequest("PATCH", path, &body, options)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.do(req, v)
+}
+
+func (s *SCIMService) delete(path string, options []OptionFunc) (bool, *Response, error) {
+	req, err := s.scimRequest("DELETE", path, nil, options)
+	if err != nil {
+		return false, nil, err
+	}
+	var deleteResponse bytes.Buffer
+
+	resp, err := s.client.do(req, &deleteResponse)
+	if resp == nil || resp.StatusCode != http.StatusNoContent {
+		return false, resp, err
+	}
+	return true, resp, nil
+}
+
+// CreateUser provisions a new user through SCIM
+func (s *SCIMService) CreateUser(user SCIMUser, options ...OptionFunc) (*SCIMUser, *Response, error) {
+	if len(user.Schemas) == 0 {
+		user.Schemas = []string{SCIMUserSchema}
+	}
+	if err := s.validate.Struct(user); err != nil {
+		return nil, nil, err
+	}
+	req, err := s.scimRequest("POST", "Users", &user, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	var createdUser SCIMUser
+
+	resp, err := s.client.do(req, &createdUser)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &createdUser, resp, nil
+}
+
+// GetUserByID retrieves a SCIM User by ID
+func (s *SCIMService) GetUserByID(id string, options ...OptionFunc) (*SCIMUser, *Response, error) {
+	req, err := s.scimRequest("GET", "Users/"+id, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	var user SCIMUser
+
+	resp, err := s.client.do(req, &user)
+	if err != nil {
+		return nil, resp, err
+	}
+	if user.ID != id {
+		return nil, resp, ErrNotFound
+	}
+	return &user, resp, nil
+}
+
+// GetUsers searches SCIM Users. Use the Filter field of opt for SCIM filter queries
+func (s *SCIMService) GetUsers(opt *GetSCIMOptions, options ...OptionFunc) (*SCIMUserList, *Response, error) {
+	req, err := s.scimRequest("GET", "Users", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	var list SCIMUserList
+
+	resp, err := s.client.do(req, &list)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &list, resp, nil
+}
+
+// PatchUser applies the given operations to a SCIM User
+func (s *SCIMService) PatchUser(id string, operations []SCIMPatchOperation, options ...OptionFunc) (*SCIMUser, *Response, error) {
+	var patchedUser SCIMUser
+
+	resp, err := s.patch("Users/"+id, operations, &patchedUser, options)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &patchedUser, resp, nil
+}
+
+// DeleteUser deprovisions a SCIM User
+func (s *SCIMService) DeleteUser(user SCIMUser, options ...OptionFunc) (bool, *Response, error) {
+	return s.delete("Users/"+user.ID, options)
+}
+
+// CreateGroup creates a new group through SCIM
+func (s *SCIMService) CreateGroup(group SCIMGroup, options ...OptionFunc) (*SCIMGroup, *Response, error) {
+	if len(group.Schemas) == 0 {
+		group.Schemas = []string{SCIMGroupSchema}
+	}
+	if err := s.validate.Struct(group); err != nil {
+		return nil, nil, err
+	}
+	req, err := s.scimRequest("POST", "Groups", &group, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	var createdGroup SCIMGroup
+
+	resp, err := s.client.do(req, &createdGroup)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &createdGroup, resp, nil
+}
+
+// GetGroupByID retrieves a SCIM Group by ID
+func (s *SCIMService) GetGroupByID(id string, options ...OptionFunc) (*SCIMGroup, *Response, error) {
+	req, err := s.scimRequest("GET", "Groups/"+id, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	var group SCIMGroup
+
+	resp, err := s.client.do(req, &group)
+	if err != nil {
+		return nil, resp, err
+	}
+	if group.ID != id {
+		return nil, resp, ErrNotFound
+	}
+	return &group, resp, nil
+}
+
+// GetGroups searches SCIM Groups. Use the Filter field of opt for SCIM filter queries
+func (s *SCIMService) GetGroups(opt *GetSCIMOptions, options ...OptionFunc) (*SCIMGroupList, *Response, error) {
+	req, err := s.scimRequest("GET", "Groups", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	var list SCIMGroupList
+
+	resp, err := s.client.do(req, &list)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &list, resp, nil
+}
+
+// PatchGroup applies the given operations to a SCIM Group
+func (s *SCIMService) PatchGroup(id string, operations []SCIMPatchOperation, options ...OptionFunc) (*SCIMGroup, *Response, error) {
+	var patchedGroup SCIMGroup
+
+	resp, err := s.patch("Groups/"+id, operations, &patchedGroup, options)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &patchedGroup, resp, nil
+}
+
+// DeleteGroup deletes a SCIM Group
+func (s *SCIMService) DeleteGroup(group SCIMGroup, options ...OptionFunc) (bool, *Response, error) {
+	return s.delete("Groups/"+group.ID, options)
+}
@@ -0,0 +1,198 @@
+package iam
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSCIMUsers(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	userID := "f7a2fee2-2b21-4a06-8525-a7a7fbc6a3b8"
+	userJSON := `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"id": "` + userID + `",
+		"userName": "ron.swanson",
+		"name": {
+			"familyName": "Swanson",
+			"givenName": "Ron"
+		},
+		"emails": [{"value": "ron@pawnee.gov", "primary": true}],
+		"active": true,
+		"meta": {
+			"resourceType": "User",
+			"version": "W/\"1\""
+		}
+	}`
+
+	muxIDM.HandleFunc("/authorize/scim/v2/Users", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/scim+json")
+		switch r.Method {
+		case "POST":
+			assert.Equal(t, "application/scim+json", r.Header.Get("Content-Type"))
+			var user SCIMUser
+			err := json.NewDecoder(r.Body).Decode(&user)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{SCIMUserSchema}, user.Schemas)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, userJSON)
+		case "GET":
+			assert.Equal(t, `userName eq "ron.swanson"`, r.URL.Query().Get("filter"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{
+				"schemas": ["urn:ietf:params:scim:api:messages:2.0:ListResponse"],
+				"totalResults": 1,
+				"startIndex": 1,
+				"itemsPerPage": 1,
+				"Resources": [`+userJSON+`]
+			}`)
+		}
+	})
+	muxIDM.HandleFunc("/authorize/scim/v2/Users/"+userID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/scim+json")
+		switch r.Method {
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, userJSON)
+		case "PATCH":
+			var patch scimPatchRequest
+			err := json.NewDecoder(r.Body).Decode(&patch)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{SCIMPatchOpSchema}, patch.Schemas)
+			if assert.Len(t, patch.Operations, 1) {
+				assert.Equal(t, SCIMOpReplace, patch.Operations[0].Op)
+				assert.Equal(t, "active", patch.Operations[0].Path)
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, userJSON)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	user, resp, err := client.SCIM.CreateUser(SCIMUser{
+		UserName: "ron.swanson",
+		Name: SCIMName{
+			FamilyName: "Swanson",
+			GivenName:  "Ron",
+		},
+		Emails: []SCIMMultiValued{{Value: "ron@pawnee.gov", Primary: true}},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	if !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, userID, user.ID)
+
+	_, _, err = client.SCIM.CreateUser(SCIMUser{UserName: "ron.swanson"})
+	assert.NotNil(t, err)
+
+	user, _, err = client.SCIM.GetUserByID(userID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "Swanson", user.Name.FamilyName)
+
+	list, _, err := client.SCIM.GetUsers(SCIMFilterEq("userName", "ron.swanson"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, 1, list.TotalResults)
+	assert.Len(t, list.Resources, 1)
+
+	user, _, err = client.SCIM.PatchUser(userID, []SCIMPatchOperation{
+		{Op: SCIMOpReplace, Path: "active", Value: false},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotNil(t, user)
+
+	_, _, err = client.SCIM.PatchUser(userID, []SCIMPatchOperation{})
+	assert.NotNil(t, err)
+
+	ok, _, err := client.SCIM.DeleteUser(*user)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestSCIMGroups(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	groupID := "0fd9c9ba-9eb3-4f8b-8a08-2d1e4b5a7e3a"
+	userID := "f7a2fee2-2b21-4a06-8525-a7a7fbc6a3b8"
+	groupJSON := `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+		"id": "` + groupID + `",
+		"displayName": "Parks",
+		"members": [{"value": "` + userID + `", "type": "User"}]
+	}`
+
+	muxIDM.HandleFunc("/authorize/scim/v2/Groups", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/scim+json")
+		switch r.Method {
+		case "POST":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, groupJSON)
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{
+				"totalResults": 1,
+				"Resources": [`+groupJSON+`]
+			}`)
+		}
+	})
+	muxIDM.HandleFunc("/authorize/scim/v2/Groups/"+groupID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/scim+json")
+		switch r.Method {
+		case "GET", "PATCH":
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, groupJSON)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	group, _, err := client.SCIM.CreateGroup(SCIMGroup{DisplayName: "Parks"})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, groupID, group.ID)
+
+	group, _, err = client.SCIM.GetGroupByID(groupID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, group.Members, 1)
+
+	list, _, err := client.SCIM.GetGroups(SCIMFilterEq("displayName", "Parks"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, list.Resources, 1)
+
+	group, _, err = client.SCIM.PatchGroup(groupID, []SCIMPatchOperation{
+		{Op: SCIMOpAdd, Path: "members", Value: []SCIMMember{{Value: userID}}},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	ok, _, err := client.SCIM.DeleteGroup(*group)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestSCIMFilterEq(t *testing.T) {
+	assert.Equal(t, `userName eq "ron"`, *SCIMFilterEq("userName", "ron").Filter)
+	assert.Equal(t, `displayName eq "Parks \"and\" Rec"`, *SCIMFilterEq("displayName", `Parks "and" Rec`).Filter)
+	assert.Equal(t, `userName eq "a\\\" or userName pr or \""`, *SCIMFilterEq("userName", `a\" or userName pr or "`).Filter)
+}
@@ -0,0 +1,62 @@
+package iam
+
+import (
+	"net/http"
+)
+
+// SecretProvider returns the current OAuth2 client secret, e.g. from a vault
+type SecretProvider func() (string, error)
+
+// invalidClientError is returned by token requests which IAM rejected because
+// of the client credentials
+type invalidClientError struct {
+	err error
+}
+
+func (e *invalidClientError) Error() string { return e.err.Error() }
+
+func (e *invalidClientError) Unwrap() error { return e.err }
+
+// isInvalidClient returns true if a failed token request was rejected because of the client credentials
+func isInvalidClient(resp *Response) bool {
+	if resp == nil || resp.Response == nil {
+		return false
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return true
+	}
+	if resp.StatusCode != http.StatusBadRequest || resp.Body == nil {
+		return false
+	}
+	var errResponse struct {
+		Error string `json:"error"`
+	}
+	if err := decodePreservingBody(resp, &errResponse); err != nil {
+		return false
+	}
+	return errResponse.Error == "invalid_client"
+}
+
+func (c *Client) oauth2Secret() string {
+	c.tokenLock.RLock()
+	defer c.tokenLock.RUnlock()
+	return c.config.OAuth2Secret
+}
+
+// rotateSecret fetches the secret from the SecretProvider and returns true if it changed
+func (c *Client) rotateSecret() (bool, error) {
+	if c.config.SecretProvider == nil {
+		return false, nil
+	}
+	secret, err := c.config.SecretProvider()
+	if err != nil {
+		return false, err
+	}
+	c.tokenLock.Lock()
+	defer c.tokenLock.Unlock()
+	if secret == "" || secret == c.config.OAuth2Secret {
+		return false, nil
+	}
+	c.config.OAuth2Secret = secret
+	return true, nil
+}
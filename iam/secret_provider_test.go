@@ -0,0 +1,85 @@
+package iam
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretProviderRotation(t *testing.T) {
+	muxIAM = http.NewServeMux()
+	serverIAM = httptest.NewServer(muxIAM)
+	muxIDM = http.NewServeMux()
+	serverIDM = httptest.NewServer(muxIDM)
+
+	defer serverIAM.Close()
+	defer serverIDM.Close()
+
+	currentSecret := "Secret"
+	tokenRequests := 0
+	muxIAM.HandleFunc("/authorize/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		w.Header().Set("Content-Type", "application/json")
+		if _, secret, _ := r.BasicAuth(); secret != currentSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"error": "invalid_client"}`)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{
+			"scope": "mail",
+			"access_token": "44d20214-7879-4e35-923d-f9d4e01c9746",
+			"refresh_token": "31f1a449-ef8e-4bfc-a227-4f2353fde547",
+			"expires_in": 1799,
+			"token_type": "Bearer"
+		}`)
+	})
+
+	providerCalls := 0
+	providedSecret := "Secret"
+	var providerErr error
+	client, err := NewClient(nil, &Config{
+		OAuth2ClientID: "TestClient",
+		OAuth2Secret:   "Secret",
+		IAMURL:         serverIAM.URL,
+		IDMURL:         serverIDM.URL,
+		SecretProvider: func() (string, error) {
+			providerCalls++
+			return providedSecret, providerErr
+		},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	if !assert.Nil(t, client.Login("username", "password")) {
+		return
+	}
+	assert.Nil(t, client.TokenRefresh())
+	assert.Equal(t, 0, providerCalls)
+
+	// Secret rotated on the IAM side
+	currentSecret = "Rotated"
+	providedSecret = "Rotated"
+	tokenRequests = 0
+	assert.Nil(t, client.TokenRefresh())
+	assert.Equal(t, 1, providerCalls)
+	assert.Equal(t, 2, tokenRequests)
+	assert.Equal(t, "Rotated", client.oauth2Secret())
+
+	// Provider does not know a newer secret
+	currentSecret = "RotatedAgain"
+	tokenRequests = 0
+	assert.NotNil(t, client.TokenRefresh())
+	assert.Equal(t, 2, providerCalls)
+	assert.Equal(t, 1, tokenRequests)
+
+	// Provider fails
+	providerErr = errors.New("vault unavailable")
+	assert.NotNil(t, client.TokenRefresh())
+	assert.Equal(t, 3, providerCalls)
+	assert.Equal(t, "Rotated", client.oauth2Secret())
+}
@@ -109,21 +109,21 @@ func (s *Service) GenerateJWT(accessTokenEndpoint string) (signedString string,
 }
 
 // GetServiceByID looks up a service by ID
-func (p *ServicesService) GetServiceByID(id string) (*Service, *Response, error) {
-	return p.GetService(&GetServiceOptions{ID: &id}, nil)
+func (p *ServicesService) GetServiceByID(id string, options ...OptionFunc) (*Service, *Response, error) {
+	return p.GetService(&GetServiceOptions{ID: &id}, options...)
 }
 
 // GetServiceByName looks up a service by name
-func (p *ServicesService) GetServiceByName(name string) (*Service, *Response, error) {
-	return p.GetService(&GetServiceOptions{Name: &name}, nil)
+func (p *ServicesService) GetServiceByName(name string, options ...OptionFunc) (*Service, *Response, error) {
+	return p.GetService(&GetServiceOptions{Name: &name}, options...)
 }
 
 // GetServicesByApplicationID finds all services which belong to the applicationID
-func (p *ServicesService) GetServicesByApplicationID(applicationID string) (*[]Service, *Response, error) {
+func (p *ServicesService) GetServicesByApplicationID(applicationID string, options ...OptionFunc) (*[]Service, *Response, error) {
 	opt := &GetServiceOptions{
 		ApplicationID: String(applicationID),
 	}
-	req, err := p.client.newRequest(IDM, "GET", "authorize/identity/Service", opt, nil)
+	req, err := p.client.newRequest(IDM, "GET", "authorize/identity/Service", opt, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -142,8 +142,8 @@ func (p *ServicesService) GetServicesByApplicationID(applicationID string) (*[]S
 }
 
 // CreateService creates a Service
-func (p *ServicesService) CreateService(service Service) (*Service, *Response, error) {
-	req, _ := p.client.newRequest(IDM, "POST", "authorize/identity/Service", &service, nil)
+func (p *ServicesService) CreateService(service Service, options ...OptionFunc) (*Service, *Response, error) {
+	req, _ := p.client.newRequest(IDM, "POST", "authorize/identity/Service", &service, options)
 	req.Header.Set("api-version", servicesAPIVersion)
 	req.Header.Set("Content-Type", "application/json")
 
@@ -204,12 +204,12 @@ type ServiceUpdateRequest struct {
 // SERVICE.WRITE
 // HSDP_IAM_ORGANIZATION.MGMT
 // Only the description and accessTokenLifetime values can be updated
-func (p *ServicesService) UpdateService(service Service) (*ServiceUpdateResponse, *Response, error) {
+func (p *ServicesService) UpdateService(service Service, options ...OptionFunc) (*ServiceUpdateResponse, *Response, error) {
 	updateRequest := ServiceUpdateRequest{
 		AccessTokenLifetime: service.AccessTokenLifetime,
 		Description:         service.Description,
 	}
-	req, _ := p.client.newRequest(IDM, http.MethodPut, "authorize/identity/Service/"+service.ID, &updateRequest, nil)
+	req, _ := p.client.newRequest(IDM, http.MethodPut, "authorize/identity/Service/"+service.ID, &updateRequest, options)
 	req.Header.Set("api-version", servicesAPIVersion)
 	req.Header.Set("Content-Type", "application/json")
 
@@ -223,8 +223,8 @@ func (p *ServicesService) UpdateService(service Service) (*ServiceUpdateResponse
 }
 
 // DeleteService deletes the given Service
-func (p *ServicesService) DeleteService(service Service) (bool, *Response, error) {
-	req, err := p.client.newRequest(IDM, "DELETE", "authorize/identity/Service/"+service.ID, nil, nil)
+func (p *ServicesService) DeleteService(service Service, options ...OptionFunc) (bool, *Response, error) {
+	req, err := p.client.newRequest(IDM, "DELETE", "authorize/identity/Service/"+service.ID, nil, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -241,7 +241,7 @@ func (p *ServicesService) DeleteService(service Service) (bool, *Response, error
 }
 
 // UpdateServiceCertificateDER updates the associated certificate of the service using raw DER
-func (p *ServicesService) UpdateServiceCertificateDER(service Service, derBytes []byte) (*Service, *Response, error) {
+func (p *ServicesService) UpdateServiceCertificateDER(service Service, derBytes []byte, options ...OptionFunc) (*Service, *Response, error) {
 	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
 
 	var request = struct {
@@ -249,7 +249,7 @@ func (p *ServicesService) UpdateServiceCertificateDER(service Service, derBytes
 	}{
 		Certificate: string(certPEM),
 	}
-	req, err := p.client.newRequest(IDM, "POST", "authorize/identity/Service/"+service.ID+"/$update-certificate", request, nil)
+	req, err := p.client.newRequest(IDM, "POST", "authorize/identity/Service/"+service.ID+"/$update-certificate", request, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -263,7 +263,7 @@ func (p *ServicesService) UpdateServiceCertificateDER(service Service, derBytes
 	if resp == nil || resp.StatusCode != http.StatusOK {
 		return nil, resp, err
 	}
-	return p.GetServiceByID(service.ID)
+	return p.GetServiceByID(service.ID, options...)
 }
 
 // UpdateServiceCertificate updates the associated certificate of the service
@@ -302,16 +302,16 @@ func (p *ServicesService) UpdateServiceCertificate(service Service, privateKey *
 }
 
 // AddScopes add scopes to the service
-func (p *ServicesService) AddScopes(service Service, scopes []string, defaultScopes []string) (bool, *Response, error) {
-	return p.updateScopes(service, "add", scopes, defaultScopes)
+func (p *ServicesService) AddScopes(service Service, scopes []string, defaultScopes []string, options ...OptionFunc) (bool, *Response, error) {
+	return p.updateScopes(service, "add", scopes, defaultScopes, options)
 }
 
 // RemoveScopes add scopes to the service
-func (p *ServicesService) RemoveScopes(service Service, scopes []string, defaultScopes []string) (bool, *Response, error) {
-	return p.updateScopes(service, "remove", scopes, defaultScopes)
+func (p *ServicesService) RemoveScopes(service Service, scopes []string, defaultScopes []string, options ...OptionFunc) (bool, *Response, error) {
+	return p.updateScopes(service, "remove", scopes, defaultScopes, options)
 }
 
-func (p *ServicesService) updateScopes(service Service, action string, scopes []string, defaultScopes []string) (bool, *Response, error) {
+func (p *ServicesService) updateScopes(service Service, action string, scopes []string, defaultScopes []string, options []OptionFunc) (bool, *Response, error) {
 	var requestBody = struct {
 		Action        string   `json:"action"`
 		Scopes        []string `json:"scopes,omitempty"`
@@ -321,7 +321,7 @@ func (p *ServicesService) updateScopes(service Service, action string, scopes []
 		scopes,
 		defaultScopes,
 	}
-	req, err := p.client.newRequest(IDM, "PUT", "authorize/identity/Service/"+service.ID+"/$scopes", requestBody, nil)
+	req, err := p.client.newRequest(IDM, "PUT", "authorize/identity/Service/"+service.ID+"/$scopes", requestBody, options)
 	if err != nil {
 		return false, nil, err
 	}
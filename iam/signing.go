@@ -0,0 +1,97 @@
+package iam
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	hsdpsigner "github.com/philips-software/go-hsdp-signer"
+)
+
+// HSDP API signature versions
+const (
+	// SignatureV1 signs the SignedDate header only
+	SignatureV1 = 1
+	// SignatureV2 additionally signs the query parameters, method and body
+	SignatureV2 = 2
+
+	signatureMaxAge = 15 * time.Minute
+)
+
+// newSigner creates the signer for the configured signature version
+func newSigner(config *Config, options ...func(*hsdpsigner.Signer) error) (*hsdpsigner.Signer, error) {
+	switch config.SignatureVersion {
+	case 0, SignatureV1:
+	case SignatureV2:
+		options = append(options, hsdpsigner.SignParam(), hsdpsigner.SignMethod(), hsdpsigner.SignBody())
+	default:
+		return nil, ErrUnsupportedSignatureVersion
+	}
+	return hsdpsigner.New(config.SharedKey, config.SecretKey, options...)
+}
+
+// ValidateSignedRequest validates the HSDP API signature of an incoming request
+// against the shared and secret key of the client. Both signature versions are
+// accepted and signatures are compared in constant time
+func (c *Client) ValidateSignedRequest(req *http.Request) (bool, error) {
+	authorization := req.Header.Get(hsdpsigner.HeaderAuthorization)
+	comps := strings.Split(authorization, ";")
+	if len(comps) < 4 || comps[0] != hsdpsigner.AlgorithmName {
+		return false, hsdpsigner.ErrInvalidSignature
+	}
+	credential := strings.TrimPrefix(comps[1], "Credential:")
+	if !hmac.Equal([]byte(credential), []byte(c.config.SharedKey)) {
+		return false, hsdpsigner.ErrInvalidCredential
+	}
+	signedDate := req.Header.Get(hsdpsigner.HeaderSignedDate)
+	signedAt, err := time.Parse(hsdpsigner.TimeFormat, signedDate)
+	if err != nil {
+		return false, hsdpsigner.ErrInvalidSignature
+	}
+	if time.Since(signedAt) > signatureMaxAge {
+		return false, hsdpsigner.ErrSignatureExpired
+	}
+
+	options := []func(*hsdpsigner.Signer) error{
+		hsdpsigner.WithNowFunc(func() time.Time { return signedAt }),
+	}
+	var headers []string
+	for _, part := range strings.Split(strings.TrimPrefix(comps[2], "SignedHeaders:"), ",") {
+		switch part {
+		case hsdpsigner.HeaderSignedDate:
+		case "param":
+			options = append(options, hsdpsigner.SignParam())
+		case "method":
+			options = append(options, hsdpsigner.SignMethod())
+		case "body":
+			options = append(options, hsdpsigner.SignBody())
+		default:
+			headers = append(headers, part)
+		}
+	}
+	options = append(options, hsdpsigner.SignHeaders(headers...))
+	verifier, err := hsdpsigner.New(c.config.SharedKey, c.config.SecretKey, options...)
+	if err != nil {
+		return false, err
+	}
+
+	verify := req.Clone(req.Context())
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return false, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		verify.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if err := verifier.SignRequest(verify); err != nil {
+		return false, err
+	}
+	if !hmac.Equal([]byte(verify.Header.Get(hsdpsigner.HeaderAuthorization)), []byte(authorization)) {
+		return false, hsdpsigner.ErrInvalidSignature
+	}
+	return true, nil
+}
@@ -0,0 +1,81 @@
+package iam
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	hsdpsigner "github.com/philips-software/go-hsdp-signer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignatureVersions(t *testing.T) {
+	signedAt := func() time.Time { return time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC) }
+	vectors := []struct {
+		version   int
+		signature string
+	}{
+		{SignatureV1, "HmacSHA256;Credential:shared;SignedHeaders:SignedDate;Signature:WLsOIPjHvd7NZ9BY13qqJSUVAvUE3yu3XhNgZZj2W6A="},
+		{SignatureV2, "HmacSHA256;Credential:shared;SignedHeaders:SignedDate,param,method,body;Signature:t53/zw3W3y71Y3uEkPCIgbiBl7ygSXN7zwiOqkTAuic="},
+	}
+	for _, v := range vectors {
+		s, err := newSigner(&Config{SharedKey: "shared", SecretKey: "secret", SignatureVersion: v.version}, hsdpsigner.WithNowFunc(signedAt))
+		if !assert.Nil(t, err) {
+			return
+		}
+		req, _ := http.NewRequest("POST", "https://idm.example.com/authorize/identity/User?loginId=ron", strings.NewReader(`{"loginId":"ron"}`))
+		assert.Nil(t, s.SignRequest(req))
+		assert.Equal(t, v.signature, req.Header.Get(hsdpsigner.HeaderAuthorization))
+		assert.Equal(t, "2022-07-01T12:00:00Z", req.Header.Get(hsdpsigner.HeaderSignedDate))
+	}
+
+	_, err := newSigner(&Config{SharedKey: "shared", SecretKey: "secret", SignatureVersion: 3})
+	assert.Equal(t, ErrUnsupportedSignatureVersion, err)
+	_, err = NewClient(nil, &Config{SharedKey: "shared", SecretKey: "secret", SignatureVersion: 3, IAMURL: "https://iam.example.com", IDMURL: "https://idm.example.com"})
+	assert.Equal(t, ErrUnsupportedSignatureVersion, err)
+}
+
+func TestValidateSignedRequest(t *testing.T) {
+	c, err := NewClient(nil, &Config{
+		SharedKey:        "shared",
+		SecretKey:        "secret",
+		SignatureVersion: SignatureV2,
+		IAMURL:           "https://iam.example.com",
+		IDMURL:           "https://idm.example.com",
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, version := range []int{SignatureV1, SignatureV2} {
+		s, _ := newSigner(&Config{SharedKey: "shared", SecretKey: "secret", SignatureVersion: version})
+		req, _ := http.NewRequest("POST", "https://svc.example.com/callback?id=1", strings.NewReader(`{"status":"ok"}`))
+		assert.Nil(t, s.SignRequest(req))
+
+		ok, err := c.ValidateSignedRequest(req)
+		assert.Nil(t, err)
+		assert.True(t, ok)
+
+		if version == SignatureV2 {
+			tampered, _ := http.NewRequest("POST", "https://svc.example.com/callback?id=2", strings.NewReader(`{"status":"ok"}`))
+			tampered.Header = req.Header.Clone()
+			ok, err = c.ValidateSignedRequest(tampered)
+			assert.Equal(t, hsdpsigner.ErrInvalidSignature, err)
+			assert.False(t, ok)
+		}
+	}
+
+	other, _ := newSigner(&Config{SharedKey: "other", SecretKey: "secret"})
+	req, _ := http.NewRequest("GET", "https://svc.example.com/callback", nil)
+	_ = other.SignRequest(req)
+	ok, err := c.ValidateSignedRequest(req)
+	assert.Equal(t, hsdpsigner.ErrInvalidCredential, err)
+	assert.False(t, ok)
+
+	old, _ := newSigner(&Config{SharedKey: "shared", SecretKey: "secret"},
+		hsdpsigner.WithNowFunc(func() time.Time { return time.Now().Add(-time.Hour) }))
+	req, _ = http.NewRequest("GET", "https://svc.example.com/callback", nil)
+	_ = old.SignRequest(req)
+	_, err = c.ValidateSignedRequest(req)
+	assert.Equal(t, hsdpsigner.ErrSignatureExpired, err)
+}
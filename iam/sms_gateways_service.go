@@ -64,7 +64,7 @@ func SMSGatewayFilterOrgEq(orgID string) *GetSMSGatewayOptions {
 }
 
 // CreateSMSGateway creates a SMS gateway for IAM
-func (o *SMSGatewaysService) CreateSMSGateway(gw SMSGateway) (*SMSGateway, *Response, error) {
+func (o *SMSGatewaysService) CreateSMSGateway(gw SMSGateway, options ...OptionFunc) (*SMSGateway, *Response, error) {
 	gw.Schemas = []string{
 		"urn:ietf:params:scim:schemas:core:philips:hsdp:2.0:SMSGateway",
 	}
@@ -72,7 +72,7 @@ func (o *SMSGatewaysService) CreateSMSGateway(gw SMSGateway) (*SMSGateway, *Resp
 		return nil, nil, err
 	}
 
-	req, err := o.client.newRequest(IDM, "POST", "authorize/scim/v2/Configurations/SMSGateway", &gw, nil)
+	req, err := o.client.newRequest(IDM, "POST", "authorize/scim/v2/Configurations/SMSGateway", &gw, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -92,8 +92,8 @@ func (o *SMSGatewaysService) CreateSMSGateway(gw SMSGateway) (*SMSGateway, *Resp
 }
 
 // DeleteSMSGateway deletes the SMS gateway
-func (o *SMSGatewaysService) DeleteSMSGateway(gw SMSGateway) (bool, *Response, error) {
-	req, err := o.client.newRequest(IDM, "DELETE", "authorize/scim/v2/Configurations/SMSGateway/"+gw.ID, nil, nil)
+func (o *SMSGatewaysService) DeleteSMSGateway(gw SMSGateway, options ...OptionFunc) (bool, *Response, error) {
+	req, err := o.client.newRequest(IDM, "DELETE", "authorize/scim/v2/Configurations/SMSGateway/"+gw.ID, nil, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -111,11 +111,11 @@ func (o *SMSGatewaysService) DeleteSMSGateway(gw SMSGateway) (bool, *Response, e
 }
 
 // UpdateSMSGateway updates the SMS gateway
-func (o *SMSGatewaysService) UpdateSMSGateway(gw SMSGateway) (*SMSGateway, *Response, error) {
+func (o *SMSGatewaysService) UpdateSMSGateway(gw SMSGateway, options ...OptionFunc) (*SMSGateway, *Response, error) {
 	gw.Schemas = []string{
 		"urn:ietf:params:scim:schemas:core:philips:hsdp:2.0:SMSGateway",
 	}
-	req, err := o.client.newRequest(IDM, "PUT", "authorize/scim/v2/Configurations/SMSGateway/"+gw.ID, &gw, nil)
+	req, err := o.client.newRequest(IDM, "PUT", "authorize/scim/v2/Configurations/SMSGateway/"+gw.ID, &gw, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -134,10 +134,10 @@ func (o *SMSGatewaysService) UpdateSMSGateway(gw SMSGateway) (*SMSGateway, *Resp
 }
 
 // GetSMSGatewayByID retrieves an SMS gateway by ID
-func (o *SMSGatewaysService) GetSMSGatewayByID(id string) (*SMSGateway, *Response, error) {
+func (o *SMSGatewaysService) GetSMSGatewayByID(id string, options ...OptionFunc) (*SMSGateway, *Response, error) {
 	var foundGW SMSGateway
 
-	req, err := o.client.newRequest(IDM, "GET", "authorize/scim/v2/Configurations/SMSGateway/"+id, nil, nil)
+	req, err := o.client.newRequest(IDM, "GET", "authorize/scim/v2/Configurations/SMSGateway/"+id, nil, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -172,5 +172,5 @@ func (o *SMSGatewaysService) GetSMSGateway(opt *GetSMSGatewayOptions, options ..
 		return nil, resp, ErrNotFound
 	}
 
-	return o.GetSMSGatewayByID(bundleResponse.Resources[0].ID)
+	return o.GetSMSGatewayByID(bundleResponse.Resources[0].ID, options...)
 }
@@ -50,7 +50,7 @@ func SMSTemplateFilterOrgTypeLang(orgID, templateType, locale string) *GetSMSTem
 }
 
 // CreateSMSTemplate creates a SMS template for IAM
-func (o *SMSTemplatesService) CreateSMSTemplate(template SMSTemplate) (*SMSTemplate, *Response, error) {
+func (o *SMSTemplatesService) CreateSMSTemplate(template SMSTemplate, options ...OptionFunc) (*SMSTemplate, *Response, error) {
 	template.Schemas = []string{
 		"urn:ietf:params:scim:schemas:core:philips:hsdp:2.0:SMSTemplate",
 	}
@@ -58,7 +58,7 @@ func (o *SMSTemplatesService) CreateSMSTemplate(template SMSTemplate) (*SMSTempl
 		return nil, nil, err
 	}
 
-	req, err := o.client.newRequest(IDM, "POST", "authorize/scim/v2/Configurations/SMSTemplate", &template, nil)
+	req, err := o.client.newRequest(IDM, "POST", "authorize/scim/v2/Configurations/SMSTemplate", &template, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -78,8 +78,8 @@ func (o *SMSTemplatesService) CreateSMSTemplate(template SMSTemplate) (*SMSTempl
 }
 
 // DeleteSMSTemplate deletes the SMS template
-func (o *SMSTemplatesService) DeleteSMSTemplate(template SMSTemplate) (bool, *Response, error) {
-	req, err := o.client.newRequest(IDM, "DELETE", "authorize/scim/v2/Configurations/SMSTemplate/"+template.ID, nil, nil)
+func (o *SMSTemplatesService) DeleteSMSTemplate(template SMSTemplate, options ...OptionFunc) (bool, *Response, error) {
+	req, err := o.client.newRequest(IDM, "DELETE", "authorize/scim/v2/Configurations/SMSTemplate/"+template.ID, nil, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -97,11 +97,11 @@ func (o *SMSTemplatesService) DeleteSMSTemplate(template SMSTemplate) (bool, *Re
 }
 
 // UpdateSMSTemplate updates the SMS template
-func (o *SMSTemplatesService) UpdateSMSTemplate(template SMSTemplate) (*SMSTemplate, *Response, error) {
+func (o *SMSTemplatesService) UpdateSMSTemplate(template SMSTemplate, options ...OptionFunc) (*SMSTemplate, *Response, error) {
 	template.Schemas = []string{
 		"urn:ietf:params:scim:schemas:core:philips:hsdp:2.0:SMSTemplate",
 	}
-	req, err := o.client.newRequest(IDM, "PUT", "authorize/scim/v2/Configurations/SMSTemplate/"+template.ID, &template, nil)
+	req, err := o.client.newRequest(IDM, "PUT", "authorize/scim/v2/Configurations/SMSTemplate/"+template.ID, &template, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -120,10 +120,10 @@ func (o *SMSTemplatesService) UpdateSMSTemplate(template SMSTemplate) (*SMSTempl
 }
 
 // GetSMSTemplateByID retrieves an SMS template by ID
-func (o *SMSTemplatesService) GetSMSTemplateByID(id string) (*SMSTemplate, *Response, error) {
+func (o *SMSTemplatesService) GetSMSTemplateByID(id string, options ...OptionFunc) (*SMSTemplate, *Response, error) {
 	var foundTemplate SMSTemplate
 
-	req, err := o.client.newRequest(IDM, "GET", "authorize/scim/v2/Configurations/SMSTemplate/"+id, nil, nil)
+	req, err := o.client.newRequest(IDM, "GET", "authorize/scim/v2/Configurations/SMSTemplate/"+id, nil, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -158,5 +158,5 @@ func (o *SMSTemplatesService) GetSMSTemplate(opt *GetSMSTemplateOptions, options
 		return nil, resp, ErrNotFound
 	}
 
-	return o.GetSMSTemplateByID(bundleResponse.Resources[0].ID)
+	return o.GetSMSTemplateByID(bundleResponse.Resources[0].ID, options...)
 }
@@ -0,0 +1,79 @@
+package iam
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RFC 8693 token type identifiers
+const (
+	TokenTypeAccessToken  = "urn:ietf:params:oauth:token-type:access_token"
+	TokenTypeRefreshToken = "urn:ietf:params:oauth:token-type:refresh_token"
+	TokenTypeIDToken      = "urn:ietf:params:oauth:token-type:id_token"
+	TokenTypeJWT          = "urn:ietf:params:oauth:token-type:jwt"
+
+	grantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+)
+
+// ExchangedToken is a token issued through ExchangeToken
+type ExchangedToken struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type"`
+	TokenType       string `json:"token_type"`
+	Scope           string `json:"scope"`
+	ExpiresIn       int64  `json:"expires_in"`
+	ExpiresAt       time.Time
+}
+
+// Scopes returns the scopes granted to the exchanged token
+func (t ExchangedToken) Scopes() []string {
+	return strings.Fields(t.Scope)
+}
+
+// ExchangeToken trades subjectToken, typically an access token of a user calling
+// this service, for a token scoped to the given scopes which can be used to call
+// downstream services on behalf of the user. The tokens of the client are not changed
+func (c *Client) ExchangeToken(subjectToken string, scopes []string, options ...OptionFunc) (*ExchangedToken, *Response, error) {
+	if subjectToken == "" {
+		return nil, nil, ErrMissingSubjectToken
+	}
+	if !c.HasOAuth2Credentials() {
+		return nil, nil, ErrMissingOAuth2Credentials
+	}
+	req, err := c.newRequest(IAM, "POST", "authorize/oauth2/token", nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	form := url.Values{}
+	form.Add("grant_type", grantTypeTokenExchange)
+	form.Add("subject_token", subjectToken)
+	form.Add("subject_token_type", TokenTypeAccessToken)
+	form.Add("requested_token_type", TokenTypeAccessToken)
+	if len(scopes) > 0 {
+		form.Add("scope", strings.Join(scopes, " "))
+	}
+	req.SetBasicAuth(c.config.OAuth2ClientID, c.oauth2Secret())
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Api-Version", loginAPIVersion)
+	req.Body = io.NopCloser(strings.NewReader(form.Encode()))
+	req.ContentLength = int64(len(form.Encode()))
+
+	var exchanged ExchangedToken
+
+	resp, err := c.do(req, &exchanged)
+	if err != nil {
+		return nil, resp, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp, fmt.Errorf("token exchange failed: %d", resp.StatusCode)
+	}
+	if exchanged.AccessToken == "" {
+		return nil, resp, ErrNotAuthorized
+	}
+	exchanged.ExpiresAt = time.Now().Add(time.Duration(exchanged.ExpiresIn) * time.Second)
+	return &exchanged, resp, nil
+}
@@ -0,0 +1,80 @@
+package iam
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExchangeToken(t *testing.T) {
+	muxIAM = http.NewServeMux()
+	serverIAM = httptest.NewServer(muxIAM)
+	muxIDM = http.NewServeMux()
+	serverIDM = httptest.NewServer(muxIDM)
+
+	defer serverIAM.Close()
+	defer serverIDM.Close()
+
+	userToken := "1ea3e7b5-7e9a-4a9d-8e3f-7d2a6c1f5b11"
+	exchangedToken := "f3c7a0d2-9f4b-4a55-b1e3-0c8d5e2a7b64"
+
+	cfg := &Config{
+		OAuth2ClientID: "TestClient",
+		OAuth2Secret:   "Secret",
+		IAMURL:         serverIAM.URL,
+		IDMURL:         serverIDM.URL,
+	}
+	client, err := NewClient(nil, cfg)
+	if !assert.Nil(t, err) {
+		return
+	}
+	client.SetTokens("service-token", "service-refresh", "", time.Now().Add(time.Hour).Unix())
+
+	muxIAM.HandleFunc("/authorize/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); !assert.Nil(t, err) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		clientID, secret, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "TestClient", clientID)
+		assert.Equal(t, "Secret", secret)
+		assert.Equal(t, grantTypeTokenExchange, r.Form.Get("grant_type"))
+		assert.Equal(t, TokenTypeAccessToken, r.Form.Get("subject_token_type"))
+		if r.Form.Get("subject_token") != userToken {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error":"invalid_grant"}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{
+			"access_token": "`+exchangedToken+`",
+			"issued_token_type": "`+TokenTypeAccessToken+`",
+			"token_type": "Bearer",
+			"scope": "`+r.Form.Get("scope")+`",
+			"expires_in": 1799
+		}`)
+	})
+
+	token, resp, err := client.ExchangeToken(userToken, []string{"cdr.read", "openid"})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, exchangedToken, token.AccessToken)
+	assert.Equal(t, []string{"cdr.read", "openid"}, token.Scopes())
+	assert.False(t, token.ExpiresAt.IsZero())
+	assert.Equal(t, "service-refresh", client.RefreshToken())
+
+	_, _, err = client.ExchangeToken("bogus", nil)
+	assert.NotNil(t, err)
+
+	_, _, err = client.ExchangeToken("", nil)
+	assert.ErrorIs(t, err, ErrMissingSubjectToken)
+}
@@ -0,0 +1,127 @@
+package iam
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// scopedTokenSource caches the token of a single scope combination
+type scopedTokenSource struct {
+	client *Client
+	scopes []string
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+// TokenSource returns an oauth2.TokenSource which issues tokens limited to scopes.
+// Tokens are requested on demand using the service identity of the client when it
+// logged in with ServiceLogin, or the client credentials otherwise. One token is
+// cached per scope combination and renewed shortly before it expires.
+// The tokens of the client itself are not changed
+func (c *Client) TokenSource(scopes ...string) oauth2.TokenSource {
+	sorted := append([]string{}, scopes...)
+	sort.Strings(sorted)
+	key := strings.Join(sorted, " ")
+
+	c.Lock()
+	defer c.Unlock()
+	if c.tokenSources == nil {
+		c.tokenSources = make(map[string]*scopedTokenSource)
+	}
+	if ts, ok := c.tokenSources[key]; ok {
+		return ts
+	}
+	ts := &scopedTokenSource{client: c, scopes: sorted}
+	c.tokenSources[key] = ts
+	return ts
+}
+
+// Token returns the cached token or requests a new one when it is about to expire
+func (s *scopedTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != nil && time.Until(s.token.Expiry) > 60*time.Second {
+		return s.token, nil
+	}
+	token, err := s.client.scopedToken(s.scopes)
+	if err != nil {
+		return nil, err
+	}
+	s.token = token
+	return token, nil
+}
+
+func (c *Client) scopedToken(scopes []string) (*oauth2.Token, error) {
+	c.tokenLock.RLock()
+	service := c.service
+	c.tokenLock.RUnlock()
+
+	u := *c.baseIAMURL
+	u.Opaque = c.baseIAMURL.Path + "authorize/oauth2/token"
+
+	req := &http.Request{
+		Method:     "POST",
+		URL:        &u,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Host:       u.Host,
+	}
+	form := url.Values{}
+	if len(scopes) > 0 {
+		form.Add("scope", strings.Join(scopes, " "))
+	}
+	var body string
+	if service.Valid() {
+		assertion, err := service.GenerateJWT(c.accessTokenEndpoint())
+		if err != nil {
+			return nil, err
+		}
+		// HSDP IAM currently croaks on URL encoded grant_type value. INC0038532
+		body = "assertion=" + assertion
+		body += "&grant_type=urn:ietf:params:oauth:grant-type:jwt-bearer"
+		body += "&"
+		body += form.Encode()
+	} else {
+		if !c.HasOAuth2Credentials() {
+			return nil, ErrMissingOAuth2Credentials
+		}
+		form.Add("grant_type", "client_credentials")
+		req.SetBasicAuth(c.config.OAuth2ClientID, c.oauth2Secret())
+		body = form.Encode()
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Api-Version", loginAPIVersion)
+	req.Body = io.NopCloser(strings.NewReader(body))
+	req.ContentLength = int64(len(body))
+
+	var tokenResponse tokenResponse
+
+	resp, err := c.do(req, &tokenResponse)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("login failed: %d", resp.StatusCode)
+	}
+	if tokenResponse.AccessToken == "" {
+		return nil, ErrNotAuthorized
+	}
+	token := &oauth2.Token{
+		AccessToken: tokenResponse.AccessToken,
+		TokenType:   tokenResponse.TokenType,
+		Expiry:      time.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second),
+	}
+	return token.WithExtra(map[string]interface{}{"scope": tokenResponse.Scope}), nil
+}
@@ -0,0 +1,79 @@
+package iam
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenSource(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	requests := map[string]int{}
+	expiresIn := 1799
+	mux.HandleFunc("/authorize/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if id, secret, ok := r.BasicAuth(); !ok || id != "TestClient" || secret != "Secret" ||
+			r.Form.Get("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"error": "invalid_client"}`)
+			return
+		}
+		scope := r.Form.Get("scope")
+		requests[scope]++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, fmt.Sprintf(`{
+			"scope": "%s",
+			"access_token": "token-%s-%d",
+			"expires_in": %d,
+			"token_type": "Bearer"
+		}`, scope, scope, requests[scope], expiresIn))
+	})
+
+	c, err := NewClient(nil, &Config{
+		OAuth2ClientID: "TestClient",
+		OAuth2Secret:   "Secret",
+		IAMURL:         server.URL,
+		IDMURL:         server.URL,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	cdr, err := c.TokenSource("cdr.read", "cdr.write").Token()
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "token-cdr.read cdr.write-1", cdr.AccessToken)
+	assert.Equal(t, "cdr.read cdr.write", cdr.Extra("scope"))
+	assert.WithinDuration(t, time.Now().Add(1799*time.Second), cdr.Expiry, 5*time.Second)
+
+	again, err := c.TokenSource("cdr.write", "cdr.read").Token()
+	assert.Nil(t, err)
+	assert.Equal(t, cdr.AccessToken, again.AccessToken)
+
+	tdr, err := c.TokenSource("tdr.contract").Token()
+	assert.Nil(t, err)
+	assert.Equal(t, "token-tdr.contract-1", tdr.AccessToken)
+	assert.Equal(t, map[string]int{"cdr.read cdr.write": 1, "tdr.contract": 1}, requests)
+	assert.Empty(t, c.token)
+
+	expiresIn = 30
+	short := c.TokenSource("logging")
+	first, _ := short.Token()
+	second, _ := short.Token()
+	assert.Equal(t, "token-logging-1", first.AccessToken)
+	assert.Equal(t, "token-logging-2", second.AccessToken)
+
+	noCreds, _ := NewClient(nil, &Config{IAMURL: server.URL, IDMURL: server.URL})
+	_, err = noCreds.TokenSource("cdr.read").Token()
+	assert.Equal(t, ErrMissingOAuth2Credentials, err)
+}
@@ -0,0 +1,125 @@
+package iam
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// StoredTokens are the tokens of a client persisted in a TokenStore
+type StoredTokens struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	IDToken      string    `json:"id_token,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	Scopes       []string  `json:"scopes,omitempty"`
+}
+
+// TokenStore persists the tokens of a client so they can be reused across
+// process invocations, e.g. by CLIs. Implementations can store the tokens in
+// a file, the OS keyring or any other secure location. Load returns nil tokens
+// when nothing has been stored yet
+type TokenStore interface {
+	Load() (*StoredTokens, error)
+	Save(tokens StoredTokens) error
+	Clear() error
+}
+
+// FileTokenStore stores tokens as JSON in a file only readable by the current user
+type FileTokenStore struct {
+	Path string
+}
+
+// NewFileTokenStore returns a TokenStore backed by the file at path
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{Path: path}
+}
+
+// Load reads the tokens from the file
+func (f *FileTokenStore) Load() (*StoredTokens, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var tokens StoredTokens
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, err
+	}
+	return &tokens, nil
+}
+
+// Save writes the tokens to the file
+func (f *FileTokenStore) Save(tokens StoredTokens) error {
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0700); err != nil {
+		return err
+	}
+	tmp := f.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.Path)
+}
+
+// Clear removes the file
+func (f *FileTokenStore) Clear() error {
+	err := os.Remove(f.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// loadStoredTokens restores the tokens from the configured TokenStore. Like saveTokens,
+// errors are ignored so e.g. a corrupt token file leaves the client unauthenticated
+func (c *Client) loadStoredTokens() {
+	if c.config.TokenStore == nil {
+		return
+	}
+	tokens, err := c.config.TokenStore.Load()
+	if err != nil || tokens == nil || tokens.AccessToken == "" {
+		return
+	}
+	c.tokenLock.Lock()
+	defer c.tokenLock.Unlock()
+	c.token = tokens.AccessToken
+	c.refreshToken = tokens.RefreshToken
+	c.idToken = tokens.IDToken
+	c.expiresAt = tokens.ExpiresAt
+	c.scopes = tokens.Scopes
+	c.tokenType = OAuthToken
+}
+
+// ClearTokenStore removes the persisted tokens, e.g. on logout.
+// The tokens of the client itself are not changed
+func (c *Client) ClearTokenStore() error {
+	if c.config.TokenStore == nil {
+		return nil
+	}
+	return c.config.TokenStore.Clear()
+}
+
+// saveTokens persists the current tokens in the configured TokenStore. Errors
+// are ignored so a failing store never breaks authentication
+func (c *Client) saveTokens() {
+	if c.config.TokenStore == nil {
+		return
+	}
+	info := c.TokenInfo()
+	_ = c.config.TokenStore.Save(StoredTokens{
+		AccessToken:  info.AccessToken,
+		RefreshToken: info.RefreshToken,
+		IDToken:      info.IDToken,
+		ExpiresAt:    info.ExpiresAt,
+		Scopes:       info.Scopes,
+	})
+}
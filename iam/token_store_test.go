@@ -0,0 +1,81 @@
+package iam
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileTokenStore(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	path := filepath.Join(t.TempDir(), "hsdp", "tokens.json")
+	store := NewFileTokenStore(path)
+
+	tokens, err := store.Load()
+	assert.Nil(t, err)
+	assert.Nil(t, tokens)
+
+	c, err := NewClient(nil, &Config{
+		OAuth2ClientID: "TestClient",
+		OAuth2Secret:   "Secret",
+		IAMURL:         serverIAM.URL,
+		IDMURL:         serverIDM.URL,
+		TokenStore:     store,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	if !assert.Nil(t, c.Login("username", "password")) {
+		return
+	}
+	info, err := os.Stat(path)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	stored, err := store.Load()
+	if !assert.Nil(t, err) || !assert.NotNil(t, stored) {
+		return
+	}
+	assert.Equal(t, token, stored.AccessToken)
+	assert.Equal(t, refreshToken, stored.RefreshToken)
+	assert.WithinDuration(t, time.Now().Add(1799*time.Second), stored.ExpiresAt, 5*time.Second)
+
+	restored, err := NewClient(nil, &Config{
+		OAuth2ClientID: "TestClient",
+		OAuth2Secret:   "Secret",
+		IAMURL:         serverIAM.URL,
+		IDMURL:         serverIDM.URL,
+		TokenStore:     store,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	accessToken, err := restored.Token()
+	assert.Nil(t, err)
+	assert.Equal(t, token, accessToken)
+	assert.Equal(t, refreshToken, restored.RefreshToken())
+
+	assert.Nil(t, restored.ClearTokenStore())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+	assert.Nil(t, store.Clear())
+	// A corrupt token file leaves the client unauthenticated instead of failing
+	assert.Nil(t, os.WriteFile(path, []byte("{corrupt"), 0600))
+	corrupt, err := NewClient(nil, &Config{
+		OAuth2ClientID: "TestClient",
+		OAuth2Secret:   "Secret",
+		IAMURL:         serverIAM.URL,
+		IDMURL:         serverIDM.URL,
+		TokenStore:     store,
+	})
+	if assert.Nil(t, err) && assert.NotNil(t, corrupt) {
+		assert.Empty(t, corrupt.TokenInfo().AccessToken)
+	}
+}
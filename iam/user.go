@@ -1,6 +1,8 @@
 package iam
 
 import (
+	"encoding/json"
+	"reflect"
 	"time"
 )
 
@@ -19,6 +21,57 @@ type User struct {
 	AccountStatus                 UserAccountStatus  `json:"accountStatus"`
 	ConsentedApps                 []string           `json:"consentedApps,omitempty"`
 	Delegations                   UserDelegation     `json:"delegations,omitempty"`
+	// Extensions holds custom attributes of the user
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON includes the extension attributes
+func (u User) MarshalJSON() ([]byte, error) {
+	type user User
+	data, err := json.Marshal(user(u))
+	if err != nil {
+		return nil, err
+	}
+	return marshalWithExtensions(data, reflect.TypeOf(u), u.Extensions)
+}
+
+// UnmarshalJSON collects unknown attributes in Extensions
+func (u *User) UnmarshalJSON(data []byte) error {
+	type user User
+	var decoded user
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, reflect.TypeOf(decoded))
+	if err != nil {
+		return err
+	}
+	*u = User(decoded)
+	u.Extensions = extensions
+	return nil
+}
+
+// person returns the Person representation of the user profile
+func (u User) person() Person {
+	person := Person{
+		ID:                            u.ID,
+		LoginID:                       u.LoginID,
+		ResourceType:                  "Person",
+		Name:                          u.Name,
+		ManagingOrganization:          u.ManagingOrganization,
+		PreferredLanguage:             u.PreferredLanguage,
+		PreferredCommunicationChannel: u.PreferredCommunicationChannel,
+		Disabled:                      u.AccountStatus.Disabled,
+		Loaded:                        true,
+		Extensions:                    u.Extensions,
+	}
+	if u.EmailAddress != "" {
+		person.Telecom = append(person.Telecom, TelecomEntry{System: "email", Value: u.EmailAddress})
+	}
+	if u.PhoneNumber != "" {
+		person.Telecom = append(person.Telecom, TelecomEntry{System: "mobile", Value: u.PhoneNumber})
+	}
+	return person
 }
 
 type UserDelegation struct {
@@ -74,6 +127,34 @@ type Person struct {
 	Password                      string         `json:"password,omitempty"`
 	Disabled                      bool           `json:"disabled"`
 	Loaded                        bool           `json:"-"`
+	// Extensions holds custom attributes which are sent along on create
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON includes the extension attributes
+func (p Person) MarshalJSON() ([]byte, error) {
+	type person Person
+	data, err := json.Marshal(person(p))
+	if err != nil {
+		return nil, err
+	}
+	return marshalWithExtensions(data, reflect.TypeOf(p), p.Extensions)
+}
+
+// UnmarshalJSON collects unknown attributes in Extensions
+func (p *Person) UnmarshalJSON(data []byte) error {
+	type person Person
+	var decoded person
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, reflect.TypeOf(decoded))
+	if err != nil {
+		return err
+	}
+	*p = Person(decoded)
+	p.Extensions = extensions
+	return nil
 }
 
 // Contact describes contact details of a Profile
@@ -170,6 +251,33 @@ type Name struct {
 	Prefix string `json:"prefix,omitempty"`
 }
 
+// UserPatch describes a partial update of a user with JSON merge patch semantics.
+// Only the fields which are set are sent, all other attributes are left untouched
+type UserPatch struct {
+	Name                          *NamePatch `json:"name,omitempty"`
+	Description                   *string    `json:"description,omitempty" validate:"omitempty,max=250"`
+	PreferredLanguage             *string    `json:"preferredLanguage,omitempty"`
+	PreferredCommunicationChannel *string    `json:"preferredCommunicationChannel,omitempty" validate:"omitempty,oneof=email sms"`
+	IsAgeValidated                *string    `json:"isAgeValidated,omitempty" validate:"omitempty,oneof=true false"`
+}
+
+// NamePatch describes a partial update of the name of a user
+type NamePatch struct {
+	Text   *string `json:"text,omitempty"`
+	Family *string `json:"family,omitempty" validate:"omitempty,min=1"`
+	Given  *string `json:"given,omitempty" validate:"omitempty,min=1"`
+	Prefix *string `json:"prefix,omitempty"`
+}
+
+// IsEmpty returns true if the patch contains no changes
+func (p UserPatch) IsEmpty() bool {
+	if p.Name != nil && (p.Name.Text != nil || p.Name.Family != nil || p.Name.Given != nil || p.Name.Prefix != nil) {
+		return false
+	}
+	return p.Description == nil && p.PreferredLanguage == nil &&
+		p.PreferredCommunicationChannel == nil && p.IsAgeValidated == nil
+}
+
 // TelecomEntry entity
 type TelecomEntry struct {
 	System string `json:"system" enum:"mobile|fax|email|url"`
@@ -0,0 +1,113 @@
+package iam
+
+import (
+	"context"
+	"strconv"
+)
+
+const defaultIteratorPageSize = 100
+
+// UserIterator walks over all user UUIDs matching a search, fetching
+// additional pages from IAM on demand
+type UserIterator struct {
+	service *UsersService
+	ctx     context.Context
+	opts    GetUserOptions
+	options []OptionFunc
+
+	page       []string
+	index      int
+	pageNumber int
+	lastPage   bool
+
+	current string
+	resp    *Response
+	err     error
+}
+
+// UserIterator returns an iterator over all users matching opts. The PageSize field
+// of opts controls how many users are fetched per request and defaults to 100.
+// Iteration stops when ctx is cancelled.
+func (u *UsersService) UserIterator(ctx context.Context, opts *GetUserOptions, options ...OptionFunc) *UserIterator {
+	it := &UserIterator{
+		service:    u,
+		ctx:        ctx,
+		options:    append([]OptionFunc{WithContext(ctx)}, options...),
+		pageNumber: 1,
+	}
+	if opts != nil {
+		it.opts = *opts
+	}
+	if it.opts.PageSize == nil {
+		it.opts.PageSize = String(strconv.Itoa(defaultIteratorPageSize))
+	}
+	if it.opts.PageNumber != nil {
+		if n, err := strconv.Atoi(*it.opts.PageNumber); err == nil && n > 0 {
+			it.pageNumber = n
+		}
+	}
+	return it
+}
+
+// Next advances the iterator. It returns false when all users have been
+// visited or an error occurred. Check Err() to distinguish between the two.
+func (it *UserIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+	for it.index >= len(it.page) {
+		if it.lastPage {
+			return false
+		}
+		if !it.fetch() {
+			return false
+		}
+	}
+	it.current = it.page[it.index]
+	it.index++
+	return true
+}
+
+func (it *UserIterator) fetch() bool {
+	it.opts.PageNumber = String(strconv.Itoa(it.pageNumber))
+	list, resp, err := it.service.GetUsers(&it.opts, it.options...)
+	it.resp = resp
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.page = list.UserUUIDs
+	it.index = 0
+	// An empty page ends the iteration even if IAM claims there are more
+	it.lastPage = !list.HasNextPage || len(list.UserUUIDs) == 0
+	it.pageNumber++
+	return true
+}
+
+// UserUUID returns the UUID of the current user
+func (it *UserIterator) UserUUID() string {
+	return it.current
+}
+
+// Response returns the response of the last page request
+func (it *UserIterator) Response() *Response {
+	return it.resp
+}
+
+// Err returns the error, if any, that stopped the iteration
+func (it *UserIterator) Err() error {
+	return it.err
+}
+
+// All collects the remaining user UUIDs of the iterator
+func (it *UserIterator) All() ([]string, error) {
+	var users []string
+	for it.Next() {
+		users = append(users, it.UserUUID())
+	}
+	return users, it.Err()
+}
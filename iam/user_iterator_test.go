@@ -0,0 +1,89 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserIterator(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	muxIDM.HandleFunc("/security/users", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected ‘GET’ request, got ‘%s’", r.Method)
+			return
+		}
+		qp := r.URL.Query()
+		assert.Equal(t, "2", qp.Get("pageSize"))
+		pageNumber := qp.Get("pageNumber")
+		if pageNumber == "4" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{
+				"exchange": {"users": [], "nextPageExists": true},
+				"responseCode": "200",
+				"responseMessage": "Success"
+			}`)
+			return
+		}
+		nextPageExists := "true"
+		if pageNumber == "3" {
+			nextPageExists = "false"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, fmt.Sprintf(`{
+			"exchange": {
+				"users": [
+					{
+						"userUUID": "user-%s-1"
+					},
+					{
+						"userUUID": "user-%s-2"
+					}
+				],
+				"nextPageExists": %s
+			},
+			"responseCode": "200",
+			"responseMessage": "Success"
+		}`, pageNumber, pageNumber, nextPageExists))
+	})
+
+	it := client.Users.UserIterator(context.Background(), &GetUserOptions{
+		PageSize: String("2"),
+	})
+	users, err := it.All()
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"user-1-1", "user-1-2", "user-2-1", "user-2-2", "user-3-1", "user-3-2"}, users)
+	assert.NotNil(t, it.Response())
+
+	// An empty page ends the iteration even when a next page is announced
+	it = client.Users.UserIterator(context.Background(), &GetUserOptions{
+		PageSize:   String("2"),
+		PageNumber: String("4"),
+	})
+	users, err = it.All()
+	assert.Nil(t, err)
+	assert.Empty(t, users)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	it = client.Users.UserIterator(ctx, &GetUserOptions{
+		PageSize: String("2"),
+	})
+	if !assert.True(t, it.Next()) {
+		return
+	}
+	assert.Equal(t, "user-1-1", it.UserUUID())
+	cancel()
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+}
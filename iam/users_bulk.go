@@ -0,0 +1,89 @@
+package iam
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultCreateUsersConcurrency = 4
+)
+
+// CreateUsersOptions configures CreateUsers
+type CreateUsersOptions struct {
+	// Concurrency is the maximum number of users created in parallel. Defaults to 4
+	Concurrency int
+	// DryRun only validates the persons without calling IAM
+	DryRun bool
+}
+
+// CreateUserResult holds the outcome of a single user creation of CreateUsers
+type CreateUserResult struct {
+	Person Person
+	User   *User
+	Err    error
+}
+
+// UserErrors aggregates the per-user errors of a bulk operation
+type UserErrors []*UserError
+
+func (e UserErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, fmt.Sprintf("%s: %v", err.User, err.Err))
+	}
+	return fmt.Sprintf("%d user(s) failed: %s", len(e), strings.Join(messages, "; "))
+}
+
+// CreateUsers creates the given persons using a bounded pool of workers.
+// The results are returned in the order of persons. When one or more creations
+// fail the returned error is of type UserErrors
+func (u *UsersService) CreateUsers(persons []Person, opts CreateUsersOptions, options ...OptionFunc) ([]CreateUserResult, error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultCreateUsersConcurrency
+	}
+	results := make([]CreateUserResult, len(persons))
+	for i, person := range persons {
+		results[i].Person = person
+	}
+
+	if opts.DryRun {
+		for i := range results {
+			results[i].Err = u.validate.Struct(results[i].Person)
+		}
+		return results, collectUserErrors(results)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Concurrency && w < len(persons); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i].User, _, results[i].Err = u.CreateUser(results[i].Person, options...)
+			}
+		}()
+	}
+	for i := range persons {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, collectUserErrors(results)
+}
+
+func collectUserErrors(results []CreateUserResult) error {
+	var errs UserErrors
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, &UserError{User: r.Person.LoginID, Err: r.Err})
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
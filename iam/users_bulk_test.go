@@ -0,0 +1,88 @@
+package iam
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateUsers(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	var mu sync.Mutex
+	posts := 0
+
+	muxIDM.HandleFunc("/authorize/identity/User", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+		switch r.Method {
+		case "POST":
+			mu.Lock()
+			posts++
+			mu.Unlock()
+			var person Person
+			if err := json.NewDecoder(r.Body).Decode(&person); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if person.LoginID == "taken" {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = io.WriteString(w, `{"resourceType":"OperationOutcome","issue":[{"severity":"error","code":"duplicate"}]}`)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{"resourceType":"OperationOutcome"}`)
+		case "GET":
+			userID := r.URL.Query().Get("userId")
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{"total":1,"entry":[{"id":"uuid-`+userID+`","loginId":"`+userID+`"}]}`)
+		}
+	})
+
+	newPerson := func(loginID string) Person {
+		return Person{
+			ResourceType:         "Person",
+			LoginID:              loginID,
+			ManagingOrganization: "c57b2625-eda3-4b27-a8e6-86f0a0e76afc",
+			Name:                 Name{Family: "Doe", Given: "John"},
+			Telecom:              []TelecomEntry{{System: "email", Value: loginID + "@example.com"}},
+		}
+	}
+	invalid := newPerson("invalid")
+	invalid.Telecom = nil
+
+	persons := []Person{newPerson("user1"), newPerson("taken"), newPerson("user2"), invalid}
+
+	results, err := client.Users.CreateUsers(persons, CreateUsersOptions{DryRun: true})
+	assert.Equal(t, 0, posts)
+	var userErrors UserErrors
+	if !assert.True(t, errors.As(err, &userErrors)) {
+		return
+	}
+	assert.Len(t, userErrors, 1)
+	assert.Equal(t, "invalid", userErrors[0].User)
+	assert.Len(t, results, 4)
+
+	results, err = client.Users.CreateUsers(persons, CreateUsersOptions{Concurrency: 2})
+	if !assert.True(t, errors.As(err, &userErrors)) {
+		return
+	}
+	assert.Len(t, userErrors, 2)
+	assert.Equal(t, 3, posts)
+	if !assert.Len(t, results, 4) {
+		return
+	}
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, "uuid-user1", results[0].User.ID)
+	assert.NotNil(t, results[1].Err)
+	assert.Equal(t, "uuid-user2", results[2].User.ID)
+	assert.NotNil(t, results[3].Err)
+
+	_, err = client.Users.CreateUsers(persons[:1], CreateUsersOptions{})
+	assert.Nil(t, err)
+}
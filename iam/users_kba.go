@@ -0,0 +1,105 @@
+package iam
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const (
+	kbaAPIVersion = "1"
+)
+
+// KBAChallenge is a knowledge based authentication question of a user.
+// Answer is only set when responding to the challenge
+type KBAChallenge struct {
+	Challenge string `json:"challenge"`
+	Answer    string `json:"answer,omitempty"`
+}
+
+// GetKBAChallenges retrieves the security questions configured for loginID.
+// This is the first step of the challenge based password reset flow
+func (u *UsersService) GetKBAChallenges(loginID string, options ...OptionFunc) ([]KBAChallenge, *Response, error) {
+	if loginID == "" {
+		return nil, nil, ErrMissingLoginID
+	}
+	opt := struct {
+		LoginID string `url:"loginId"`
+	}{loginID}
+	req, err := u.client.newRequest(IDM, "GET", "authorize/identity/User/$kba", &opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", kbaAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var kbaResponse struct {
+		LoginID    string         `json:"loginId"`
+		Challenges []KBAChallenge `json:"challenges"`
+	}
+
+	resp, err := u.kbaDo(req, &kbaResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(kbaResponse.Challenges) == 0 {
+		return nil, resp, fmt.Errorf("GetKBAChallenges: %w", ErrEmptyResults)
+	}
+	for i := range kbaResponse.Challenges {
+		kbaResponse.Challenges[i].Answer = ""
+	}
+	return kbaResponse.Challenges, resp, nil
+}
+
+// AnswerKBAChallenges submits the answers to the challenges of loginID. On success
+// the returned confirmation code can be passed to SetPassword to set a new password
+func (u *UsersService) AnswerKBAChallenges(loginID string, answers []KBAChallenge, options ...OptionFunc) (string, *Response, error) {
+	if loginID == "" {
+		return "", nil, ErrMissingLoginID
+	}
+	if len(answers) == 0 {
+		return "", nil, ErrMissingKBAAnswers
+	}
+	body := struct {
+		LoginID    string         `json:"loginId"`
+		Challenges []KBAChallenge `json:"challenges"`
+	}{loginID, answers}
+	req, err := u.client.newRequest(IDM, "POST", "authorize/identity/User/$validate-kba", &body, options)
+	if err != nil {
+		return "", nil, err
+	}
+	req.Header.Set("api-version", kbaAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	var validateResponse struct {
+		ConfirmationCode string `json:"confirmationCode"`
+	}
+
+	resp, err := u.kbaDo(req, &validateResponse)
+	if err != nil {
+		return "", resp, err
+	}
+	if resp.StatusCode != http.StatusOK || validateResponse.ConfirmationCode == "" {
+		return "", resp, ErrKBAValidationFailed
+	}
+	return validateResponse.ConfirmationCode, resp, nil
+}
+
+// ResetPasswordWithKBA completes the challenge based password reset flow by
+// answering the challenges and setting newPassword with the confirmation code
+func (u *UsersService) ResetPasswordWithKBA(loginID string, answers []KBAChallenge, newPassword string, options ...OptionFunc) (bool, *Response, error) {
+	if newPassword == "" {
+		return false, nil, ErrMissingPassword
+	}
+	code, resp, err := u.AnswerKBAChallenges(loginID, answers, options...)
+	if err != nil {
+		return false, resp, err
+	}
+	return u.SetPassword(loginID, code, newPassword, PasswordContextRecoverPassword, options...)
+}
+
+func (u *UsersService) kbaDo(req *http.Request, v interface{}) (*Response, error) {
+	if u.client.validSigner() {
+		return u.client.doSigned(req, v)
+	}
+	return u.client.do(req, v)
+}
@@ -0,0 +1,101 @@
+package iam
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResetPasswordWithKBA(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	loginID := "ron"
+	confirmationCode := "3b5c7a09"
+
+	muxIDM.HandleFunc("/authorize/identity/User/$kba", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("loginId") != loginID {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"resourceType": "OperationOutcome","issue": [{"severity": "error","code": "not-found"}]}`)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{
+			"loginId": "`+loginID+`",
+			"challenges": [
+				{"challenge": "What is your favourite color?"},
+				{"challenge": "What was the name of your first pet?"}
+			]
+		}`)
+	})
+	muxIDM.HandleFunc("/authorize/identity/User/$validate-kba", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			LoginID    string         `json:"loginId"`
+			Challenges []KBAChallenge `json:"challenges"`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.LoginID != loginID {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"resourceType": "OperationOutcome","issue": [{"severity": "error","code": "invalid"}]}`)
+			return
+		}
+		for _, c := range body.Challenges {
+			if c.Answer != "blue" && c.Answer != "rex" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = io.WriteString(w, `{"resourceType": "OperationOutcome","issue": [{"severity": "error","code": "invalid"}]}`)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"confirmationCode": "`+confirmationCode+`"}`)
+	})
+	muxIDM.HandleFunc("/authorize/identity/User/$set-password", func(w http.ResponseWriter, r *http.Request) {
+		var body Parameters
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Parameter) != 1 ||
+			body.Parameter[0].Resource.ConfirmationCode != confirmationCode ||
+			body.Parameter[0].Resource.Context != PasswordContextRecoverPassword {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"resourceType": "OperationOutcome","issue": [{"severity": "error","code": "invalid"}]}`)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"resourceType": "OperationOutcome","issue": [{"severity": "information","code": "informational"}]}`)
+	})
+
+	challenges, resp, err := client.Users.GetKBAChallenges(loginID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotNil(t, resp)
+	if !assert.Len(t, challenges, 2) {
+		return
+	}
+	challenges[0].Answer = "blue"
+	challenges[1].Answer = "rex"
+
+	ok, resp, err := client.Users.ResetPasswordWithKBA(loginID, challenges, "N3wP@ss")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	if assert.NotNil(t, resp) {
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	challenges[1].Answer = "wrong"
+	ok, _, err = client.Users.ResetPasswordWithKBA(loginID, challenges, "N3wP@ss")
+	assert.NotNil(t, err)
+	assert.False(t, ok)
+
+	_, _, err = client.Users.GetKBAChallenges("")
+	assert.Equal(t, ErrMissingLoginID, err)
+	_, _, err = client.Users.AnswerKBAChallenges(loginID, nil)
+	assert.Equal(t, ErrMissingKBAAnswers, err)
+}
@@ -2,6 +2,7 @@ package iam
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -12,17 +13,38 @@ const (
 	userAPIVersion = "2"
 )
 
-// GetUserOptions describes search criteria for looking up users
+// Contexts of SetPassword
+const (
+	PasswordContextUserCreate      = "userCreate"
+	PasswordContextRecoverPassword = "recoverPassword"
+)
+
+// Profile types of GetUserProfile
+const (
+	ProfileTypeMembership     = "membership"
+	ProfileTypeAccountStatus  = "accountStatus"
+	ProfileTypePasswordStatus = "passwordStatus"
+	ProfileTypeConsentedApps  = "consentedApps"
+	ProfileTypeAll            = "all"
+)
+
+// GetUserOptions describes search criteria for looking up users.
+// Set Disabled to false to only find active users
 type GetUserOptions struct {
-	ID             *string `url:"_id,omitempty"`
-	OrganizationID *string `url:"organizationID,omitempty"`
-	Name           *string `url:"name,omitempty"`
-	LoginID        *string `url:"loginId,omitempty"`
-	GroupID        *string `url:"groupId,omitempty"`
-	PageSize       *string `url:"pageSize,omitempty"`
-	PageNumber     *string `url:"pageNumber,omitempty"`
-	UserID         *string `url:"userId,omitempty"`
-	ProfileType    *string `url:"profileType,omitempty" enum:"membership|accountStatus|passwordStatus|consentedApps|all"`
+	ID                   *string `url:"_id,omitempty"`
+	OrganizationID       *string `url:"organizationID,omitempty"`
+	Name                 *string `url:"name,omitempty"`
+	LoginID              *string `url:"loginId,omitempty"`
+	GroupID              *string `url:"groupId,omitempty"`
+	PageSize             *string `url:"pageSize,omitempty"`
+	PageNumber           *string `url:"pageNumber,omitempty"`
+	UserID               *string `url:"userId,omitempty"`
+	ProfileType          *string `url:"profileType,omitempty" enum:"membership|accountStatus|passwordStatus|consentedApps|all"`
+	EmailAddress         *string `url:"emailAddress,omitempty"`
+	Disabled             *bool   `url:"disabled,omitempty"`
+	ManagingOrganization *string `url:"managingOrganization,omitempty"`
+	SortBy               *string `url:"sortBy,omitempty" enum:"loginId|emailAddress|givenName|familyName|lastModified"`
+	SortOrder            *string `url:"sortOrder,omitempty" enum:"asc|desc"`
 }
 
 // UsersService provides operations on IAM User resources
@@ -67,11 +89,11 @@ type UserList struct {
 }
 
 // CreateUser creates a new IAM user.
-func (u *UsersService) CreateUser(person Person) (*User, *Response, error) {
+func (u *UsersService) CreateUser(person Person, options ...OptionFunc) (*User, *Response, error) {
 	if err := u.validate.Struct(person); err != nil {
 		return nil, nil, err
 	}
-	req, err := u.client.newRequest(IDM, "POST", "authorize/identity/User", &person, nil)
+	req, err := u.client.newRequest(IDM, "POST", "authorize/identity/User", &person, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -98,18 +120,58 @@ func (u *UsersService) CreateUser(person Person) (*User, *Response, error) {
 		if count == 0 {
 			return nil, resp, ErrCouldNoReadResourceAfterCreate
 		}
-		return u.GetUserByID(id)
+		return u.GetUserByID(id, options...)
 	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, resp, fmt.Errorf("unexpected StatusCode '%d' during user create", resp.StatusCode)
 	}
 	// HTTP 200
-	return u.GetUserByID(person.LoginID)
+	return u.GetUserByID(person.LoginID, options...)
+}
+
+// RegisterUser self registers a new user. The request is signed with the
+// shared and secret key of the client so no user or org admin login is required.
+// The UUID of the user is returned when IAM created a new account. IAM sends an
+// activation email after which ActivateUser completes the registration
+func (u *UsersService) RegisterUser(person Person, options ...OptionFunc) (string, *Response, error) {
+	if !u.client.validSigner() {
+		return "", nil, ErrNoValidSignerAvailable
+	}
+	person.ManagingOrganization = ""
+	if err := u.validate.Struct(person); err != nil {
+		return "", nil, err
+	}
+	req, err := u.client.newRequest(IDM, "POST", "authorize/identity/User", &person, options)
+	if err != nil {
+		return "", nil, err
+	}
+	req.Header.Set("api-version", "3")
+
+	var bundleResponse interface{}
+
+	resp, err := u.client.doSigned(req, &bundleResponse)
+	if err != nil {
+		return "", resp, err
+	}
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		var id string
+		_, _ = fmt.Sscanf(resp.Header.Get("Location"), "/authorize/identity/User/%s", &id)
+		return id, resp, nil
+	case http.StatusOK: // Existing registration, activation email is sent again
+		return "", resp, nil
+	}
+	return "", resp, fmt.Errorf("unexpected StatusCode '%d' during user registration", resp.StatusCode)
+}
+
+// ActivateUser completes a self registration using the confirmation code of the activation email
+func (u *UsersService) ActivateUser(loginID, confirmationCode, newPassword string, options ...OptionFunc) (bool, *Response, error) {
+	return u.SetPassword(loginID, confirmationCode, newPassword, PasswordContextUserCreate, options...)
 }
 
 // DeleteUser deletes the  IAM user.
-func (u *UsersService) DeleteUser(person Person) (bool, *Response, error) {
-	req, err := u.client.newRequest(IDM, "DELETE", "authorize/identity/User/"+person.ID, nil, nil)
+func (u *UsersService) DeleteUser(person Person, options ...OptionFunc) (bool, *Response, error) {
+	req, err := u.client.newRequest(IDM, "DELETE", "authorize/identity/User/"+person.ID, nil, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -130,10 +192,39 @@ func (u *UsersService) DeleteUser(person Person) (bool, *Response, error) {
 	return ok, resp, err
 }
 
+// PatchUser updates only the fields of the user which are set in patch, using
+// JSON merge patch semantics. This avoids a read-modify-write of the whole
+// Person and does not overwrite attributes changed concurrently by others
+func (u *UsersService) PatchUser(userID string, patch UserPatch, options ...OptionFunc) (*User, *Response, error) {
+	if patch.IsEmpty() {
+		return nil, nil, ErrEmptyPatch
+	}
+	if err := u.validate.Struct(patch); err != nil {
+		return nil, nil, err
+	}
+	req, err := u.client.newRequest(IDM, "PATCH", "authorize/identity/User/"+userID, &patch, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("api-version", "3")
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+
+	var bundleResponse interface{}
+
+	resp, err := u.client.do(req, &bundleResponse)
+	if err != nil && err != io.EOF { // EOF is valid
+		return nil, resp, err
+	}
+	if resp == nil || (resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent) {
+		return nil, resp, fmt.Errorf("PatchUser: %w", ErrOperationFailed)
+	}
+	return u.GetUserByID(userID, options...)
+}
+
 // RecoverPassword triggers the recovery flow for the given user
 //
 // Deprecated: Support end date is 1 Augustus 2020
-func (u *UsersService) RecoverPassword(loginID string) (bool, *Response, error) {
+func (u *UsersService) RecoverPassword(loginID string, options ...OptionFunc) (bool, *Response, error) {
 	body := &Parameters{
 		ResourceType: "Parameters",
 		Parameter: []Param{
@@ -145,16 +236,16 @@ func (u *UsersService) RecoverPassword(loginID string) (bool, *Response, error)
 			},
 		},
 	}
-	return u.userActionV(body, "$recover-password", "1")
+	return u.userActionV(body, "$recover-password", "1", options)
 }
 
 // ChangeLoginID changes the loginID
 // Link: https://www.hsdp.io/documentation/identity-and-access-management-iam/api-documents/resource-reference-api/user-api-v2#/User%20Management/post_User__id___change_loginid
-func (u *UsersService) ChangeLoginID(user Person, newLoginID string) (bool, *Response, error) {
+func (u *UsersService) ChangeLoginID(user Person, newLoginID string, options ...OptionFunc) (bool, *Response, error) {
 	body := &ChangeLoginIDRequest{
 		LoginID: newLoginID,
 	}
-	req, err := u.client.newRequest(IDM, "POST", "authorize/identity/User/"+user.ID+"/$change-loginid", body, nil)
+	req, err := u.client.newRequest(IDM, "POST", "authorize/identity/User/"+user.ID+"/$change-loginid", body, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -171,7 +262,7 @@ func (u *UsersService) ChangeLoginID(user Person, newLoginID string) (bool, *Res
 }
 
 // ResendActivation re-sends an activation email to the given user
-func (u *UsersService) ResendActivation(loginID string) (bool, *Response, error) {
+func (u *UsersService) ResendActivation(loginID string, options ...OptionFunc) (bool, *Response, error) {
 	body := &Parameters{
 		ResourceType: "Parameters",
 		Parameter: []Param{
@@ -183,11 +274,27 @@ func (u *UsersService) ResendActivation(loginID string) (bool, *Response, error)
 			},
 		},
 	}
-	return u.userActionV(body, "$resend-activation", "2")
+	return u.userActionV(body, "$resend-activation", "2", options)
+}
+
+// VerifyEmail confirms the email address of a user using the code from the verification email
+func (u *UsersService) VerifyEmail(confirmationCode string, options ...OptionFunc) (bool, *Response, error) {
+	body := &Parameters{
+		ResourceType: "Parameters",
+		Parameter: []Param{
+			{
+				Name: "confirmEmail",
+				Resource: Resource{
+					ConfirmationCode: confirmationCode,
+				},
+			},
+		},
+	}
+	return u.userActionV(body, "$confirm-email", "2", options)
 }
 
-func (u *UsersService) userActionV(body *Parameters, action, apiVersion string) (bool, *Response, error) {
-	req, err := u.client.newRequest(IDM, "POST", "authorize/identity/User/"+action, body, nil)
+func (u *UsersService) userActionV(body *Parameters, action, apiVersion string, options []OptionFunc) (bool, *Response, error) {
+	req, err := u.client.newRequest(IDM, "POST", "authorize/identity/User/"+action, body, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -204,7 +311,7 @@ func (u *UsersService) userActionV(body *Parameters, action, apiVersion string)
 }
 
 // SetPassword sets the password of a user given a correct confirmation code
-func (u *UsersService) SetPassword(loginID, confirmationCode, newPassword, context string) (bool, *Response, error) {
+func (u *UsersService) SetPassword(loginID, confirmationCode, newPassword, context string, options ...OptionFunc) (bool, *Response, error) {
 	body := &Parameters{
 		ResourceType: "Parameters",
 		Parameter: []Param{
@@ -219,11 +326,11 @@ func (u *UsersService) SetPassword(loginID, confirmationCode, newPassword, conte
 			},
 		},
 	}
-	return u.userActionV(body, "$set-password", "2")
+	return u.userActionV(body, "$set-password", "2", options)
 }
 
 // ChangePassword changes the password. The current pasword must be provided as well.
-func (u *UsersService) ChangePassword(loginID, oldPassword, newPassword string) (bool, *Response, error) {
+func (u *UsersService) ChangePassword(loginID, oldPassword, newPassword string, options ...OptionFunc) (bool, *Response, error) {
 	body := &Parameters{
 		ResourceType: "Parameters",
 		Parameter: []Param{
@@ -237,7 +344,7 @@ func (u *UsersService) ChangePassword(loginID, oldPassword, newPassword string)
 			},
 		},
 	}
-	return u.userActionV(body, "$change-password", "1")
+	return u.userActionV(body, "$change-password", "1", options)
 }
 
 func stringInc(number string) string {
@@ -313,13 +420,57 @@ func (u *UsersService) GetUsers(opts *GetUserOptions, options ...OptionFunc) (*U
 	return &list, resp, err
 }
 
-// GetUserByID looks up a user by UUID
-func (u *UsersService) GetUserByID(uuid string) (*User, *Response, error) {
+// HydratedUserList holds a page of users with their full profile
+type HydratedUserList struct {
+	Users       []User
+	PageNumber  int
+	HasNextPage bool
+}
+
+// SearchUsers looks up a page of users matching opts and retrieves the full
+// profile of each match. This issues one additional request per user
+func (u *UsersService) SearchUsers(opts *GetUserOptions, options ...OptionFunc) (*HydratedUserList, *Response, error) {
+	list, resp, err := u.GetUsers(opts, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	hydrated := &HydratedUserList{
+		Users:       make([]User, 0, len(list.UserUUIDs)),
+		PageNumber:  list.PageNumber,
+		HasNextPage: list.HasNextPage,
+	}
+	for _, uuid := range list.UserUUIDs {
+		user, resp, err := u.GetUserByID(uuid, options...)
+		if err != nil {
+			return nil, resp, &UserError{User: uuid, Err: err}
+		}
+		hydrated.Users = append(hydrated.Users, *user)
+	}
+	return hydrated, resp, nil
+}
+
+// GetUserByID looks up a user by UUID and returns the full profile,
+// including memberships, consented apps, password and account status
+func (u *UsersService) GetUserByID(uuid string, options ...OptionFunc) (*User, *Response, error) {
+	return u.GetUserProfile(uuid, ProfileTypeAll, options...)
+}
+
+// GetUserProfile looks up a user by UUID and returns the parts of the
+// profile selected by profileType
+func (u *UsersService) GetUserProfile(uuid, profileType string, options ...OptionFunc) (*User, *Response, error) {
+	switch profileType {
+	case ProfileTypeMembership, ProfileTypeAccountStatus, ProfileTypePasswordStatus, ProfileTypeConsentedApps, ProfileTypeAll:
+	default:
+		return nil, nil, fmt.Errorf("GetUserProfile('%s'): profileType '%s': %w", uuid, profileType, ErrMalformedInputValue)
+	}
 	opt := &GetUserOptions{
 		UserID:      &uuid,
-		ProfileType: String("all"),
+		ProfileType: String(profileType),
+	}
+	req, err := u.client.newRequest(IDM, "GET", "authorize/identity/User", opt, options)
+	if err != nil {
+		return nil, nil, err
 	}
-	req, _ := u.client.newRequest(IDM, "GET", "authorize/identity/User", opt, nil)
 	req.Header.Set("api-version", "3")
 
 	var responseStruct struct {
@@ -331,15 +482,15 @@ func (u *UsersService) GetUserByID(uuid string) (*User, *Response, error) {
 	if err != nil {
 		return nil, resp, fmt.Errorf("GetUserByID('%s'): %w", uuid, err)
 	}
-	if responseStruct.Total == 0 {
+	if responseStruct.Total == 0 || len(responseStruct.Entry) == 0 {
 		return nil, resp, fmt.Errorf("GetUserByID('%s'): %w", uuid, ErrEmptyResults)
 	}
 	return &responseStruct.Entry[0], resp, nil
 }
 
 // GetUserIDByLoginID looks up the UUID of a user by LoginID (email address)
-func (u *UsersService) GetUserIDByLoginID(loginID string) (string, *Response, error) {
-	user, resp, err := u.GetUserByID(loginID)
+func (u *UsersService) GetUserIDByLoginID(loginID string, options ...OptionFunc) (string, *Response, error) {
+	user, resp, err := u.GetUserByID(loginID, options...)
 	if err != nil {
 		return "", resp, err
 	}
@@ -350,7 +501,7 @@ func (u *UsersService) GetUserIDByLoginID(loginID string) (string, *Response, er
 }
 
 // LegacyUpdateUser updates the user profile
-func (u *UsersService) LegacyUpdateUser(profile Profile) (*Profile, *Response, error) {
+func (u *UsersService) LegacyUpdateUser(profile Profile, options ...OptionFunc) (*Profile, *Response, error) {
 	// don't send blank addresses
 	profile.PruneBlankAddresses()
 	// Also clear out un-settable fields
@@ -358,7 +509,7 @@ func (u *UsersService) LegacyUpdateUser(profile Profile) (*Profile, *Response, e
 	profile.EmailVerifiedStatus = ""
 	profile.MustChangePassword = ""
 
-	req, _ := u.client.newRequest(IDM, "PUT", "security/users/"+profile.ID, profile, nil)
+	req, _ := u.client.newRequest(IDM, "PUT", "security/users/"+profile.ID, profile, options)
 	req.Header.Set("api-version", "2")
 
 	var responseStruct struct {
@@ -381,8 +532,8 @@ func (u *UsersService) LegacyUpdateUser(profile Profile) (*Profile, *Response, e
 }
 
 // LegacyGetUserByUUID looks the a user by UUID using the legacy API
-func (u *UsersService) LegacyGetUserByUUID(uuid string) (*Profile, *Response, error) {
-	req, _ := u.client.newRequest(IDM, "GET", "security/users/"+uuid, nil, nil)
+func (u *UsersService) LegacyGetUserByUUID(uuid string, options ...OptionFunc) (*Profile, *Response, error) {
+	req, _ := u.client.newRequest(IDM, "GET", "security/users/"+uuid, nil, options)
 	req.Header.Set("api-version", userAPIVersion)
 
 	var responseStruct struct {
@@ -401,11 +552,11 @@ func (u *UsersService) LegacyGetUserByUUID(uuid string) (*Profile, *Response, er
 }
 
 // LegacyGetUserIDByLoginID looks up the UUID of a user by LoginID (email address)
-func (u *UsersService) LegacyGetUserIDByLoginID(loginID string) (string, *Response, error) {
+func (u *UsersService) LegacyGetUserIDByLoginID(loginID string, options ...OptionFunc) (string, *Response, error) {
 	opt := &GetUserOptions{
 		LoginID: &loginID,
 	}
-	req, _ := u.client.newRequest(IDM, "GET", "security/users", opt, nil)
+	req, _ := u.client.newRequest(IDM, "GET", "security/users", opt, options)
 	req.Header.Set("api-version", userAPIVersion)
 
 	var responseStruct struct {
@@ -429,7 +580,7 @@ func (u *UsersService) LegacyGetUserIDByLoginID(loginID string) (string, *Respon
 }
 
 // SetMFA activate Multi-Factor-Authentication for the given UUID. See also SetMFAByLoginID.
-func (u *UsersService) SetMFA(userID string, activate bool) (bool, *Response, error) {
+func (u *UsersService) SetMFA(userID string, activate bool, options ...OptionFunc) (bool, *Response, error) {
 	activateString := "true"
 	if !activate {
 		activateString = "false"
@@ -437,7 +588,7 @@ func (u *UsersService) SetMFA(userID string, activate bool) (bool, *Response, er
 	body := &struct {
 		Activate string `json:"activate"`
 	}{activateString}
-	req, err := u.client.newRequest(IDM, "POST", "authorize/identity/User/"+userID+"/$mfa", body, nil)
+	req, err := u.client.newRequest(IDM, "POST", "authorize/identity/User/"+userID+"/$mfa", body, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -451,8 +602,8 @@ func (u *UsersService) SetMFA(userID string, activate bool) (bool, *Response, er
 }
 
 // Unlock unlocks a user account with the given UserID
-func (u *UsersService) Unlock(userID string) (bool, *Response, error) {
-	req, err := u.client.newRequest(IDM, "POST", "authorize/identity/User/"+userID+"/$unlock", nil, nil)
+func (u *UsersService) Unlock(userID string, options ...OptionFunc) (bool, *Response, error) {
+	req, err := u.client.newRequest(IDM, "POST", "authorize/identity/User/"+userID+"/$unlock", nil, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -465,11 +616,121 @@ func (u *UsersService) Unlock(userID string) (bool, *Response, error) {
 	return ok, resp, nil
 }
 
+// RevokeSessions ends all active sessions of the user with the given UserID and
+// revokes the tokens issued to them, so a compromised account is locked out immediately
+func (u *UsersService) RevokeSessions(userID string, options ...OptionFunc) (bool, *Response, error) {
+	req, err := u.client.newRequest(IDM, "POST", "authorize/identity/User/"+userID+"/$revoke-sessions", nil, options)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("api-version", userAPIVersion)
+
+	var bundleResponse interface{}
+
+	resp, err := u.client.do(req, &bundleResponse)
+	if err != nil && err != io.EOF { // EOF is valid
+		return false, resp, err
+	}
+	ok := resp != nil && (resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusOK)
+	return ok, resp, nil
+}
+
+// SetUserStatus activates or deactivates the user with the given UserID. A deactivated
+// user can no longer log in. ErrUserAlreadyActive or ErrUserAlreadyInactive is
+// returned when the user is already in the requested state
+func (u *UsersService) SetUserStatus(userID string, active bool, options ...OptionFunc) (bool, *Response, error) {
+	user, resp, err := u.GetUserProfile(userID, ProfileTypeAccountStatus, options...)
+	if err != nil {
+		return false, resp, err
+	}
+	alreadyErr := ErrUserAlreadyInactive
+	action := "$deactivate"
+	if active {
+		alreadyErr = ErrUserAlreadyActive
+		action = "$activate"
+	}
+	if user.AccountStatus.Disabled != active {
+		return false, resp, alreadyErr
+	}
+	req, err := u.client.newRequest(IDM, "POST", "authorize/identity/User/"+userID+"/"+action, nil, options)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("api-version", userAPIVersion)
+
+	var bundleResponse interface{}
+
+	resp, err = u.client.do(req, &bundleResponse)
+	if resp != nil && resp.StatusCode == http.StatusConflict {
+		return false, resp, alreadyErr
+	}
+	if err != nil && err != io.EOF { // EOF is valid
+		return false, resp, err
+	}
+	ok := resp != nil && (resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusOK)
+	return ok, resp, nil
+}
+
+// ForcePasswordChange requires the user with the given UserID to change the password at the next login
+func (u *UsersService) ForcePasswordChange(userID string, options ...OptionFunc) (bool, *Response, error) {
+	req, err := u.client.newRequest(IDM, "POST", "authorize/identity/User/"+userID+"/$force-password-change", nil, options)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("api-version", userAPIVersion)
+
+	var bundleResponse interface{}
+
+	resp, err := u.client.do(req, &bundleResponse)
+	if err != nil && err != io.EOF { // EOF is valid
+		return false, resp, err
+	}
+	ok := resp != nil && (resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusOK)
+	return ok, resp, nil
+}
+
+// AdminSetPassword sets the password of the user with the given UserID without a confirmation
+// code. When forceChange is true the user must change the password at the next login.
+// This requires administrative permissions in the managing organization of the user
+func (u *UsersService) AdminSetPassword(userID, newPassword string, forceChange bool, options ...OptionFunc) (bool, *Response, error) {
+	if newPassword == "" {
+		return false, nil, ErrMissingPassword
+	}
+	body := &Parameters{
+		ResourceType: "Parameters",
+		Parameter: []Param{
+			{
+				Name: "setPassword",
+				Resource: Resource{
+					NewPassword: newPassword,
+				},
+			},
+		},
+	}
+	req, err := u.client.newRequest(IDM, "POST", "authorize/identity/User/"+userID+"/$set-password", body, options)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("api-version", userAPIVersion)
+
+	var bundleResponse interface{}
+
+	resp, err := u.client.do(req, &bundleResponse)
+	if err != nil && err != io.EOF { // EOF is valid
+		return false, resp, err
+	}
+	ok := resp != nil && (resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusOK)
+	if !ok || !forceChange {
+		return ok, resp, nil
+	}
+	return u.ForcePasswordChange(userID, options...)
+}
+
 // SetMFAByLoginID enabled Multi-Factor-Authentication for the given user. Only OrgAdmins can do this.
-func (u *UsersService) SetMFAByLoginID(loginID string, activate bool) (bool, *Response, error) {
-	userUUID, _, err := u.GetUserIDByLoginID(loginID)
+func (u *UsersService) SetMFAByLoginID(loginID string, activate bool, options ...OptionFunc) (bool, *Response, error) {
+	userUUID, _, err := u.GetUserIDByLoginID(loginID, options...)
 	if err != nil {
 		return false, nil, err
 	}
-	return u.SetMFA(userUUID, activate)
+	return u.SetMFA(userUUID, activate, options...)
 }
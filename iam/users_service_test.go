@@ -2,8 +2,10 @@ package iam
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -542,6 +544,132 @@ func TestGetUserByID(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 	assert.Equal(t, email, foundUser.EmailAddress)
 	assert.Equal(t, "Swanson", foundUser.Name.Family)
+	assert.Len(t, foundUser.Memberships, 2)
+	assert.Equal(t, "NOTREQUIRED", foundUser.AccountStatus.MFAStatus)
+	assert.False(t, foundUser.PasswordStatus.PasswordExpiresOn.IsZero())
+}
+
+func TestGetUserProfile(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	userUUID := "44d20214-7879-4e35-923d-f9d4e01c9746"
+	handler := userIDByLoginIDHandler(t, "ron", "foo@bar.com", userUUID)
+	var profileType string
+	muxIDM.HandleFunc("/authorize/identity/User", func(w http.ResponseWriter, r *http.Request) {
+		profileType = r.URL.Query().Get("profileType")
+		handler(w, r)
+	})
+
+	foundUser, _, err := client.Users.GetUserProfile(userUUID, ProfileTypeMembership)
+	if !assert.Nil(t, err) || !assert.NotNil(t, foundUser) {
+		return
+	}
+	assert.Equal(t, ProfileTypeMembership, profileType)
+	assert.Equal(t, userUUID, foundUser.ID)
+
+	_, _, err = client.Users.GetUserByID(userUUID)
+	assert.Nil(t, err)
+	assert.Equal(t, ProfileTypeAll, profileType)
+
+	_, _, err = client.Users.GetUserProfile(userUUID, "bogus")
+	assert.True(t, errors.Is(err, ErrMalformedInputValue))
+}
+
+func TestSetUserStatus(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	userUUID := "f5fe538f-c3b5-4454-8774-cd3789f59b9f"
+	disabled := false
+	muxIDM.HandleFunc("/authorize/identity/User", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, ProfileTypeAccountStatus, r.URL.Query().Get("profileType"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{
+  "total": 1,
+  "entry": [
+    {
+      "id": "`+userUUID+`",
+      "accountStatus": {
+        "disabled": `+strconv.FormatBool(disabled)+`
+      }
+    }
+  ]
+}`)
+	})
+	muxIDM.HandleFunc("/authorize/identity/User/"+userUUID+"/$deactivate", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		disabled = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+	muxIDM.HandleFunc("/authorize/identity/User/"+userUUID+"/$activate", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		disabled = false
+		// An empty 200 response is a success as well
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ok, _, err := client.Users.SetUserStatus(userUUID, true)
+	assert.False(t, ok)
+	assert.True(t, errors.Is(err, ErrUserAlreadyActive))
+
+	ok, resp, err := client.Users.SetUserStatus(userUUID, false)
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) {
+		return
+	}
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.True(t, disabled)
+
+	ok, _, err = client.Users.SetUserStatus(userUUID, false)
+	assert.False(t, ok)
+	assert.True(t, errors.Is(err, ErrUserAlreadyInactive))
+
+	ok, resp, err = client.Users.SetUserStatus(userUUID, true)
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) {
+		return
+	}
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.False(t, disabled)
+}
+
+func TestPatchUser(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	userUUID := "f5fe538f-c3b5-4454-8774-cd3789f59b9f"
+	muxIDM.HandleFunc("/authorize/identity/User", userIDByLoginIDHandler(t, "ron", "foo@bar.com", userUUID))
+	muxIDM.HandleFunc("/authorize/identity/User/"+userUUID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assert.Equal(t, "application/merge-patch+json", r.Header.Get("Content-Type"))
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		assert.Equal(t, map[string]interface{}{
+			"name":              map[string]interface{}{"given": "Ronald"},
+			"preferredLanguage": "nl-NL",
+		}, body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, _, err := client.Users.PatchUser(userUUID, UserPatch{})
+	assert.Equal(t, ErrEmptyPatch, err)
+	_, _, err = client.Users.PatchUser(userUUID, UserPatch{PreferredCommunicationChannel: String("pigeon")})
+	assert.NotNil(t, err)
+
+	user, resp, err := client.Users.PatchUser(userUUID, UserPatch{
+		Name:              &NamePatch{Given: String("Ronald")},
+		PreferredLanguage: String("nl-NL"),
+	})
+	if !assert.Nil(t, err) || !assert.NotNil(t, user) {
+		return
+	}
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, userUUID, user.ID)
 }
 
 func TestUserActions(t *testing.T) {
@@ -552,6 +680,8 @@ func TestUserActions(t *testing.T) {
 		actionRequestHandler(t, "resendOTP", "Password reset send", http.StatusOK))
 	muxIDM.HandleFunc("/authorize/identity/User/$set-password",
 		actionRequestHandler(t, "setPassword", "TODO: fix", http.StatusOK))
+	muxIDM.HandleFunc("/authorize/identity/User/$confirm-email",
+		actionRequestHandler(t, "confirmEmail", "Email confirmed", http.StatusOK))
 	muxIDM.HandleFunc("/authorize/identity/User/$change-password",
 		actionRequestHandler(t, "changePassword", "TODO: fix", http.StatusOK))
 	muxIDM.HandleFunc("/authorize/identity/User/$recover-password",
@@ -566,6 +696,20 @@ func TestUserActions(t *testing.T) {
 		actionRequestHandler(t, "unlock", "", http.StatusNoContent))
 	muxIDM.HandleFunc("/authorize/identity/User/"+userUUID+"/$change-loginid",
 		actionRequestHandler(t, "unlock", "", http.StatusNoContent))
+	muxIDM.HandleFunc("/authorize/identity/User/"+userUUID+"/$force-password-change",
+		actionRequestHandler(t, "forcePasswordChange", "", http.StatusNoContent))
+	muxIDM.HandleFunc("/authorize/identity/User/"+userUUID+"/$revoke-sessions",
+		actionRequestHandler(t, "revokeSessions", "", http.StatusOK))
+	muxIDM.HandleFunc("/authorize/identity/User/"+userUUID+"/$set-password", func(w http.ResponseWriter, r *http.Request) {
+		var body Parameters
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Parameter) != 1 ||
+			body.Parameter[0].Name != "setPassword" || body.Parameter[0].Resource.NewPassword != "N3wP@ss" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// An empty 200 response is a success as well
+		w.WriteHeader(http.StatusOK)
+	})
 
 	ok, resp, err := client.Users.ResendActivation("foo@bar.com")
 	if !assert.NotNil(t, resp) {
@@ -575,6 +719,14 @@ func TestUserActions(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 
+	ok, resp, err = client.Users.VerifyEmail("1234")
+	if !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
 	ok, resp, err = client.Users.RecoverPassword("foo@bar.co")
 	if !assert.NotNil(t, resp) {
 		return
@@ -623,6 +775,42 @@ func TestUserActions(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
 
+	ok, resp, err = client.Users.ForcePasswordChange(userUUID)
+	if !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	ok, resp, err = client.Users.RevokeSessions(userUUID)
+	if !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	ok, resp, err = client.Users.AdminSetPassword(userUUID, "N3wP@ss", true)
+	if !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	ok, resp, err = client.Users.AdminSetPassword(userUUID, "N3wP@ss", false)
+	if !assert.NotNil(t, resp) {
+		return
+	}
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	ok, _, err = client.Users.AdminSetPassword(userUUID, "", false)
+	assert.False(t, ok)
+	assert.ErrorIs(t, err, ErrMissingPassword)
+
 	ok, resp, err = client.Users.ChangeLoginID(Person{
 		ID:      userUUID,
 		LoginID: "ronswanon1",
@@ -776,3 +964,125 @@ func TestLegacyPaths(t *testing.T) {
 	}
 	assert.Equal(t, "Swanson", profile.FamilyName)
 }
+
+func TestSearchUsers(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	orgID := "c57b2625-eda3-4b27-a8e6-86f0a0e76afc"
+	email := "john@doe.com"
+
+	muxIDM.HandleFunc("/security/users", func(w http.ResponseWriter, r *http.Request) {
+		qp := r.URL.Query()
+		assert.Equal(t, email, qp.Get("emailAddress"))
+		assert.Equal(t, "false", qp.Get("disabled"))
+		assert.Equal(t, orgID, qp.Get("managingOrganization"))
+		assert.Equal(t, "loginId", qp.Get("sortBy"))
+		assert.Equal(t, "desc", qp.Get("sortOrder"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{
+			"exchange": {
+				"users": [
+					{"userUUID": "7dbfe5fc-1320-4bc6-92a7-2be5d7f07cac"},
+					{"userUUID": "5620b687-7f67-4222-b7c2-91ff312b3066"}
+				],
+				"nextPageExists": true
+			},
+			"responseCode": "200",
+			"responseMessage": "Success"
+		}`)
+	})
+	muxIDM.HandleFunc("/authorize/identity/User", func(w http.ResponseWriter, r *http.Request) {
+		userID := r.URL.Query().Get("userId")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"total":1,"entry":[{"id":"`+userID+`","loginId":"john","emailAddress":"`+email+`"}]}`)
+	})
+
+	disabled := false
+	list, resp, err := client.Users.SearchUsers(&GetUserOptions{
+		EmailAddress:         &email,
+		Disabled:             &disabled,
+		ManagingOrganization: &orgID,
+		SortBy:               String("loginId"),
+		SortOrder:            String("desc"),
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotNil(t, resp)
+	if !assert.Len(t, list.Users, 2) {
+		return
+	}
+	assert.True(t, list.HasNextPage)
+	assert.Equal(t, "5620b687-7f67-4222-b7c2-91ff312b3066", list.Users[1].ID)
+	assert.Equal(t, email, list.Users[0].EmailAddress)
+}
+
+func TestRegisterUser(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	newUserUUID := "867128a6-0e02-431c-ba1e-9e764436dae4"
+	existingLoginID := "existing"
+
+	muxIDM.HandleFunc("/authorize/identity/User", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.NotEmpty(t, r.Header.Get("hsdp-api-signature")) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var person Person
+		if err := json.NewDecoder(r.Body).Decode(&person); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		assert.Empty(t, person.ManagingOrganization)
+		w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+		if person.LoginID == existingLoginID {
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{"resourceType":"OperationOutcome"}`)
+			return
+		}
+		w.Header().Set("Location", "/authorize/identity/User/"+newUserUUID)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"resourceType":"OperationOutcome"}`)
+	})
+	muxIDM.HandleFunc("/authorize/identity/User/$set-password", func(w http.ResponseWriter, r *http.Request) {
+		var body Parameters
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Parameter) != 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		assert.Equal(t, PasswordContextUserCreate, body.Parameter[0].Resource.Context)
+		assert.Equal(t, "123456", body.Parameter[0].Resource.ConfirmationCode)
+		w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"resourceType":"OperationOutcome"}`)
+	})
+
+	person := Person{
+		ResourceType:         "Person",
+		LoginID:              "loafoe",
+		ManagingOrganization: "ignored",
+		Name:                 Name{Family: "Foe", Given: "La"},
+		Telecom:              []TelecomEntry{{System: "email", Value: "foo@bar.com"}},
+		IsAgeValidated:       "true",
+	}
+	id, resp, err := client.Users.RegisterUser(person)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, newUserUUID, id)
+
+	person.LoginID = existingLoginID
+	id, resp, err = client.Users.RegisterUser(person)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, id)
+
+	ok, _, err := client.Users.ActivateUser("loafoe", "123456", "N3wP@ss")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
@@ -0,0 +1,57 @@
+package iam
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	validator "github.com/go-playground/validator/v10"
+)
+
+// FieldViolation describes a single failed validation rule
+type FieldViolation struct {
+	// Field is the namespaced struct field, e.g. ApplicationClient.Password
+	Field string
+	// Rule is the validation tag which failed, e.g. max
+	Rule string
+	// Param is the parameter of the rule, e.g. 16
+	Param string
+	Value interface{}
+}
+
+// ValidationError holds all violations found while validating a resource
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		rule := v.Rule
+		if v.Param != "" {
+			rule += "=" + v.Param
+		}
+		messages = append(messages, fmt.Sprintf("%s: failed on '%s'", v.Field, rule))
+	}
+	return fmt.Sprintf("%d validation error(s): %s", len(e.Violations), strings.Join(messages, "; "))
+}
+
+// validateStruct validates s and returns a *ValidationError listing
+// every violated rule. Other errors are returned as-is
+func validateStruct(v *validator.Validate, s interface{}) error {
+	err := v.Struct(s)
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return err
+	}
+	validationError := &ValidationError{Violations: make([]FieldViolation, 0, len(fieldErrors))}
+	for _, fe := range fieldErrors {
+		validationError.Violations = append(validationError.Violations, FieldViolation{
+			Field: fe.Namespace(),
+			Rule:  fe.Tag(),
+			Param: fe.Param(),
+			Value: fe.Value(),
+		})
+	}
+	return validationError
+}
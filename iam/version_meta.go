@@ -0,0 +1,9 @@
+package iam
+
+// VersionMeta holds the version information IAM returns in the meta of
+// versioned resources such as Clients, Propositions and Applications.
+// Pass VersionID back when updating the resource
+type VersionMeta struct {
+	VersionID    string `json:"versionId,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"errors"
+	"strings"
+)
+
+// MultiError holds the failures of an operation which kept going after an error
+type MultiError struct {
+	Errs []error
+}
+
+// JoinErrors returns nil when errs is empty, the error itself when it holds one
+// error and a *MultiError otherwise
+func JoinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return &MultiError{Errs: errs}
+}
+
+func (e *MultiError) Error() string {
+	messages := make([]string, 0, len(e.Errs))
+	for _, err := range e.Errs {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Is reports whether any of the errors matches target
+func (e *MultiError) Is(target error) bool {
+	for _, err := range e.Errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the errors that matches target
+func (e *MultiError) As(target interface{}) bool {
+	for _, err := range e.Errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinErrors(t *testing.T) {
+	first := errors.New("delete topic")
+	second := errors.New("delete subscriber")
+
+	assert.Nil(t, JoinErrors(nil))
+	assert.Equal(t, first, JoinErrors([]error{first}))
+	err := JoinErrors([]error{first, second})
+	assert.ErrorIs(t, err, first)
+	assert.ErrorIs(t, err, second)
+	assert.Equal(t, "delete topic; delete subscriber", err.Error())
+}
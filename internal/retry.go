@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// DefaultRetryMaxDelay caps the delay between retries when no maximum is configured
+const DefaultRetryMaxDelay = 30 * time.Second
+
+type noRetryKey struct{}
+
+// WithoutRetry returns a copy of req which DoWithRetry sends only once, e.g. because
+// the caller already retries the request itself
+func WithoutRetry(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), noRetryKey{}, true))
+}
+
+// DoWithRetry sends req with client and resends it up to retries times while retry reports
+// true for the response. The Retry-After header of the response is honoured, otherwise an
+// exponential backoff is used, both capped at maxDelay. Requests with a body that cannot be
+// replayed are sent once. Waiting stops when the context of req is done
+func DoWithRetry(client *http.Client, req *http.Request, retries int, maxDelay time.Duration, retry func(resp *http.Response) bool) (*http.Response, error) {
+	if retries <= 0 || !CanReplay(req) || req.Context().Value(noRetryKey{}) != nil {
+		return client.Do(req)
+	}
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = 0
+	b.MaxInterval = maxDelay
+	for attempt := 0; ; attempt++ {
+		resp, err := client.Do(req)
+		if err != nil || attempt >= retries || !retry(resp) {
+			return resp, err
+		}
+		wait, ok := ParseRetryAfter(resp.Header.Get("Retry-After"))
+		if !ok {
+			wait = b.NextBackOff()
+		}
+		if wait > maxDelay {
+			wait = maxDelay
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// CanReplay reports whether the body of req, if any, can be sent again
+func CanReplay(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// IsIdempotent reports whether req can be resent after a server error
+func IsIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// ParseRetryAfter parses a Retry-After header in seconds or as HTTP date
+func ParseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
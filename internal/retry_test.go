@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	wait, ok := ParseRetryAfter("3")
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, wait)
+
+	wait, ok = ParseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+
+	_, ok = ParseRetryAfter("soon")
+	assert.False(t, ok)
+	_, ok = ParseRetryAfter("")
+	assert.False(t, ok)
+}
+
+func TestDoWithRetry(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	throttled := func(resp *http.Response) bool {
+		return resp.StatusCode == http.StatusTooManyRequests
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := DoWithRetry(server.Client(), req, 3, time.Second, throttled)
+	if assert.Nil(t, err) {
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		_ = resp.Body.Close()
+	}
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	req, _ = http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err = DoWithRetry(server.Client(), WithoutRetry(req), 3, time.Second, throttled)
+	if assert.Nil(t, err) {
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		_ = resp.Body.Close()
+	}
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RollbackError is returned when a multi step operation failed. Err is the original
+// failure, RollbackErrs lists the created resources that could not be removed again
+type RollbackError struct {
+	Err          error
+	RollbackErrs []error
+}
+
+func (e *RollbackError) Error() string {
+	if len(e.RollbackErrs) == 0 {
+		return e.Err.Error()
+	}
+	messages := make([]string, 0, len(e.RollbackErrs))
+	for _, err := range e.RollbackErrs {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("%v (rollback failed: %s)", e.Err, strings.Join(messages, "; "))
+}
+
+func (e *RollbackError) Unwrap() error {
+	return e.Err
+}
+
+// Rollback collects the steps undoing a multi step operation
+type Rollback []func() error
+
+// Add registers fn to undo the last successful step
+func (r *Rollback) Add(fn func() error) {
+	*r = append(*r, fn)
+}
+
+// Fail runs the registered steps in reverse order and returns a *RollbackError
+// wrapping err and the errors of the steps which failed
+func (r Rollback) Fail(err error) *RollbackError {
+	rollbackErr := &RollbackError{Err: err}
+	for i := len(r) - 1; i >= 0; i-- {
+		if err := r[i](); err != nil {
+			rollbackErr.RollbackErrs = append(rollbackErr.RollbackErrs, err)
+		}
+	}
+	return rollbackErr
+}
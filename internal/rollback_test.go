@@ -0,0 +1,28 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollback(t *testing.T) {
+	var order []int
+	var rollback Rollback
+	rollback.Add(func() error {
+		order = append(order, 1)
+		return nil
+	})
+	rollback.Add(func() error {
+		order = append(order, 2)
+		return errors.New("delete topic")
+	})
+	cause := errors.New("create subscription")
+
+	err := rollback.Fail(cause)
+	assert.Equal(t, []int{2, 1}, order)
+	assert.ErrorIs(t, err, cause)
+	assert.Len(t, err.RollbackErrs, 1)
+	assert.Equal(t, "create subscription (rollback failed: delete topic)", err.Error())
+}
@@ -0,0 +1,386 @@
+package notification
+
+import (
+	"fmt"
+
+	"github.com/philips-software/go-hsdp-api/internal"
+)
+
+// Spec is the desired set of notification resources of an organization. Resources are
+// identified by name: producers by ProducerServiceName, topics by their producer and
+// Name, subscribers by SubscriberServicename. IDs in the spec are ignored
+type Spec struct {
+	ManagingOrganizationID string
+	Producers              []Producer
+	Topics                 []TopicSpec
+	Subscribers            []Subscriber
+	Subscriptions          []SubscriptionSpec
+}
+
+// TopicSpec is a topic of a Spec
+type TopicSpec struct {
+	Topic
+	// Producer is the ProducerServiceName of the producer of the topic
+	Producer string
+}
+
+// SubscriptionSpec is a subscription of a Spec
+type SubscriptionSpec struct {
+	// Topic is the Name of the topic
+	Topic string
+	// Producer is the ProducerServiceName of the producer of the topic. It may be
+	// omitted when no other producer in the spec has a topic of the same name
+	Producer string
+	// Subscriber is the SubscriberServicename of the subscriber
+	Subscriber string
+	Endpoint   string
+}
+
+// Plan lists the changes needed to converge the current state to a Spec
+type Plan struct {
+	CreateProducers     []Producer
+	CreateTopics        []TopicSpec
+	CreateSubscribers   []Subscriber
+	CreateSubscriptions []SubscriptionSpec
+
+	DeleteSubscriptions []Subscription
+	DeleteTopics        []Topic
+	DeleteSubscribers   []Subscriber
+	DeleteProducers     []Producer
+
+	spec        Spec
+	producers   map[string]Producer
+	topics      map[string]Topic
+	subscribers map[string]Subscriber
+	kept        []Subscription
+}
+
+// Empty reports whether the current state already matches the spec
+func (p *Plan) Empty() bool {
+	return len(p.CreateProducers)+len(p.CreateTopics)+len(p.CreateSubscribers)+len(p.CreateSubscriptions)+
+		len(p.DeleteSubscriptions)+len(p.DeleteTopics)+len(p.DeleteSubscribers)+len(p.DeleteProducers) == 0
+}
+
+// ApplyResult is the state after a successful Apply
+type ApplyResult struct {
+	Plan          *Plan
+	Producers     []Producer
+	Topics        []Topic
+	Subscribers   []Subscriber
+	Subscriptions []Subscription
+}
+
+// ApplyError is returned when Apply failed. Err is the original failure, RollbackErrs
+// lists the created resources that could not be removed again
+type ApplyError = internal.RollbackError
+
+// PlanSpec compares the spec with the producers and subscribers of the managing
+// organization, their topics and subscriptions, and returns the changes to converge
+func (c *Client) PlanSpec(spec Spec, options ...OptionFunc) (*Plan, error) {
+	if err := validateSpec(spec); err != nil {
+		return nil, err
+	}
+	plan := &Plan{
+		spec:        spec,
+		producers:   make(map[string]Producer),
+		topics:      make(map[string]Topic),
+		subscribers: make(map[string]Subscriber),
+	}
+	it := c.Producer.IterateProducers(nil, options...)
+	for it.Next() {
+		if producer := it.Item(); producer.ManagingOrganizationID == spec.ManagingOrganizationID {
+			plan.producers[producer.ProducerServiceName] = producer
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("list producers: %w", err)
+	}
+	topicNames := make(map[string]string)
+	for _, producer := range plan.producers {
+		producerID := producer.ID
+		it := c.Topic.IterateTopics(&GetOptions{ProducerID: &producerID}, options...)
+		for it.Next() {
+			topic := it.Item()
+			key := topicKey(producer.ProducerServiceName, topic.Name)
+			plan.topics[key] = topic
+			topicNames[topic.ID] = key
+		}
+		if err := it.Err(); err != nil {
+			return nil, fmt.Errorf("list topics: %w", err)
+		}
+	}
+	subscriberNames := make(map[string]string)
+	subscribers := c.Subscriber.IterateSubscribers(nil, options...)
+	for subscribers.Next() {
+		if subscriber := subscribers.Item(); subscriber.ManagingOrganizationID == spec.ManagingOrganizationID {
+			plan.subscribers[subscriber.SubscriberServicename] = subscriber
+			subscriberNames[subscriber.ID] = subscriber.SubscriberServicename
+		}
+	}
+	if err := subscribers.Err(); err != nil {
+		return nil, fmt.Errorf("list subscribers: %w", err)
+	}
+	var subscriptions []Subscription
+	for _, subscriber := range plan.subscribers {
+		subscriberID := subscriber.ID
+		items, err := c.Subscription.IterateSubscriptions(&GetOptions{SubscriberID: &subscriberID}, options...).All()
+		if err != nil {
+			return nil, fmt.Errorf("list subscriptions: %w", err)
+		}
+		subscriptions = append(subscriptions, items...)
+	}
+
+	wantProducers := make(map[string]bool)
+	for _, producer := range spec.Producers {
+		wantProducers[producer.ProducerServiceName] = true
+		if _, ok := plan.producers[producer.ProducerServiceName]; !ok {
+			plan.CreateProducers = append(plan.CreateProducers, producer)
+		}
+	}
+	wantTopics := make(map[string]bool)
+	for _, topic := range spec.Topics {
+		key := topicKey(topic.Producer, topic.Name)
+		if _, ok := plan.topics[key]; ok {
+			wantTopics[key] = true
+			continue
+		}
+		plan.CreateTopics = append(plan.CreateTopics, topic)
+	}
+	wantSubscribers := make(map[string]bool)
+	for _, subscriber := range spec.Subscribers {
+		wantSubscribers[subscriber.SubscriberServicename] = true
+		if _, ok := plan.subscribers[subscriber.SubscriberServicename]; !ok {
+			plan.CreateSubscribers = append(plan.CreateSubscribers, subscriber)
+		}
+	}
+	wantSubscriptions := make(map[string]bool)
+	for _, subscription := range subscriptions {
+		key := subscriptionKey(topicNames[subscription.TopicID], subscriberNames[subscription.SubscriberID], subscription.SubscriptionEndpoint)
+		if wantTopics[topicNames[subscription.TopicID]] && specHasSubscription(spec, key) && !wantSubscriptions[key] {
+			wantSubscriptions[key] = true
+			plan.kept = append(plan.kept, subscription)
+			continue
+		}
+		plan.DeleteSubscriptions = append(plan.DeleteSubscriptions, subscription)
+	}
+	for _, subscription := range spec.Subscriptions {
+		if !wantSubscriptions[subscriptionKey(spec.subscriptionTopic(subscription), subscription.Subscriber, subscription.Endpoint)] {
+			plan.CreateSubscriptions = append(plan.CreateSubscriptions, subscription)
+		}
+	}
+	for key, topic := range plan.topics {
+		if !wantTopics[key] {
+			plan.DeleteTopics = append(plan.DeleteTopics, topic)
+			delete(plan.topics, key)
+		}
+	}
+	for name, subscriber := range plan.subscribers {
+		if !wantSubscribers[name] {
+			plan.DeleteSubscribers = append(plan.DeleteSubscribers, subscriber)
+			delete(plan.subscribers, name)
+		}
+	}
+	for name, producer := range plan.producers {
+		if !wantProducers[name] {
+			plan.DeleteProducers = append(plan.DeleteProducers, producer)
+			delete(plan.producers, name)
+		}
+	}
+	return plan, nil
+}
+
+// Apply converges the notification resources of the managing organization to the spec.
+// Missing resources are created first, after which resources not in the spec are deleted.
+// When any step fails the resources created so far are deleted again and an *ApplyError
+// is returned. Deleted resources are not restored
+func (c *Client) Apply(spec Spec, options ...OptionFunc) (*ApplyResult, error) {
+	plan, err := c.PlanSpec(spec, options...)
+	if err != nil {
+		return nil, err
+	}
+	var rollback internal.Rollback
+	fail := func(err error) (*ApplyResult, error) {
+		return nil, rollback.Fail(err)
+	}
+	producers := make(map[string]Producer, len(plan.producers))
+	for name, producer := range plan.producers {
+		producers[name] = producer
+	}
+	topics := make(map[string]Topic, len(plan.topics))
+	for key, topic := range plan.topics {
+		topics[key] = topic
+	}
+	subscribers := make(map[string]Subscriber, len(plan.subscribers))
+	for name, subscriber := range plan.subscribers {
+		subscribers[name] = subscriber
+	}
+	result := &ApplyResult{Plan: plan}
+	result.Subscriptions = append(result.Subscriptions, plan.kept...)
+
+	for _, producer := range plan.CreateProducers {
+		producer.ID = ""
+		created, _, err := c.Producer.CreateProducer(producer, options...)
+		if err != nil {
+			return fail(fmt.Errorf("create producer %s: %w", producer.ProducerServiceName, err))
+		}
+		producers[created.ProducerServiceName] = *created
+		rollback.Add(func() error {
+			_, _, err := c.Producer.DeleteProducer(*created, options...)
+			return err
+		})
+	}
+	for _, spec := range plan.CreateTopics {
+		topic := spec.Topic
+		topic.ID = ""
+		topic.ProducerID = producers[spec.Producer].ID
+		created, _, err := c.Topic.CreateTopic(topic, options...)
+		if err != nil {
+			return fail(fmt.Errorf("create topic %s: %w", topic.Name, err))
+		}
+		topics[topicKey(spec.Producer, created.Name)] = *created
+		rollback.Add(func() error {
+			_, _, err := c.Topic.DeleteTopic(*created, options...)
+			return err
+		})
+	}
+	for _, subscriber := range plan.CreateSubscribers {
+		subscriber.ID = ""
+		created, _, err := c.Subscriber.CreateSubscriber(subscriber, options...)
+		if err != nil {
+			return fail(fmt.Errorf("create subscriber %s: %w", subscriber.SubscriberServicename, err))
+		}
+		subscribers[created.SubscriberServicename] = *created
+		rollback.Add(func() error {
+			_, _, err := c.Subscriber.DeleteSubscriber(*created, options...)
+			return err
+		})
+	}
+	for _, subscription := range plan.CreateSubscriptions {
+		topic := plan.spec.subscriptionTopic(subscription)
+		created, _, err := c.Subscription.CreateSubscription(Subscription{
+			TopicID:              topics[topic].ID,
+			SubscriberID:         subscribers[subscription.Subscriber].ID,
+			SubscriptionEndpoint: subscription.Endpoint,
+		}, options...)
+		if err != nil {
+			return fail(fmt.Errorf("create subscription %s: %w", subscriptionKey(topic, subscription.Subscriber, subscription.Endpoint), err))
+		}
+		result.Subscriptions = append(result.Subscriptions, *created)
+		rollback.Add(func() error {
+			_, _, err := c.Subscription.DeleteSubscription(*created, options...)
+			return err
+		})
+	}
+
+	for _, subscription := range plan.DeleteSubscriptions {
+		if _, _, err := c.Subscription.DeleteSubscription(subscription, options...); err != nil {
+			return fail(fmt.Errorf("delete subscription %s: %w", subscription.ID, err))
+		}
+	}
+	for _, topic := range plan.DeleteTopics {
+		if _, _, err := c.Topic.DeleteTopic(topic, options...); err != nil {
+			return fail(fmt.Errorf("delete topic %s: %w", topic.Name, err))
+		}
+	}
+	for _, subscriber := range plan.DeleteSubscribers {
+		if _, _, err := c.Subscriber.DeleteSubscriber(subscriber, options...); err != nil {
+			return fail(fmt.Errorf("delete subscriber %s: %w", subscriber.SubscriberServicename, err))
+		}
+	}
+	for _, producer := range plan.DeleteProducers {
+		if _, _, err := c.Producer.DeleteProducer(producer, options...); err != nil {
+			return fail(fmt.Errorf("delete producer %s: %w", producer.ProducerServiceName, err))
+		}
+	}
+
+	for _, producer := range spec.Producers {
+		result.Producers = append(result.Producers, producers[producer.ProducerServiceName])
+	}
+	for _, topic := range spec.Topics {
+		result.Topics = append(result.Topics, topics[topicKey(topic.Producer, topic.Name)])
+	}
+	for _, subscriber := range spec.Subscribers {
+		result.Subscribers = append(result.Subscribers, subscribers[subscriber.SubscriberServicename])
+	}
+	return result, nil
+}
+
+// validateSpec checks that names are unique and references resolve within the spec
+func validateSpec(spec Spec) error {
+	if spec.ManagingOrganizationID == "" {
+		return fmt.Errorf("missing managing organization: %w", ErrInvalidSpec)
+	}
+	producers := make(map[string]bool)
+	for _, producer := range spec.Producers {
+		if producer.ManagingOrganizationID != spec.ManagingOrganizationID {
+			return fmt.Errorf("producer %s of another organization: %w", producer.ProducerServiceName, ErrInvalidSpec)
+		}
+		if producers[producer.ProducerServiceName] {
+			return fmt.Errorf("duplicate producer %s: %w", producer.ProducerServiceName, ErrInvalidSpec)
+		}
+		producers[producer.ProducerServiceName] = true
+	}
+	topics := make(map[string]bool)
+	topicNames := make(map[string]int)
+	for _, topic := range spec.Topics {
+		key := topicKey(topic.Producer, topic.Name)
+		if topics[key] {
+			return fmt.Errorf("duplicate topic %s: %w", key, ErrInvalidSpec)
+		}
+		if !producers[topic.Producer] {
+			return fmt.Errorf("topic %s of unknown producer %s: %w", topic.Name, topic.Producer, ErrInvalidSpec)
+		}
+		topics[key] = true
+		topicNames[topic.Name]++
+	}
+	subscribers := make(map[string]bool)
+	for _, subscriber := range spec.Subscribers {
+		if subscriber.ManagingOrganizationID != spec.ManagingOrganizationID {
+			return fmt.Errorf("subscriber %s of another organization: %w", subscriber.SubscriberServicename, ErrInvalidSpec)
+		}
+		if subscribers[subscriber.SubscriberServicename] {
+			return fmt.Errorf("duplicate subscriber %s: %w", subscriber.SubscriberServicename, ErrInvalidSpec)
+		}
+		subscribers[subscriber.SubscriberServicename] = true
+	}
+	for _, subscription := range spec.Subscriptions {
+		if subscription.Producer == "" && topicNames[subscription.Topic] > 1 {
+			return fmt.Errorf("subscription to topic %s of several producers: %w", subscription.Topic, ErrInvalidSpec)
+		}
+		topic := spec.subscriptionTopic(subscription)
+		if !topics[topic] || !subscribers[subscription.Subscriber] {
+			return fmt.Errorf("subscription %s: %w", subscriptionKey(topic, subscription.Subscriber, subscription.Endpoint), ErrInvalidSpec)
+		}
+	}
+	return nil
+}
+
+// subscriptionTopic returns the key of the topic of the subscription
+func (s Spec) subscriptionTopic(subscription SubscriptionSpec) string {
+	if subscription.Producer != "" {
+		return topicKey(subscription.Producer, subscription.Topic)
+	}
+	for _, topic := range s.Topics {
+		if topic.Name == subscription.Topic {
+			return topicKey(topic.Producer, topic.Name)
+		}
+	}
+	return subscription.Topic
+}
+
+func specHasSubscription(spec Spec, key string) bool {
+	for _, subscription := range spec.Subscriptions {
+		if subscriptionKey(spec.subscriptionTopic(subscription), subscription.Subscriber, subscription.Endpoint) == key {
+			return true
+		}
+	}
+	return false
+}
+
+func topicKey(producer, topic string) string {
+	return producer + "/" + topic
+}
+
+func subscriptionKey(topic, subscriber, endpoint string) string {
+	return topic + "/" + subscriber + "/" + endpoint
+}
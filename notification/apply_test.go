@@ -0,0 +1,214 @@
+package notification_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/philips-software/go-hsdp-api/notification"
+	"github.com/stretchr/testify/assert"
+)
+
+// provisioningState serves the resources of the notification service from memory
+type provisioningState struct {
+	sync.Mutex
+	nextID int
+	items  map[string]map[string]json.RawMessage
+	fail   string
+}
+
+func (s *provisioningState) add(kind string, item map[string]interface{}) string {
+	s.nextID++
+	id := fmt.Sprintf("%s-%d", strings.ToLower(kind), s.nextID)
+	item["_id"] = id
+	data, _ := json.Marshal(item)
+	s.items[kind][id] = data
+	return id
+}
+
+func (s *provisioningState) handle(t *testing.T, kind string) {
+	muxNotification.HandleFunc("/core/notification/"+kind, func(w http.ResponseWriter, r *http.Request) {
+		s.Lock()
+		defer s.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "GET":
+			var entries []json.RawMessage
+			for _, item := range s.items[kind] {
+				var fields map[string]interface{}
+				_ = json.Unmarshal(item, &fields)
+				if producerID := r.URL.Query().Get("producerId"); producerID != "" && fields["producerId"] != producerID {
+					continue
+				}
+				if subscriberID := r.URL.Query().Get("subscriberId"); subscriberID != "" && fields["subscriberId"] != subscriberID {
+					continue
+				}
+				entries = append(entries, item)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"resourceType": "Bundle",
+				"total":        len(entries),
+				"entry":        entries,
+			})
+		case "POST":
+			if s.fail == kind {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			var item map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&item)
+			id := s.add(kind, item)
+			_, _ = w.Write(s.items[kind][id])
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	muxNotification.HandleFunc("/core/notification/"+kind+"/", func(w http.ResponseWriter, r *http.Request) {
+		s.Lock()
+		defer s.Unlock()
+		if !assert.Equal(t, "DELETE", r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		delete(s.items[kind], strings.TrimPrefix(r.URL.Path, "/core/notification/"+kind+"/"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func TestApply(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	state := &provisioningState{items: map[string]map[string]json.RawMessage{
+		"Producer": {}, "Topic": {}, "Subscriber": {}, "Subscription": {},
+	}}
+	for _, kind := range []string{"Producer", "Topic", "Subscriber", "Subscription"} {
+		state.handle(t, kind)
+	}
+	producerID := state.add("Producer", map[string]interface{}{
+		"managingOrganizationId": notificationOrgID,
+		"producerServiceName":    "orders",
+	})
+	state.add("Topic", map[string]interface{}{"name": "stale", "producerId": producerID, "scope": "public"})
+	state.add("Producer", map[string]interface{}{"managingOrganizationId": "other-org", "producerServiceName": "foreign"})
+
+	spec := notification.Spec{
+		ManagingOrganizationID: notificationOrgID,
+		Producers: []notification.Producer{{
+			ManagingOrganizationID:      notificationOrgID,
+			ProducerProductName:         "shop",
+			ProducerServiceName:         "orders",
+			ProducerServiceInstanceName: "orders-1",
+			ProducerServiceBaseURL:      "https://orders.example.com",
+			ProducerServicePathURL:      "/notify",
+		}},
+		Topics: []notification.TopicSpec{{
+			Topic:    notification.Topic{Name: "created", Scope: "public"},
+			Producer: "orders",
+		}},
+		Subscribers: []notification.Subscriber{{
+			ManagingOrganizationID:   notificationOrgID,
+			SubscriberProductName:    "shop",
+			SubscriberServicename:    "billing",
+			SubscriberServiceBaseURL: "https://billing.example.com",
+			SubscriberServicePathURL: "/notification",
+		}},
+		Subscriptions: []notification.SubscriptionSpec{{
+			Topic:      "created",
+			Subscriber: "billing",
+			Endpoint:   "https://billing.example.com/notification",
+		}},
+	}
+
+	plan, err := notificationClient.PlanSpec(spec)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, plan.CreateProducers, 0)
+	assert.Len(t, plan.CreateTopics, 1)
+	assert.Len(t, plan.CreateSubscribers, 1)
+	assert.Len(t, plan.CreateSubscriptions, 1)
+	assert.Len(t, plan.DeleteTopics, 1)
+	assert.Len(t, plan.DeleteProducers, 0)
+
+	result, err := notificationClient.Apply(spec)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, producerID, result.Producers[0].ID)
+	assert.Equal(t, producerID, result.Topics[0].ProducerID)
+	if assert.Len(t, result.Subscriptions, 1) {
+		assert.Equal(t, result.Topics[0].ID, result.Subscriptions[0].TopicID)
+		assert.Equal(t, result.Subscribers[0].ID, result.Subscriptions[0].SubscriberID)
+	}
+	assert.Len(t, state.items["Producer"], 2)
+	assert.Len(t, state.items["Topic"], 1)
+
+	plan, err = notificationClient.PlanSpec(spec)
+	if assert.Nil(t, err) {
+		assert.True(t, plan.Empty())
+	}
+
+	// A failing step removes the resources created so far
+	spec.Topics = append(spec.Topics, notification.TopicSpec{
+		Topic:    notification.Topic{Name: "shipped", Scope: "public"},
+		Producer: "orders",
+	})
+	spec.Subscriptions = append(spec.Subscriptions, notification.SubscriptionSpec{
+		Topic:      "shipped",
+		Subscriber: "billing",
+		Endpoint:   "https://billing.example.com/notification",
+	})
+	state.fail = "Subscription"
+	_, err = notificationClient.Apply(spec)
+	var applyErr *notification.ApplyError
+	if assert.True(t, errors.As(err, &applyErr)) {
+		assert.Len(t, applyErr.RollbackErrs, 0)
+	}
+	assert.Len(t, state.items["Topic"], 1)
+	assert.Len(t, state.items["Subscription"], 1)
+
+	spec.Subscriptions[0].Subscriber = "unknown"
+	_, err = notificationClient.Apply(spec)
+	assert.True(t, errors.Is(err, notification.ErrInvalidSpec))
+
+	// Topics are identified by producer and name
+	state.fail = ""
+	spec.Subscriptions = spec.Subscriptions[:1]
+	spec.Subscriptions[0].Subscriber = "billing"
+	spec.Topics = spec.Topics[:1]
+	spec.Producers = append(spec.Producers, notification.Producer{
+		ManagingOrganizationID:      notificationOrgID,
+		ProducerProductName:         "shop",
+		ProducerServiceName:         "returns",
+		ProducerServiceInstanceName: "returns-1",
+		ProducerServiceBaseURL:      "https://returns.example.com",
+		ProducerServicePathURL:      "/notify",
+	})
+	spec.Topics = append(spec.Topics, notification.TopicSpec{
+		Topic:    notification.Topic{Name: "created", Scope: "public"},
+		Producer: "returns",
+	})
+	_, err = notificationClient.PlanSpec(spec)
+	assert.True(t, errors.Is(err, notification.ErrInvalidSpec))
+	spec.Subscriptions[0].Producer = "returns"
+	plan, err = notificationClient.PlanSpec(spec)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, plan.CreateTopics, 1)
+	assert.Len(t, plan.DeleteTopics, 0)
+	result, err = notificationClient.Apply(spec)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, state.items["Topic"], 2)
+	if assert.Len(t, result.Subscriptions, 1) {
+		assert.Equal(t, result.Topics[1].ID, result.Subscriptions[0].TopicID)
+		assert.Equal(t, result.Producers[1].ID, result.Topics[1].ProducerID)
+	}
+}
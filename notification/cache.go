@@ -0,0 +1,111 @@
+package notification
+
+import (
+	"sync"
+	"time"
+)
+
+// ttlCache keeps resources by ID for a limited time. A nil cache stores nothing
+type ttlCache[T any] struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry[T]
+}
+
+type cacheEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+func newTTLCache[T any](ttl time.Duration) *ttlCache[T] {
+	if ttl <= 0 {
+		return nil
+	}
+	return &ttlCache[T]{ttl: ttl, entries: make(map[string]cacheEntry[T])}
+}
+
+func (c *ttlCache[T]) get(id string) (T, bool) {
+	var zero T
+	if c == nil {
+		return zero, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[id]
+	if !ok {
+		return zero, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, id)
+		return zero, false
+	}
+	return entry.value, true
+}
+
+func (c *ttlCache[T]) set(id string, value T) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[id] = cacheEntry[T]{value: value, expires: time.Now().Add(c.ttl)}
+}
+
+func (c *ttlCache[T]) delete(id string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, id)
+}
+
+func (c *ttlCache[T]) clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]cacheEntry[T])
+}
+
+// GetProducerByID returns the producer with the given id. When Config.CacheTTL is set
+// the producer is served from the cache while fresh, in which case the response is nil
+func (p *ProducerService) GetProducerByID(id string, options ...OptionFunc) (*Producer, *Response, error) {
+	if id == "" {
+		return nil, nil, ErrMissingID
+	}
+	if producer, ok := p.cache.get(id); ok {
+		return &producer, nil, nil
+	}
+	producer, resp, err := p.GetProducer(id, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	p.cache.set(id, *producer)
+	return producer, resp, nil
+}
+
+// GetTopicByID returns the topic with the given id. When Config.CacheTTL is set
+// the topic is served from the cache while fresh, in which case the response is nil
+func (p *TopicService) GetTopicByID(id string, options ...OptionFunc) (*Topic, *Response, error) {
+	if id == "" {
+		return nil, nil, ErrMissingID
+	}
+	if topic, ok := p.cache.get(id); ok {
+		return &topic, nil, nil
+	}
+	topic, resp, err := p.GetTopic(id, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	p.cache.set(id, *topic)
+	return topic, resp, nil
+}
+
+// ClearCache drops all cached producers and topics
+func (c *Client) ClearCache() {
+	c.Producer.cache.clear()
+	c.Topic.cache.clear()
+}
@@ -0,0 +1,81 @@
+package notification_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/philips-software/go-hsdp-api/notification"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetByIDCache(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	producerID := "aa0e6f2c-8b7d-4a6e-9c1f-2d3e4f5a6b7c"
+	topicID := "bb1f7a3d-9c8e-4b7f-8d2a-3e4f5a6b7c8d"
+	producerCalls := 0
+	topicCalls := 0
+	muxNotification.HandleFunc("/core/notification/Producer", func(w http.ResponseWriter, r *http.Request) {
+		producerCalls++
+		assert.Equal(t, producerID, r.URL.Query().Get("_id"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"total": 1, "entry": [{"_id": "`+producerID+`", "producerServiceName": "orders"}]}`)
+	})
+	muxNotification.HandleFunc("/core/notification/Topic", func(w http.ResponseWriter, r *http.Request) {
+		topicCalls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"total": 1, "entry": [{"_id": "`+topicID+`", "name": "created"}]}`)
+	})
+	muxNotification.HandleFunc("/core/notification/Topic/"+topicID, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// Without CacheTTL every call reaches the service
+	for i := 0; i < 2; i++ {
+		producer, resp, err := notificationClient.Producer.GetProducerByID(producerID)
+		if assert.Nil(t, err) && assert.NotNil(t, producer) {
+			assert.Equal(t, "orders", producer.ProducerServiceName)
+			assert.NotNil(t, resp)
+		}
+	}
+	assert.Equal(t, 2, producerCalls)
+
+	client, err := notification.NewClient(iamClient, &notification.Config{
+		NotificationURL: serverNotification.URL,
+		CacheTTL:        50 * time.Millisecond,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	producerCalls = 0
+	for i := 0; i < 3; i++ {
+		_, _, err := client.Producer.GetProducerByID(producerID)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 1, producerCalls)
+	time.Sleep(60 * time.Millisecond)
+	_, _, err = client.Producer.GetProducerByID(producerID)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, producerCalls)
+
+	_, _, _ = client.Topic.GetTopicByID(topicID)
+	_, resp, _ := client.Topic.GetTopicByID(topicID)
+	assert.Nil(t, resp)
+	assert.Equal(t, 1, topicCalls)
+	_, _, err = client.Topic.DeleteTopic(notification.Topic{ID: topicID})
+	assert.Nil(t, err)
+	_, _, _ = client.Topic.GetTopicByID(topicID)
+	assert.Equal(t, 2, topicCalls)
+
+	client.ClearCache()
+	_, _, _ = client.Topic.GetTopicByID(topicID)
+	assert.Equal(t, 3, topicCalls)
+
+	_, _, err = client.Topic.GetTopicByID("")
+	assert.ErrorIs(t, err, notification.ErrMissingID)
+}
@@ -3,6 +3,7 @@ package notification
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,6 +11,8 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/go-querystring/query"
@@ -26,6 +29,14 @@ const (
 // OptionFunc is the function signature function for options
 type OptionFunc func(*http.Request) error
 
+// WithContext runs the request with the provided context
+func WithContext(ctx context.Context) OptionFunc {
+	return func(req *http.Request) error {
+		*req = *req.WithContext(ctx)
+		return nil
+	}
+}
+
 // Config contains the configuration of a client
 type Config struct {
 	Region          string
@@ -35,7 +46,13 @@ type Config struct {
 	Type            string
 	TimeZone        string
 	DebugLog        string
-	Retry           int
+	// Retry is the number of times transient 429 and 5xx responses are retried.
+	// Non-idempotent requests are only retried on 429. Retries are disabled when zero
+	Retry int
+	// RetryMaxDelay caps the delay between retries, including Retry-After. Defaults to 30 seconds
+	RetryMaxDelay time.Duration
+	// CacheTTL enables caching of GetProducerByID and GetTopicByID results for this duration
+	CacheTTL time.Duration
 }
 
 // A Client manages communication with HSDP Notification API
@@ -53,7 +70,11 @@ type Client struct {
 	debugFile *os.File
 	validate  *validator.Validate
 
+	schemaMu sync.RWMutex
+	schemas  map[string]Schema
+
 	Producer     *ProducerService
+	Publisher    *PublishService
 	Subscription *SubscriptionService
 	Subscriber   *SubscriberService
 	Topic        *TopicService
@@ -73,10 +94,11 @@ func newClient(iamClient *iam.Client, config *Config) (*Client, error) {
 		return nil, err
 	}
 
-	c.Producer = &ProducerService{client: c, validate: validator.New()}
+	c.Producer = &ProducerService{client: c, validate: validator.New(), cache: newTTLCache[Producer](config.CacheTTL)}
+	c.Publisher = &PublishService{client: c, validate: validator.New()}
 	c.Subscriber = &SubscriberService{client: c, validate: validator.New()}
 	c.Subscription = &SubscriptionService{client: c, validate: validator.New()}
-	c.Topic = &TopicService{client: c, validate: validator.New()}
+	c.Topic = &TopicService{client: c, validate: validator.New(), cache: newTTLCache[Topic](config.CacheTTL)}
 
 	return c, nil
 }
@@ -162,6 +184,9 @@ func (c *Client) newNotificationRequest(method, path string, opt interface{}, op
 		u.RawQuery = ""
 		req.Body = io.NopCloser(bodyReader)
 		req.ContentLength = int64(bodyReader.Len())
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(bodyBytes)), nil
+		}
 		req.Header.Set("Content-Type", "application/json")
 	}
 	token, err := c.iamClient.Token()
@@ -210,7 +235,7 @@ func (c *Client) TokenRefresh() error {
 // interface, the raw response body will be written to v, without attempting to
 // first decode it.
 func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
-	resp, err := c.iamClient.HttpClient().Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, err
 	}
@@ -219,6 +244,7 @@ func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
 
 	err = internal.CheckResponse(resp)
 	if err != nil {
+		err = parseServiceError(resp, err)
 		// even though there was an error, we still return the response
 		// in case the caller wants to inspect it further
 		return response, err
@@ -234,3 +260,22 @@ func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
 	}
 	return response, err
 }
+
+// send executes the request, retrying transient failures as configured by Config.Retry
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	return internal.DoWithRetry(c.iamClient.HttpClient(), req, c.config.Retry, c.config.RetryMaxDelay, func(resp *http.Response) bool {
+		return isTransient(req, resp.StatusCode)
+	})
+}
+
+// isTransient reports whether the response is worth retrying. Throttled requests
+// were not processed and are always retried, server errors only for idempotent requests
+func isTransient(req *http.Request, statusCode int) bool {
+	if statusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if statusCode < 500 || statusCode == http.StatusNotImplemented {
+		return false
+	}
+	return internal.IsIdempotent(req)
+}
@@ -1,6 +1,8 @@
 package notification_test
 
 import (
+	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -200,3 +202,94 @@ func TestAutoconfig(t *testing.T) {
 	_, _ = notification.NewClient(nil, cfg)
 	assert.Equal(t, foo, cfg.NotificationURL)
 }
+
+func TestServiceError(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	muxNotification.HandleFunc("/core/notification/Topic", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("HSDP-Transaction-ID", "b2f0e7a4-1c3d-4e5f-8a9b-0c1d2e3f4a5b")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{
+  "resourceType": "OperationOutcome",
+  "issue": [{"severity": "error", "code": "invalid", "diagnostics": "Invalid scope"}]
+}`)
+	})
+
+	_, resp, err := notificationClient.Topic.CreateTopic(notification.Topic{
+		Name:       "name",
+		ProducerID: "producer",
+		Scope:      "bogus",
+	})
+	if !assert.NotNil(t, err) || !assert.NotNil(t, resp) {
+		return
+	}
+	var serviceErr *notification.ServiceError
+	if !assert.True(t, errors.As(err, &serviceErr)) {
+		return
+	}
+	assert.Equal(t, http.StatusBadRequest, serviceErr.StatusCode)
+	assert.Equal(t, "invalid", serviceErr.Code())
+	assert.Equal(t, "b2f0e7a4-1c3d-4e5f-8a9b-0c1d2e3f4a5b", serviceErr.TransactionID)
+	assert.Contains(t, serviceErr.Error(), "Invalid scope")
+	assert.NotNil(t, errors.Unwrap(serviceErr))
+}
+
+func TestRetry(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	client, err := notification.NewClient(iamClient, &notification.Config{
+		NotificationURL: serverNotification.URL,
+		Retry:           2,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	topicID := "f6a2b1c4-6e47-4a7e-9d1b-3b0b8e3c2a10"
+	getCalls := 0
+	postCalls := 0
+	muxNotification.HandleFunc("/core/notification/Topic", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "GET":
+			getCalls++
+			if getCalls < 3 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{
+  "resourceType": "Bundle",
+  "type": "searchset",
+  "total": 1,
+  "entry": [{"_id": "`+topicID+`", "name": "Topic1", "resourceType": "Topic"}]
+}`)
+		case "POST":
+			postCalls++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+
+	topic, _, err := client.Topic.GetTopic(topicID)
+	if !assert.Nil(t, err) || !assert.NotNil(t, topic) {
+		return
+	}
+	assert.Equal(t, topicID, topic.ID)
+	assert.Equal(t, 3, getCalls)
+
+	_, _, err = client.Topic.CreateTopic(notification.Topic{
+		Name:       "Topic1",
+		ProducerID: "producer",
+		Scope:      "public",
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, postCalls, "non-idempotent requests must not be retried on 5xx")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = client.Topic.GetTopic(topicID, notification.WithContext(ctx))
+	assert.ErrorIs(t, err, context.Canceled)
+}
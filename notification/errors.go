@@ -1,11 +1,109 @@
 package notification
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
 )
 
 // Errors
 var (
 	ErrNotificationURLCannotBeEmpty = errors.New("base Notification URL cannot be empty")
 	ErrEmptyResult                  = errors.New("empty result")
+	ErrMissingID                    = errors.New("missing ID")
+	ErrMessageTooLarge              = errors.New("message exceeds the maximum size")
+	ErrInvalidNextLink              = errors.New("next link points to another host")
+	ErrMissingQueue                 = errors.New("missing queue")
+	ErrMissingHandler               = errors.New("missing handler")
+	ErrInvalidScope                 = errors.New("invalid topic scope")
+	ErrInvalidSpec                  = errors.New("invalid provisioning spec")
+	ErrInvalidURL                   = errors.New("URL must be an absolute https URL without credentials, query or fragment")
+	ErrMissingName                  = errors.New("missing name")
+	ErrInvalidSchema                = errors.New("invalid JSON schema")
+	ErrSchemaViolation              = errors.New("message does not match the topic schema")
 )
+
+// Issue is a single issue of an OperationOutcome returned by the service
+type Issue struct {
+	Severity    string `json:"severity"`
+	Code        string `json:"code"`
+	Diagnostics string `json:"diagnostics"`
+	Details     struct {
+		Text string `json:"text"`
+	} `json:"details"`
+}
+
+// ServiceError is an error response of the Notification service, parsed from
+// its OperationOutcome body. Err is the generic error of the response
+type ServiceError struct {
+	StatusCode int
+	// TransactionID is taken from the HSDP-Transaction-ID header or the transactionId of the body
+	TransactionID string
+	Issues        []Issue
+	Err           error
+}
+
+func (e *ServiceError) Error() string {
+	var messages []string
+	for _, issue := range e.Issues {
+		message := issue.Code
+		if issue.Diagnostics != "" {
+			message += ": " + issue.Diagnostics
+		} else if issue.Details.Text != "" {
+			message += ": " + issue.Details.Text
+		}
+		messages = append(messages, message)
+	}
+	msg := fmt.Sprintf("notification: HTTP %d: %s", e.StatusCode, strings.Join(messages, "; "))
+	if e.TransactionID != "" {
+		msg += " (transaction " + e.TransactionID + ")"
+	}
+	return msg
+}
+
+func (e *ServiceError) Unwrap() error {
+	return e.Err
+}
+
+// Code returns the code of the first issue, if any
+func (e *ServiceError) Code() string {
+	if len(e.Issues) == 0 {
+		return ""
+	}
+	return e.Issues[0].Code
+}
+
+// parseServiceError turns the error of a failed response into a *ServiceError
+// when the body is an OperationOutcome, otherwise err is returned as is
+func parseServiceError(resp *http.Response, err error) error {
+	if resp == nil || resp.Body == nil {
+		return err
+	}
+	data, readErr := io.ReadAll(resp.Body)
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if readErr != nil {
+		return err
+	}
+	var outcome struct {
+		ResourceType  string  `json:"resourceType"`
+		TransactionID string  `json:"transactionId"`
+		Issue         []Issue `json:"issue"`
+	}
+	if json.Unmarshal(data, &outcome) != nil || len(outcome.Issue) == 0 {
+		return err
+	}
+	transactionID := resp.Header.Get("HSDP-Transaction-ID")
+	if transactionID == "" {
+		transactionID = outcome.TransactionID
+	}
+	return &ServiceError{
+		StatusCode:    resp.StatusCode,
+		TransactionID: transactionID,
+		Issues:        outcome.Issue,
+		Err:           err,
+	}
+}
@@ -0,0 +1,146 @@
+package notification
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Iterator walks over all resources of a search, following the next links of
+// the result bundles on demand
+type Iterator[T any] struct {
+	client  *Client
+	path    string
+	opt     *GetOptions
+	options []OptionFunc
+
+	started bool
+	next    *url.URL
+	page    []T
+	index   int
+
+	current T
+	resp    *Response
+	err     error
+}
+
+type bundle[T any] struct {
+	ResourceType string `json:"resourceType,omitempty"`
+	Type         string `json:"type,omitempty"`
+	Total        int    `json:"total"`
+	Link         []struct {
+		Relation string `json:"relation"`
+		URL      string `json:"url"`
+	} `json:"link"`
+	Entry []T `json:"entry"`
+}
+
+func newIterator[T any](client *Client, path string, opt *GetOptions, options []OptionFunc) *Iterator[T] {
+	return &Iterator[T]{client: client, path: path, opt: opt, options: options}
+}
+
+// Next advances the iterator. It returns false when all resources have been
+// visited or an error occurred. Check Err() to distinguish between the two.
+func (it *Iterator[T]) Next() bool {
+	for it.index >= len(it.page) {
+		if it.err != nil || (it.started && it.next == nil) {
+			return false
+		}
+		it.fetch()
+	}
+	it.current = it.page[it.index]
+	it.index++
+	return true
+}
+
+func (it *Iterator[T]) fetch() {
+	var req *http.Request
+	var err error
+	if !it.started {
+		it.started = true
+		req, err = it.client.newNotificationRequest("GET", it.path, it.opt, it.options...)
+	} else {
+		req, err = it.client.newNotificationRequest("GET", it.path, nil, it.options...)
+		if err == nil {
+			req.URL = it.next
+			req.Host = it.next.Host
+		}
+	}
+	it.next = nil
+	if err != nil {
+		it.err = err
+		return
+	}
+	var result bundle[T]
+	resp, err := it.client.do(req, &result)
+	it.resp = resp
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return
+		}
+		it.err = err
+		return
+	}
+	it.page = result.Entry
+	it.index = 0
+	for _, link := range result.Link {
+		if link.Relation != "next" || link.URL == "" {
+			continue
+		}
+		u, err := req.URL.Parse(link.URL)
+		if err != nil {
+			it.err = fmt.Errorf("next link: %w", err)
+			return
+		}
+		// Never send our token to another host
+		if u.Host != it.client.notificationURL.Host {
+			it.err = fmt.Errorf("next link [%s]: %w", link.URL, ErrInvalidNextLink)
+			return
+		}
+		it.next = u
+	}
+}
+
+// Item returns the current resource
+func (it *Iterator[T]) Item() T {
+	return it.current
+}
+
+// Response returns the response of the last page request
+func (it *Iterator[T]) Response() *Response {
+	return it.resp
+}
+
+// Err returns the error, if any, that stopped the iteration
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All collects the remaining resources of the iterator
+func (it *Iterator[T]) All() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// IterateProducers returns an iterator over all producers matching opt
+func (p *ProducerService) IterateProducers(opt *GetOptions, options ...OptionFunc) *Iterator[Producer] {
+	return newIterator[Producer](p.client, "core/notification/Producer", opt, options)
+}
+
+// IterateSubscribers returns an iterator over all subscribers matching opt
+func (p *SubscriberService) IterateSubscribers(opt *GetOptions, options ...OptionFunc) *Iterator[Subscriber] {
+	return newIterator[Subscriber](p.client, "core/notification/Subscriber", opt, options)
+}
+
+// IterateTopics returns an iterator over all topics matching opt
+func (p *TopicService) IterateTopics(opt *GetOptions, options ...OptionFunc) *Iterator[Topic] {
+	return newIterator[Topic](p.client, "core/notification/Topic", opt, options)
+}
+
+// IterateSubscriptions returns an iterator over all subscriptions matching opt
+func (p *SubscriptionService) IterateSubscriptions(opt *GetOptions, options ...OptionFunc) *Iterator[Subscription] {
+	return newIterator[Subscription](p.client, "core/notification/Subscription", opt, options)
+}
@@ -0,0 +1,73 @@
+package notification_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/philips-software/go-hsdp-api/notification"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterateProducers(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	muxNotification.HandleFunc("/core/notification/Producer", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, "GET", r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Query().Get("_page") {
+		case "", "1":
+			assert.Equal(t, "2", r.URL.Query().Get("_count"))
+			_, _ = io.WriteString(w, `{
+  "resourceType": "Bundle",
+  "type": "searchset",
+  "total": 3,
+  "link": [{"relation": "next", "url": "`+serverNotification.URL+`/core/notification/Producer?_count=2&_page=2"}],
+  "entry": [{"_id": "p1", "resourceType": "Producer"}, {"_id": "p2", "resourceType": "Producer"}]
+}`)
+		case "2":
+			_, _ = io.WriteString(w, `{
+  "resourceType": "Bundle",
+  "type": "searchset",
+  "total": 3,
+  "entry": [{"_id": "p3", "resourceType": "Producer"}]
+}`)
+		case "evil":
+			_, _ = io.WriteString(w, `{
+  "resourceType": "Bundle",
+  "type": "searchset",
+  "total": 1,
+  "link": [{"relation": "next", "url": "https://evil.example.com/core/notification/Producer?_page=2"}],
+  "entry": [{"_id": "p1", "resourceType": "Producer"}]
+}`)
+		}
+	})
+
+	size := 2
+	producers, err := notificationClient.Producer.IterateProducers(&notification.GetOptions{Size: &size}).All()
+	if !assert.Nil(t, err) || !assert.Len(t, producers, 3) {
+		return
+	}
+	assert.Equal(t, "p3", producers[2].ID)
+
+	page := 2
+	single, _, err := notificationClient.Producer.GetProducers(&notification.GetOptions{Page: &page, Size: &size})
+	if !assert.Nil(t, err) || !assert.Len(t, single, 1) {
+		return
+	}
+	assert.Equal(t, "p3", single[0].ID)
+
+	it := notificationClient.Producer.IterateProducers(nil, func(r *http.Request) error {
+		r.URL.RawQuery = "_page=evil"
+		return nil
+	})
+	producers, err = it.All()
+	assert.Len(t, producers, 1)
+	assert.True(t, errors.Is(err, notification.ErrInvalidNextLink))
+}
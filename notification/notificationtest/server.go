@@ -0,0 +1,439 @@
+// Package notificationtest provides an in-memory mock HSDP Notification service for
+// testing code which uses the notification package, without needing a live tenant.
+package notificationtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/philips-software/go-hsdp-api/iam/iamtest"
+	"github.com/philips-software/go-hsdp-api/notification"
+)
+
+// Resource kinds of the service
+const (
+	KindProducer     = "Producer"
+	KindTopic        = "Topic"
+	KindSubscriber   = "Subscriber"
+	KindSubscription = "Subscription"
+)
+
+const basePath = "/core/notification/"
+
+// Server is an httptest server mocking the Notification service, together with the mock
+// IAM server issuing its tokens. Producers, topics, subscribers and subscriptions are kept
+// in memory and published messages are captured. Additional handlers can be registered on Mux
+type Server struct {
+	Notification *httptest.Server
+	IAM          *iamtest.Server
+	Mux          *http.ServeMux
+
+	OrgID string
+
+	mu        sync.Mutex
+	resources map[string]*collection
+	published []PublishedMessage
+}
+
+type collection struct {
+	order []string
+	items map[string]map[string]interface{}
+}
+
+// PublishedMessage is a message captured by the Publish endpoint
+type PublishedMessage struct {
+	ID      string
+	TopicID string
+	// Message is the decoded message as passed to Publisher.Publish
+	Message []byte
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithOrganization sets the organization of the IAM user. Defaults to iamtest.DefaultOrgID
+func WithOrganization(orgID string) Option {
+	return func(s *Server) {
+		s.OrgID = orgID
+	}
+}
+
+// NewServer starts a mock Notification server. Call Close when done
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		Mux:       http.NewServeMux(),
+		OrgID:     iamtest.DefaultOrgID,
+		resources: make(map[string]*collection),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.IAM = iamtest.NewServer(iamtest.WithOrganization(s.OrgID))
+	s.Notification = httptest.NewServer(s.Mux)
+	for _, kind := range []string{KindProducer, KindTopic, KindSubscriber, KindSubscription} {
+		s.resources[kind] = &collection{items: make(map[string]map[string]interface{})}
+		s.Mux.HandleFunc(basePath+kind, s.handleCollection(kind))
+		s.Mux.HandleFunc(basePath+kind+"/", s.handleResource(kind))
+	}
+	s.Mux.HandleFunc(basePath+"Publish", s.handlePublish)
+	return s
+}
+
+// Close shuts down the servers
+func (s *Server) Close() {
+	s.Notification.Close()
+	s.IAM.Close()
+}
+
+// Config returns a client configuration pointing to the mock service
+func (s *Server) Config() *notification.Config {
+	return &notification.Config{
+		NotificationURL: s.Notification.URL,
+	}
+}
+
+// NewClient returns a client of the mock service, logged in to the mock IAM server
+func (s *Server) NewClient() (*notification.Client, error) {
+	iamClient, err := s.IAM.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	return notification.NewClient(iamClient, s.Config())
+}
+
+// AddProducer seeds the service with the producer and returns it with its assigned ID
+func (s *Server) AddProducer(producer notification.Producer) notification.Producer {
+	var stored notification.Producer
+	s.add(KindProducer, producer, &stored)
+	return stored
+}
+
+// AddTopic seeds the service with the topic and returns it with its assigned ID
+func (s *Server) AddTopic(topic notification.Topic) notification.Topic {
+	var stored notification.Topic
+	s.add(KindTopic, topic, &stored)
+	return stored
+}
+
+// AddSubscriber seeds the service with the subscriber and returns it with its assigned ID
+func (s *Server) AddSubscriber(subscriber notification.Subscriber) notification.Subscriber {
+	var stored notification.Subscriber
+	s.add(KindSubscriber, subscriber, &stored)
+	return stored
+}
+
+// AddSubscription seeds the service with the subscription and returns it with its assigned ID
+func (s *Server) AddSubscription(subscription notification.Subscription) notification.Subscription {
+	var stored notification.Subscription
+	s.add(KindSubscription, subscription, &stored)
+	return stored
+}
+
+// Producers returns the stored producers in creation order
+func (s *Server) Producers() []notification.Producer {
+	var producers []notification.Producer
+	s.list(KindProducer, &producers)
+	return producers
+}
+
+// Topics returns the stored topics in creation order
+func (s *Server) Topics() []notification.Topic {
+	var topics []notification.Topic
+	s.list(KindTopic, &topics)
+	return topics
+}
+
+// Subscribers returns the stored subscribers in creation order
+func (s *Server) Subscribers() []notification.Subscriber {
+	var subscribers []notification.Subscriber
+	s.list(KindSubscriber, &subscribers)
+	return subscribers
+}
+
+// Subscriptions returns the stored subscriptions in creation order
+func (s *Server) Subscriptions() []notification.Subscription {
+	var subscriptions []notification.Subscription
+	s.list(KindSubscription, &subscriptions)
+	return subscriptions
+}
+
+// Published returns the messages published to the topic, or all messages when topicID is empty
+func (s *Server) Published(topicID string) []PublishedMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var messages []PublishedMessage
+	for _, message := range s.published {
+		if topicID == "" || message.TopicID == topicID {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
+
+// Reset removes all resources and captured messages
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for kind := range s.resources {
+		s.resources[kind] = &collection{items: make(map[string]map[string]interface{})}
+	}
+	s.published = nil
+}
+
+func (s *Server) add(kind string, resource interface{}, stored interface{}) {
+	data, _ := json.Marshal(resource)
+	var item map[string]interface{}
+	_ = json.Unmarshal(data, &item)
+	s.mu.Lock()
+	item = s.store(kind, item)
+	s.mu.Unlock()
+	data, _ = json.Marshal(item)
+	_ = json.Unmarshal(data, stored)
+}
+
+func (s *Server) list(kind string, v interface{}) {
+	s.mu.Lock()
+	c := s.resources[kind]
+	items := make([]map[string]interface{}, 0, len(c.order))
+	for _, id := range c.order {
+		items = append(items, c.items[id])
+	}
+	s.mu.Unlock()
+	data, _ := json.Marshal(items)
+	_ = json.Unmarshal(data, v)
+}
+
+// store assigns an ID to the item and stores it. The caller must hold s.mu
+func (s *Server) store(kind string, item map[string]interface{}) map[string]interface{} {
+	id := uuid.New().String()
+	item["_id"] = id
+	item["resourceType"] = kind
+	c := s.resources[kind]
+	c.order = append(c.order, id)
+	c.items[id] = item
+	return item
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeOutcome(w http.ResponseWriter, status int, code, diagnostics string) {
+	writeJSON(w, status, map[string]interface{}{
+		"resourceType": "OperationOutcome",
+		"issue": []map[string]string{{
+			"severity":    "error",
+			"code":        code,
+			"diagnostics": diagnostics,
+		}},
+	})
+}
+
+func (s *Server) authorized(w http.ResponseWriter, r *http.Request) bool {
+	if r.Header.Get("Authorization") != "Bearer "+s.IAM.AccessToken {
+		writeOutcome(w, http.StatusUnauthorized, "login", "invalid token")
+		return false
+	}
+	return true
+}
+
+func (s *Server) handleCollection(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.authorized(w, r) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			s.mu.Lock()
+			c := s.resources[kind]
+			entry := []map[string]interface{}{}
+			for _, id := range c.order {
+				if matches(c.items[id], r) {
+					entry = append(entry, c.items[id])
+				}
+			}
+			s.mu.Unlock()
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"resourceType": "Bundle",
+				"type":         "searchset",
+				"total":        len(entry),
+				"entry":        entry,
+			})
+		case http.MethodPost:
+			var item map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+				writeOutcome(w, http.StatusBadRequest, "invalid", err.Error())
+				return
+			}
+			s.mu.Lock()
+			if missing := s.missingReference(kind, item); missing != "" {
+				s.mu.Unlock()
+				writeOutcome(w, http.StatusBadRequest, "invalid", "unknown "+missing)
+				return
+			}
+			item = s.store(kind, item)
+			s.mu.Unlock()
+			status := http.StatusCreated
+			if kind == KindProducer || kind == KindTopic {
+				status = http.StatusOK
+			}
+			writeJSON(w, status, item)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func (s *Server) handleResource(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.authorized(w, r) {
+			return
+		}
+		id := strings.TrimPrefix(r.URL.Path, basePath+kind+"/")
+		if kind == KindSubscription && id == "_confirm" && r.Method == http.MethodPost {
+			s.handleConfirm(w, r)
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		c := s.resources[kind]
+		item, ok := c.items[id]
+		if !ok {
+			writeOutcome(w, http.StatusNotFound, "not-found", fmt.Sprintf("%s %s not found", kind, id))
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, item)
+		case http.MethodPut:
+			if kind != KindProducer && kind != KindTopic {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			var update map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				writeOutcome(w, http.StatusBadRequest, "invalid", err.Error())
+				return
+			}
+			update["_id"] = id
+			update["resourceType"] = kind
+			c.items[id] = update
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			delete(c.items, id)
+			for i, stored := range c.order {
+				if stored == id {
+					c.order = append(c.order[:i], c.order[i+1:]...)
+					break
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func (s *Server) handleConfirm(w http.ResponseWriter, r *http.Request) {
+	var confirm notification.ConfirmRequest
+	if err := json.NewDecoder(r.Body).Decode(&confirm); err != nil {
+		writeOutcome(w, http.StatusBadRequest, "invalid", err.Error())
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.resources[KindSubscription]
+	for _, id := range c.order {
+		if c.items[id]["subscriptionEndpoint"] == confirm.Endpoint {
+			writeJSON(w, http.StatusCreated, c.items[id])
+			return
+		}
+	}
+	writeOutcome(w, http.StatusNotFound, "not-found", "no subscription for endpoint "+confirm.Endpoint)
+}
+
+func (s *Server) handlePublish(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(w, r) {
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	var request notification.PublishRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeOutcome(w, http.StatusBadRequest, "invalid", err.Error())
+		return
+	}
+	message, err := base64.StdEncoding.DecodeString(request.Message)
+	if err != nil {
+		writeOutcome(w, http.StatusBadRequest, "invalid", "message is not base64 encoded")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.resources[KindTopic].items[request.TopicID]; !ok {
+		writeOutcome(w, http.StatusNotFound, "not-found", "topic "+request.TopicID+" not found")
+		return
+	}
+	published := PublishedMessage{
+		ID:      uuid.New().String(),
+		TopicID: request.TopicID,
+		Message: message,
+	}
+	s.published = append(s.published, published)
+	writeJSON(w, http.StatusOK, notification.PublishResponse{
+		ID:           published.ID,
+		ResourceType: "Publish",
+		TopicID:      published.TopicID,
+	})
+}
+
+// missingReference returns the referenced resource of item that does not exist, if any.
+// The caller must hold s.mu
+func (s *Server) missingReference(kind string, item map[string]interface{}) string {
+	check := func(field, referenced string) string {
+		id, _ := item[field].(string)
+		if _, ok := s.resources[referenced].items[id]; !ok {
+			return field + " " + id
+		}
+		return ""
+	}
+	switch kind {
+	case KindTopic:
+		return check("producerId", KindProducer)
+	case KindSubscription:
+		if missing := check("topicId", KindTopic); missing != "" {
+			return missing
+		}
+		return check("subscriberId", KindSubscriber)
+	}
+	return ""
+}
+
+// matches reports whether item has the field values of the search query
+func matches(item map[string]interface{}, r *http.Request) bool {
+	for key, values := range r.URL.Query() {
+		switch key {
+		case "_page", "_count":
+			continue
+		case "managedOrganizationId":
+			key = "managingOrganizationId"
+		case "managedOrganization":
+			key = "managingOrganization"
+		}
+		value, ok := item[key]
+		if !ok || len(values) == 0 || fmt.Sprint(value) != values[0] {
+			return false
+		}
+	}
+	return true
+}
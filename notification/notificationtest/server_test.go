@@ -0,0 +1,99 @@
+package notificationtest_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/philips-software/go-hsdp-api/iam/iamtest"
+	"github.com/philips-software/go-hsdp-api/notification"
+	"github.com/philips-software/go-hsdp-api/notification/notificationtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer(t *testing.T) {
+	server := notificationtest.NewServer()
+	defer server.Close()
+
+	client, err := server.NewClient()
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	producer, _, err := client.Producer.CreateProducer(notification.Producer{
+		ManagingOrganizationID:      iamtest.DefaultOrgID,
+		ProducerProductName:         "shop",
+		ProducerServiceName:         "orders",
+		ProducerServiceInstanceName: "orders-1",
+		ProducerServiceBaseURL:      "https://orders.example.com",
+		ProducerServicePathURL:      "/notify",
+	})
+	if !assert.Nil(t, err) || !assert.NotNil(t, producer) {
+		return
+	}
+	assert.NotEmpty(t, producer.ID)
+
+	topic, _, err := client.Topic.CreateTopic(notification.Topic{
+		Name:       "created",
+		ProducerID: producer.ID,
+		Scope:      notification.ScopePublic,
+	})
+	if !assert.Nil(t, err) || !assert.NotNil(t, topic) {
+		return
+	}
+	_, _, err = client.Topic.CreateTopic(notification.Topic{
+		Name:       "orphan",
+		ProducerID: "unknown",
+		Scope:      notification.ScopePublic,
+	})
+	var serviceErr *notification.ServiceError
+	if assert.True(t, errors.As(err, &serviceErr)) {
+		assert.Equal(t, http.StatusBadRequest, serviceErr.StatusCode)
+	}
+
+	topic.Description = "Order created"
+	updated, _, err := client.Topic.UpdateTopic(*topic)
+	if assert.Nil(t, err) && assert.NotNil(t, updated) {
+		assert.Equal(t, "Order created", updated.Description)
+	}
+
+	name := "created"
+	topics, _, err := client.Topic.GetTopics(&notification.GetOptions{Name: &name})
+	if assert.Nil(t, err) && assert.Len(t, topics, 1) {
+		assert.Equal(t, topic.ID, topics[0].ID)
+	}
+
+	subscriber := server.AddSubscriber(notification.Subscriber{
+		ManagingOrganizationID: iamtest.DefaultOrgID,
+		SubscriberServicename:  "billing",
+	})
+	subscription, _, err := client.Subscription.CreateSubscription(notification.Subscription{
+		TopicID:              topic.ID,
+		SubscriberID:         subscriber.ID,
+		SubscriptionEndpoint: "https://billing.example.com/notification",
+	})
+	if !assert.Nil(t, err) || !assert.NotNil(t, subscription) {
+		return
+	}
+	assert.Len(t, server.Subscriptions(), 1)
+
+	receipt, _, err := client.Publisher.Publish(topic.ID, []byte(`{"order":"1"}`))
+	if assert.Nil(t, err) && assert.NotNil(t, receipt) {
+		assert.Equal(t, topic.ID, receipt.TopicID)
+	}
+	_, _, err = client.Publisher.Publish("unknown", []byte(`{}`))
+	assert.NotNil(t, err)
+	published := server.Published(topic.ID)
+	if assert.Len(t, published, 1) {
+		assert.Equal(t, `{"order":"1"}`, string(published[0].Message))
+	}
+
+	ok, _, err := client.Subscription.DeleteSubscription(*subscription)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Len(t, server.Subscriptions(), 0)
+
+	server.Reset()
+	assert.Len(t, server.Producers(), 0)
+	assert.Len(t, server.Published(""), 0)
+}
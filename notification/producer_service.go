@@ -13,6 +13,7 @@ type ProducerService struct {
 	client *Client
 
 	validate *validator.Validate
+	cache    *ttlCache[Producer]
 }
 
 type Producer struct {
@@ -38,13 +39,18 @@ type GetOptions struct {
 	Scope                 *string `url:"scope,omitempty"`
 	Name                  *string `url:"name,omitempty"`
 	ProducerID            *string `url:"producerId,omitempty"`
+	TopicID               *string `url:"topicId,omitempty"`
+	SubscriberID          *string `url:"subscriberId,omitempty"`
+	// Page and Size select a single page of results. Use the Iterate methods to walk all pages
+	Page *int `url:"_page,omitempty"`
+	Size *int `url:"_count,omitempty"`
 }
 
-func (p *ProducerService) CreateProducer(producer Producer) (*Producer, *Response, error) {
+func (p *ProducerService) CreateProducer(producer Producer, options ...OptionFunc) (*Producer, *Response, error) {
 	if err := p.validate.Struct(producer); err != nil {
 		return nil, nil, err
 	}
-	req, err := p.client.newNotificationRequest("POST", "core/notification/Producer", producer, nil)
+	req, err := p.client.newNotificationRequest("POST", "core/notification/Producer", producer, options...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -59,6 +65,65 @@ func (p *ProducerService) CreateProducer(producer Producer) (*Producer, *Respons
 	return &createdProducer, resp, nil
 }
 
+// UpdateProducer updates the producer, e.g. its base URL or description. Fields left
+// empty are taken from the current producer, so only the changes need to be set.
+// This also means a field cannot be cleared, e.g. an empty Description keeps the
+// current description. The cache of GetProducerByID is refreshed with the result
+func (p *ProducerService) UpdateProducer(producer Producer, options ...OptionFunc) (*Producer, *Response, error) {
+	if producer.ID == "" {
+		return nil, nil, ErrMissingID
+	}
+	current, resp, err := p.GetProducer(producer.ID, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	merged := mergeProducer(*current, producer)
+	if err := p.validate.Struct(merged); err != nil {
+		return nil, nil, err
+	}
+	req, err := p.client.newNotificationRequest("PUT", "core/notification/Producer/"+producer.ID, merged, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	var updateResponse bytes.Buffer
+	resp, err = p.client.do(req, &updateResponse)
+	// Only invalidate now, a concurrent GetProducerByID could otherwise cache the old producer again
+	p.cache.delete(producer.ID)
+	if (err != nil && err != io.EOF) || resp == nil {
+		if resp == nil && err != nil {
+			err = fmt.Errorf("UpdateProducer: %w", ErrEmptyResult)
+		}
+		return nil, resp, err
+	}
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return nil, resp, fmt.Errorf("update error: %v", updateResponse)
+	}
+	updated, resp, err := p.GetProducer(producer.ID, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	p.cache.set(producer.ID, *updated)
+	return updated, resp, nil
+}
+
+func mergeProducer(current, update Producer) Producer {
+	merged := current
+	set := func(dst *string, value string) {
+		if value != "" {
+			*dst = value
+		}
+	}
+	set(&merged.ManagingOrganizationID, update.ManagingOrganizationID)
+	set(&merged.ManagingOrganization, update.ManagingOrganization)
+	set(&merged.ProducerProductName, update.ProducerProductName)
+	set(&merged.ProducerServiceName, update.ProducerServiceName)
+	set(&merged.ProducerServiceInstanceName, update.ProducerServiceInstanceName)
+	set(&merged.ProducerServiceBaseURL, update.ProducerServiceBaseURL)
+	set(&merged.ProducerServicePathURL, update.ProducerServicePathURL)
+	set(&merged.Description, update.Description)
+	return merged
+}
+
 func (p *ProducerService) GetProducers(opt *GetOptions, options ...OptionFunc) ([]Producer, *Response, error) {
 	var producers []Producer
 
@@ -89,8 +154,8 @@ func (p *ProducerService) GetProducers(opt *GetOptions, options ...OptionFunc) (
 	return producers, resp, err
 }
 
-func (p *ProducerService) GetProducer(id string) (*Producer, *Response, error) {
-	producers, resp, err := p.GetProducers(&GetOptions{ID: &id})
+func (p *ProducerService) GetProducer(id string, options ...OptionFunc) (*Producer, *Response, error) {
+	producers, resp, err := p.GetProducers(&GetOptions{ID: &id}, options...)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -100,8 +165,9 @@ func (p *ProducerService) GetProducer(id string) (*Producer, *Response, error) {
 	return &producers[0], resp, nil
 }
 
-func (p *ProducerService) DeleteProducer(producer Producer) (bool, *Response, error) {
-	req, err := p.client.newNotificationRequest("DELETE", "core/notification/Producer/"+producer.ID, nil, nil)
+func (p *ProducerService) DeleteProducer(producer Producer, options ...OptionFunc) (bool, *Response, error) {
+	p.cache.delete(producer.ID)
+	req, err := p.client.newNotificationRequest("DELETE", "core/notification/Producer/"+producer.ID, nil, options...)
 	if err != nil {
 		return false, nil, err
 	}
@@ -110,7 +176,10 @@ func (p *ProducerService) DeleteProducer(producer Producer) (bool, *Response, er
 	var deleteResponse bytes.Buffer
 
 	resp, err := p.client.do(req, &deleteResponse)
-	if resp == nil || resp.StatusCode != http.StatusNoContent {
+	if resp == nil {
+		return false, nil, err
+	}
+	if resp.StatusCode != http.StatusNoContent {
 		return false, resp, fmt.Errorf("DeleteProducer: HTTP %d", resp.StatusCode)
 	}
 	return true, resp, err
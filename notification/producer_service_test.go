@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/philips-software/go-hsdp-api/notification"
 	"github.com/stretchr/testify/assert"
@@ -127,3 +128,72 @@ func TestProducersCRD(t *testing.T) {
 	}
 	assert.Equal(t, storeID, item.ID)
 }
+
+func TestUpdateProducer(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	storeID := "2d9c4e0b-6f1a-4f43-9f1c-5a0e7b3d8c11"
+	current := notification.Producer{
+		ID:                          storeID,
+		ResourceType:                "Producer",
+		ManagingOrganizationID:      "614b0053-7a57-44d8-ba8a-809b9362a9a6",
+		ProducerProductName:         "test",
+		ProducerServiceName:         "test",
+		ProducerServiceInstanceName: "test",
+		ProducerServiceBaseURL:      "https://foo",
+		ProducerServicePathURL:      "/bar",
+		Description:                 "old",
+	}
+
+	muxNotification.HandleFunc("/core/notification/Producer", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		body, _ := json.Marshal(current)
+		_, _ = io.WriteString(w, `{"resourceType":"Bundle","type":"searchset","total":1,"entry":[`+string(body)+`]}`)
+	})
+	muxNotification.HandleFunc("/core/notification/Producer/"+storeID, func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, "PUT", r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		var received notification.Producer
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		assert.Equal(t, "test", received.ProducerProductName)
+		current = received
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	updated, _, err := notificationClient.Producer.UpdateProducer(notification.Producer{
+		ID:                     storeID,
+		ProducerServiceBaseURL: "https://new.example.com",
+		Description:            "new",
+	})
+	if !assert.Nil(t, err) || !assert.NotNil(t, updated) {
+		return
+	}
+	assert.Equal(t, "https://new.example.com", updated.ProducerServiceBaseURL)
+	assert.Equal(t, "/bar", updated.ProducerServicePathURL)
+	assert.Equal(t, "new", updated.Description)
+
+	_, _, err = notificationClient.Producer.UpdateProducer(notification.Producer{Description: "new"})
+	assert.Equal(t, notification.ErrMissingID, err)
+
+	// The cache holds the updated producer
+	cached, err := notification.NewClient(iamClient, &notification.Config{
+		NotificationURL: serverNotification.URL,
+		CacheTTL:        time.Minute,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, _, err = cached.Producer.GetProducerByID(storeID)
+	assert.Nil(t, err)
+	_, _, err = cached.Producer.UpdateProducer(notification.Producer{ID: storeID, Description: "newer"})
+	assert.Nil(t, err)
+	producer, resp, err := cached.Producer.GetProducerByID(storeID)
+	if assert.Nil(t, err) && assert.NotNil(t, producer) {
+		assert.Nil(t, resp)
+		assert.Equal(t, "newer", producer.Description)
+	}
+}
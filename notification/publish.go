@@ -1,27 +1,69 @@
 package notification
 
 import (
+	"encoding/base64"
 	"fmt"
 	"io"
+
+	"github.com/go-playground/validator/v10"
 )
 
+// MaxMessageSize is the maximum size in bytes of a base64 encoded message accepted by the service
+const MaxMessageSize = 256 * 1024
+
+// PublishService publishes messages to topics
+type PublishService struct {
+	client *Client
+
+	validate *validator.Validate
+}
+
 type PublishRequest struct {
-	TopicID string `json:"topicId"`
-	Message string `json:"message"`
+	TopicID string `json:"topicId" validate:"required"`
+	Message string `json:"message" validate:"required"`
 }
 
+// PublishResponse is the receipt of a published message
 type PublishResponse struct {
 	ID           string `json:"_id,omitempty"`
 	ResourceType string `json:"resourceType,omitempty"`
 	TopicID      string `json:"topicId"`
 }
 
-// Publish publishes a message to a topic
-func (c *Client) Publish(request PublishRequest) (*PublishResponse, *Response, error) {
+// Publish publishes a message to a topic. The message must already be base64 encoded,
+// use Publisher.Publish to have this done automatically. Messages to topics with a
+// registered schema are validated before sending
+func (c *Client) Publish(request PublishRequest, options ...OptionFunc) (*PublishResponse, *Response, error) {
+	return c.publish(request, options...)
+}
+
+// Publish base64 encodes message and publishes it to the topic. Messages larger than
+// MaxMessageSize after encoding are rejected before sending
+func (p *PublishService) Publish(topicID string, message []byte, options ...OptionFunc) (*PublishResponse, *Response, error) {
+	encoded := base64.StdEncoding.EncodeToString(message)
+	if len(encoded) > MaxMessageSize {
+		return nil, nil, fmt.Errorf("message of %d bytes: %w", len(encoded), ErrMessageTooLarge)
+	}
+	return p.client.publish(PublishRequest{
+		TopicID: topicID,
+		Message: encoded,
+	}, options...)
+}
+
+func (c *Client) publish(request PublishRequest, options ...OptionFunc) (*PublishResponse, *Response, error) {
 	if err := c.validate.Struct(request); err != nil {
 		return nil, nil, err
 	}
-	req, err := c.newNotificationRequest("POST", "core/notification/Publish", request, nil)
+	if schema := c.schema(request.TopicID); schema != nil {
+		payload, err := base64.StdEncoding.DecodeString(request.Message)
+		if err != nil {
+			return nil, nil, fmt.Errorf("decode message: %w", err)
+		}
+		if err := schema.Validate(payload); err != nil {
+			return nil, nil, fmt.Errorf("topic %s: %w", request.TopicID, err)
+		}
+	}
+	req, err := c.newNotificationRequest("POST", "core/notification/Publish", request, options...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1 +1,110 @@
 package notification_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/philips-software/go-hsdp-api/notification"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublish(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	topicID := "c5a4c3a8-5a2b-4d6f-8a4e-2f3b1e0f9a77"
+	messageID := "0e6c4b1e-1b69-4a5c-9d3e-8a6f2b7c5d41"
+
+	muxNotification.HandleFunc("/core/notification/Publish", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, "POST", r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		var received notification.PublishRequest
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		assert.Equal(t, topicID, received.TopicID)
+		decoded, err := base64.StdEncoding.DecodeString(received.Message)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"event":"created"}`, string(decoded))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"_id":"`+messageID+`","resourceType":"Publish","topicId":"`+topicID+`"}`)
+	})
+
+	receipt, resp, err := notificationClient.Publisher.Publish(topicID, []byte(`{"event":"created"}`))
+	if !assert.Nil(t, err) || !assert.NotNil(t, resp) || !assert.NotNil(t, receipt) {
+		return
+	}
+	assert.Equal(t, messageID, receipt.ID)
+	assert.Equal(t, topicID, receipt.TopicID)
+
+	_, _, err = notificationClient.Publisher.Publish(topicID, []byte(strings.Repeat("x", notification.MaxMessageSize)))
+	assert.True(t, errors.Is(err, notification.ErrMessageTooLarge))
+
+	_, _, err = notificationClient.Publisher.Publish("", []byte("x"))
+	assert.NotNil(t, err)
+}
+
+func TestPublishSchema(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	topicID := "c5a4c3a8-5a2b-4d6f-8a4e-2f3b1e0f9a77"
+	published := 0
+	muxNotification.HandleFunc("/core/notification/Publish", func(w http.ResponseWriter, r *http.Request) {
+		published++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"_id":"0e6c4b1e-1b69-4a5c-9d3e-8a6f2b7c5d41","topicId":"`+topicID+`"}`)
+	})
+
+	err := notificationClient.RegisterJSONSchema(topicID, []byte(`{
+  "type": "object",
+  "required": ["event", "count"],
+  "additionalProperties": false,
+  "properties": {
+    "event": {"type": "string", "enum": ["created", "deleted"]},
+    "count": {"type": "integer", "minimum": 1},
+    "tags": {"type": "array", "items": {"type": "string", "pattern": "^[a-z]+$"}, "maxItems": 2}
+  }
+}`))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	_, _, err = notificationClient.Publisher.Publish(topicID, []byte(`{"event":"created","count":2,"tags":["a"]}`))
+	assert.Nil(t, err)
+
+	for _, message := range []string{
+		`{"event":"created"}`,
+		`{"event":"updated","count":1}`,
+		`{"event":"created","count":1.5}`,
+		`{"event":"created","count":0}`,
+		`{"event":"created","count":1,"extra":true}`,
+		`{"event":"created","count":1,"tags":["A"]}`,
+		`{"event":"created","count":1,"tags":["a","b","c"]}`,
+		`not json`,
+	} {
+		_, _, err = notificationClient.Publisher.Publish(topicID, []byte(message))
+		assert.True(t, errors.Is(err, notification.ErrSchemaViolation), message)
+	}
+	_, _, err = notificationClient.Publish(notification.PublishRequest{
+		TopicID: topicID,
+		Message: base64.StdEncoding.EncodeToString([]byte(`{}`)),
+	})
+	assert.True(t, errors.Is(err, notification.ErrSchemaViolation))
+	assert.Equal(t, 1, published)
+
+	notificationClient.UnregisterSchema(topicID)
+	_, _, err = notificationClient.Publisher.Publish(topicID, []byte(`{}`))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, published)
+
+	err = notificationClient.RegisterJSONSchema(topicID, []byte(`{"type": 1}`))
+	assert.True(t, errors.Is(err, notification.ErrInvalidSchema))
+}
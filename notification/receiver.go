@@ -0,0 +1,180 @@
+package notification
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+const (
+	defaultMaxMessages = 10
+	defaultWaitTime    = 20 * time.Second
+)
+
+// QueueMessage is a single message received from the queue of a subscriber
+type QueueMessage struct {
+	ID            string
+	ReceiptHandle string
+	Body          []byte
+}
+
+// Queue is the SQS queue provisioned for a subscriber. Implement it with the
+// SQS client of your choice using the queue URL and credentials of the subscriber
+type Queue interface {
+	// Receive long-polls for at most max messages, waiting up to wait for the first one
+	Receive(ctx context.Context, max int, wait time.Duration) ([]QueueMessage, error)
+	// Delete removes a handled message from the queue
+	Delete(ctx context.Context, receiptHandle string) error
+	// ChangeVisibility makes the message visible to receivers again after timeout
+	ChangeVisibility(ctx context.Context, receiptHandle string, timeout time.Duration) error
+}
+
+// Delivery is a notification received from the queue
+type Delivery struct {
+	// Message is the message as received from the queue
+	Message QueueMessage
+	// Event is the SNS envelope of the notification. It is empty when the queue
+	// uses raw message delivery
+	Event Event
+	// Payload is the decoded message as originally published
+	Payload []byte
+}
+
+// Handler processes a delivery. Returning nil acknowledges the delivery and removes
+// it from the queue, returning an error makes it available for redelivery
+type Handler func(ctx context.Context, delivery *Delivery) error
+
+// ReceiverConfig holds the optional settings of a Receiver
+type ReceiverConfig struct {
+	// MaxMessages is the number of messages requested per poll, defaults to 10
+	MaxMessages int
+	// WaitTime is the long-poll duration, defaults to 20 seconds
+	WaitTime time.Duration
+	// NackDelay is the time before a failed delivery becomes visible again. Zero redelivers immediately
+	NackDelay time.Duration
+	// ErrorHandler is called with errors that occur outside of the handler, e.g. failed polls
+	// or messages that cannot be decoded. Undecodable messages are left on the queue
+	ErrorHandler func(err error)
+}
+
+// Receiver consumes the queue of a subscriber and dispatches notifications to a handler
+type Receiver struct {
+	queue   Queue
+	handler Handler
+	config  ReceiverConfig
+}
+
+// NewReceiver returns a Receiver dispatching the notifications of queue to handler
+func NewReceiver(queue Queue, handler Handler, config *ReceiverConfig) (*Receiver, error) {
+	if queue == nil {
+		return nil, ErrMissingQueue
+	}
+	if handler == nil {
+		return nil, ErrMissingHandler
+	}
+	r := &Receiver{
+		queue:   queue,
+		handler: handler,
+	}
+	if config != nil {
+		r.config = *config
+	}
+	if r.config.MaxMessages <= 0 {
+		r.config.MaxMessages = defaultMaxMessages
+	}
+	if r.config.WaitTime <= 0 {
+		r.config.WaitTime = defaultWaitTime
+	}
+	return r, nil
+}
+
+// Run polls the queue until ctx is done. Failed polls are retried with backoff
+func (r *Receiver) Run(ctx context.Context) error {
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := r.ReceiveOnce(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			r.reportError(err)
+			timer := time.NewTimer(b.NextBackOff())
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+			continue
+		}
+		b.Reset()
+	}
+}
+
+// ReceiveOnce polls the queue once and handles the received messages concurrently.
+// It returns the number of messages received
+func (r *Receiver) ReceiveOnce(ctx context.Context) (int, error) {
+	messages, err := r.queue.Receive(ctx, r.config.MaxMessages, r.config.WaitTime)
+	if err != nil {
+		return 0, fmt.Errorf("receive: %w", err)
+	}
+	var wg sync.WaitGroup
+	for _, message := range messages {
+		wg.Add(1)
+		go func(message QueueMessage) {
+			defer wg.Done()
+			r.dispatch(ctx, message)
+		}(message)
+	}
+	wg.Wait()
+	return len(messages), nil
+}
+
+func (r *Receiver) dispatch(ctx context.Context, message QueueMessage) {
+	delivery, err := DecodeDelivery(message)
+	if err != nil {
+		r.reportError(fmt.Errorf("message %s: %w", message.ID, err))
+		return
+	}
+	if err := r.handler(ctx, delivery); err != nil {
+		if err := r.queue.ChangeVisibility(ctx, message.ReceiptHandle, r.config.NackDelay); err != nil {
+			r.reportError(fmt.Errorf("nack message %s: %w", message.ID, err))
+		}
+		return
+	}
+	if err := r.queue.Delete(ctx, message.ReceiptHandle); err != nil {
+		r.reportError(fmt.Errorf("ack message %s: %w", message.ID, err))
+	}
+}
+
+func (r *Receiver) reportError(err error) {
+	if r.config.ErrorHandler != nil {
+		r.config.ErrorHandler(err)
+	}
+}
+
+// DecodeDelivery decodes the SNS envelope of a queue message and the base64 encoded
+// message it carries. Messages of queues with raw message delivery are decoded directly
+func DecodeDelivery(message QueueMessage) (*Delivery, error) {
+	delivery := &Delivery{Message: message}
+	encoded := string(message.Body)
+	if err := json.Unmarshal(message.Body, &delivery.Event); err == nil && delivery.Event.Type != "" {
+		encoded = delivery.Event.Message
+	} else {
+		delivery.Event = Event{}
+	}
+	payload, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode message: %w", err)
+	}
+	delivery.Payload = payload
+	return delivery, nil
+}
@@ -0,0 +1,119 @@
+package notification_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/philips-software/go-hsdp-api/notification"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeQueue struct {
+	sync.Mutex
+	messages []notification.QueueMessage
+	deleted  []string
+	nacked   map[string]time.Duration
+}
+
+func (q *fakeQueue) Receive(_ context.Context, max int, _ time.Duration) ([]notification.QueueMessage, error) {
+	q.Lock()
+	defer q.Unlock()
+	if max > len(q.messages) {
+		max = len(q.messages)
+	}
+	received := q.messages[:max]
+	q.messages = q.messages[max:]
+	return received, nil
+}
+
+func (q *fakeQueue) Delete(_ context.Context, receiptHandle string) error {
+	q.Lock()
+	defer q.Unlock()
+	q.deleted = append(q.deleted, receiptHandle)
+	return nil
+}
+
+func (q *fakeQueue) ChangeVisibility(_ context.Context, receiptHandle string, timeout time.Duration) error {
+	q.Lock()
+	defer q.Unlock()
+	q.nacked[receiptHandle] = timeout
+	return nil
+}
+
+func snsBody(t *testing.T, message string) []byte {
+	body, err := json.Marshal(notification.Event{
+		Type:      "Notification",
+		MessageID: "3f1d2c4b-5a6e-4f70-8a9b-0c1d2e3f4a5b",
+		TopicARN:  "arn:aws:sns:us-east-1:123456789012:topic",
+		Message:   base64.StdEncoding.EncodeToString([]byte(message)),
+	})
+	assert.Nil(t, err)
+	return body
+}
+
+func TestReceiver(t *testing.T) {
+	queue := &fakeQueue{
+		nacked: make(map[string]time.Duration),
+		messages: []notification.QueueMessage{
+			{ID: "1", ReceiptHandle: "handle-1", Body: snsBody(t, "ok")},
+			{ID: "2", ReceiptHandle: "handle-2", Body: snsBody(t, "fail")},
+			{ID: "3", ReceiptHandle: "handle-3", Body: []byte(base64.StdEncoding.EncodeToString([]byte("raw")))},
+			{ID: "4", ReceiptHandle: "handle-4", Body: []byte("not base64!")},
+		},
+	}
+	var mu sync.Mutex
+	var payloads []string
+	var reported []error
+	receiver, err := notification.NewReceiver(queue, func(_ context.Context, delivery *notification.Delivery) error {
+		mu.Lock()
+		defer mu.Unlock()
+		payloads = append(payloads, string(delivery.Payload))
+		if string(delivery.Payload) == "fail" {
+			return errors.New("handler failed")
+		}
+		return nil
+	}, &notification.ReceiverConfig{
+		NackDelay: 30 * time.Second,
+		ErrorHandler: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			reported = append(reported, err)
+		},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	count, err := receiver.ReceiveOnce(context.Background())
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, 4, count)
+	assert.ElementsMatch(t, []string{"ok", "fail", "raw"}, payloads)
+	assert.ElementsMatch(t, []string{"handle-1", "handle-3"}, queue.deleted)
+	assert.Equal(t, map[string]time.Duration{"handle-2": 30 * time.Second}, queue.nacked)
+	assert.Len(t, reported, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, receiver.Run(ctx), context.Canceled)
+
+	_, err = notification.NewReceiver(nil, nil, nil)
+	assert.ErrorIs(t, err, notification.ErrMissingQueue)
+	_, err = notification.NewReceiver(queue, nil, nil)
+	assert.ErrorIs(t, err, notification.ErrMissingHandler)
+}
+
+func TestDecodeDelivery(t *testing.T) {
+	delivery, err := notification.DecodeDelivery(notification.QueueMessage{Body: snsBody(t, `{"hello":"world"}`)})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "Notification", delivery.Event.Type)
+	assert.Equal(t, `{"hello":"world"}`, string(delivery.Payload))
+}
@@ -0,0 +1,360 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema validates the payload of messages published to a topic
+type Schema interface {
+	Validate(payload []byte) error
+}
+
+// RegisterSchema validates all messages published to the topic against schema before sending
+func (c *Client) RegisterSchema(topicID string, schema Schema) {
+	c.schemaMu.Lock()
+	defer c.schemaMu.Unlock()
+	if c.schemas == nil {
+		c.schemas = make(map[string]Schema)
+	}
+	c.schemas[topicID] = schema
+}
+
+// RegisterJSONSchema compiles the JSON Schema document with CompileSchema and registers it for the topic
+func (c *Client) RegisterJSONSchema(topicID string, schema []byte) error {
+	compiled, err := CompileSchema(schema)
+	if err != nil {
+		return err
+	}
+	c.RegisterSchema(topicID, compiled)
+	return nil
+}
+
+// UnregisterSchema stops validating messages published to the topic
+func (c *Client) UnregisterSchema(topicID string) {
+	c.schemaMu.Lock()
+	defer c.schemaMu.Unlock()
+	delete(c.schemas, topicID)
+}
+
+func (c *Client) schema(topicID string) Schema {
+	c.schemaMu.RLock()
+	defer c.schemaMu.RUnlock()
+	return c.schemas[topicID]
+}
+
+// jsonSchema implements the commonly used subset of JSON Schema: type, enum, const,
+// properties, required, additionalProperties, items, min/max constraints and pattern
+type jsonSchema struct {
+	Types                []string
+	Enum                 []interface{}
+	Const                interface{}
+	HasConst             bool
+	Properties           map[string]*jsonSchema
+	Required             []string
+	AdditionalProperties *jsonSchema
+	NoAdditional         bool
+	Items                *jsonSchema
+	MinItems, MaxItems   *int
+	MinLength, MaxLength *int
+	Minimum, Maximum     *float64
+	Pattern              *regexp.Regexp
+}
+
+// supportedKeywords are the JSON Schema keywords CompileSchema implements. Annotations
+// which do not affect validation are accepted as well
+var supportedKeywords = map[string]bool{
+	"type": true, "enum": true, "const": true,
+	"properties": true, "required": true, "additionalProperties": true,
+	"items": true, "minItems": true, "maxItems": true,
+	"minLength": true, "maxLength": true, "pattern": true,
+	"minimum": true, "maximum": true,
+	"$schema": true, "$id": true, "$comment": true,
+	"title": true, "description": true, "default": true, "examples": true,
+}
+
+// CompileSchema compiles a JSON Schema document. The subset of JSON Schema listed in
+// jsonSchema is supported, a document using any other keyword, such as $ref, allOf,
+// anyOf, oneOf or format, is rejected with ErrInvalidSchema rather than accepting
+// messages the keyword would reject
+func CompileSchema(schema []byte) (Schema, error) {
+	var document interface{}
+	if err := json.Unmarshal(schema, &document); err != nil {
+		return nil, fmt.Errorf("schema: %w", err)
+	}
+	return compileSchema(document, "#")
+}
+
+func compileSchema(document interface{}, path string) (*jsonSchema, error) {
+	if allow, ok := document.(bool); ok {
+		if allow {
+			return &jsonSchema{}, nil
+		}
+		return &jsonSchema{Enum: []interface{}{}}, nil
+	}
+	fields, ok := document.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("schema %s: not an object: %w", path, ErrInvalidSchema)
+	}
+	keywords := make([]string, 0, len(fields))
+	for keyword := range fields {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+	for _, keyword := range keywords {
+		if !supportedKeywords[keyword] {
+			return nil, fmt.Errorf("schema %s/%s: unsupported keyword: %w", path, keyword, ErrInvalidSchema)
+		}
+	}
+	s := &jsonSchema{}
+	switch t := fields["type"].(type) {
+	case nil:
+	case string:
+		s.Types = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("schema %s/type: %w", path, ErrInvalidSchema)
+			}
+			s.Types = append(s.Types, name)
+		}
+	default:
+		return nil, fmt.Errorf("schema %s/type: %w", path, ErrInvalidSchema)
+	}
+	if enum, ok := fields["enum"]; ok {
+		values, ok := enum.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("schema %s/enum: %w", path, ErrInvalidSchema)
+		}
+		s.Enum = values
+	}
+	if value, ok := fields["const"]; ok {
+		s.Const, s.HasConst = value, true
+	}
+	if value, ok := fields["properties"]; ok {
+		properties, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("schema %s/properties: %w", path, ErrInvalidSchema)
+		}
+		s.Properties = make(map[string]*jsonSchema, len(properties))
+		for name, property := range properties {
+			compiled, err := compileSchema(property, path+"/properties/"+name)
+			if err != nil {
+				return nil, err
+			}
+			s.Properties[name] = compiled
+		}
+	}
+	if value, ok := fields["required"]; ok {
+		required, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("schema %s/required: %w", path, ErrInvalidSchema)
+		}
+		for _, item := range required {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("schema %s/required: %w", path, ErrInvalidSchema)
+			}
+			s.Required = append(s.Required, name)
+		}
+	}
+	switch additional := fields["additionalProperties"].(type) {
+	case nil:
+	case bool:
+		s.NoAdditional = !additional
+	default:
+		compiled, err := compileSchema(additional, path+"/additionalProperties")
+		if err != nil {
+			return nil, err
+		}
+		s.AdditionalProperties = compiled
+	}
+	if items, ok := fields["items"]; ok {
+		compiled, err := compileSchema(items, path+"/items")
+		if err != nil {
+			return nil, err
+		}
+		s.Items = compiled
+	}
+	for keyword, target := range map[string]**int{
+		"minItems": &s.MinItems, "maxItems": &s.MaxItems,
+		"minLength": &s.MinLength, "maxLength": &s.MaxLength,
+	} {
+		n, err := intKeyword(fields, keyword, path)
+		if err != nil {
+			return nil, err
+		}
+		*target = n
+	}
+	for keyword, target := range map[string]**float64{"minimum": &s.Minimum, "maximum": &s.Maximum} {
+		if value, ok := fields[keyword]; ok {
+			number, ok := value.(float64)
+			if !ok {
+				return nil, fmt.Errorf("schema %s/%s: %w", path, keyword, ErrInvalidSchema)
+			}
+			*target = &number
+		}
+	}
+	if value, ok := fields["pattern"]; ok {
+		pattern, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("schema %s/pattern: %w", path, ErrInvalidSchema)
+		}
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("schema %s/pattern: %v: %w", path, err, ErrInvalidSchema)
+		}
+		s.Pattern = compiled
+	}
+	return s, nil
+}
+
+func intKeyword(fields map[string]interface{}, name, path string) (*int, error) {
+	value, ok := fields[name]
+	if !ok {
+		return nil, nil
+	}
+	number, ok := value.(float64)
+	if !ok || number < 0 || number != math.Trunc(number) {
+		return nil, fmt.Errorf("schema %s/%s: %w", path, name, ErrInvalidSchema)
+	}
+	n := int(number)
+	return &n, nil
+}
+
+// Validate checks the JSON payload against the schema
+func (s *jsonSchema) Validate(payload []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(payload, &value); err != nil {
+		return fmt.Errorf("payload is not JSON: %v: %w", err, ErrSchemaViolation)
+	}
+	return s.validate(value, "")
+}
+
+func (s *jsonSchema) validate(value interface{}, path string) error {
+	fail := func(format string, args ...interface{}) error {
+		location := path
+		if location == "" {
+			location = "/"
+		}
+		return fmt.Errorf("%s: %s: %w", location, fmt.Sprintf(format, args...), ErrSchemaViolation)
+	}
+	if len(s.Types) > 0 && !matchesType(value, s.Types) {
+		return fail("expected %s", strings.Join(s.Types, " or "))
+	}
+	if s.Enum != nil {
+		found := false
+		for _, allowed := range s.Enum {
+			if reflect.DeepEqual(allowed, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fail("value not in enum")
+		}
+	}
+	if s.HasConst && !reflect.DeepEqual(s.Const, value) {
+		return fail("value does not match const")
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				return fail("missing required property %q", name)
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, ok := s.Properties[name]
+			switch {
+			case ok:
+			case s.NoAdditional:
+				return fail("additional property %q not allowed", name)
+			case s.AdditionalProperties != nil:
+				property = s.AdditionalProperties
+			default:
+				continue
+			}
+			if err := property.validate(v[name], path+"/"+name); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			return fail("at least %d items expected", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			return fail("at most %d items expected", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range v {
+				if err := s.Items.validate(item, fmt.Sprintf("%s/%d", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.MinLength != nil && length < *s.MinLength {
+			return fail("at least %d characters expected", *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			return fail("at most %d characters expected", *s.MaxLength)
+		}
+		if s.Pattern != nil && !s.Pattern.MatchString(v) {
+			return fail("does not match pattern %q", s.Pattern.String())
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			return fail("minimum is %v", *s.Minimum)
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			return fail("maximum is %v", *s.Maximum)
+		}
+	}
+	return nil
+}
+
+func matchesType(value interface{}, types []string) bool {
+	for _, t := range types {
+		switch v := value.(type) {
+		case nil:
+			if t == "null" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case string:
+			if t == "string" {
+				return true
+			}
+		case float64:
+			if t == "number" || (t == "integer" && v == math.Trunc(v)) {
+				return true
+			}
+		case []interface{}:
+			if t == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if t == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
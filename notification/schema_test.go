@@ -0,0 +1,76 @@
+package notification_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/philips-software/go-hsdp-api/notification"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileSchemaKeywords(t *testing.T) {
+	cases := []struct {
+		keyword string
+		schema  string
+		valid   []string
+		invalid []string
+	}{
+		{"type", `{"type": "string"}`, []string{`"a"`}, []string{`1`, `null`}},
+		{"type list", `{"type": ["integer", "null"]}`, []string{`1`, `null`}, []string{`1.5`, `"a"`}},
+		{"enum", `{"enum": ["a", 1]}`, []string{`"a"`, `1`}, []string{`"b"`}},
+		{"const", `{"const": {"a": 1}}`, []string{`{"a": 1}`}, []string{`{"a": 2}`}},
+		{"properties", `{"properties": {"a": {"type": "string"}}}`, []string{`{"a": "x"}`, `{"b": 1}`}, []string{`{"a": 1}`}},
+		{"required", `{"required": ["a"]}`, []string{`{"a": null}`, `"not an object"`}, []string{`{}`}},
+		{"additionalProperties false", `{"properties": {"a": {}}, "additionalProperties": false}`, []string{`{"a": 1}`}, []string{`{"a": 1, "b": 2}`}},
+		{"additionalProperties schema", `{"additionalProperties": {"type": "number"}}`, []string{`{"a": 1}`}, []string{`{"a": "x"}`}},
+		{"items", `{"items": {"type": "boolean"}}`, []string{`[true, false]`, `[]`}, []string{`[true, 1]`}},
+		{"minItems", `{"minItems": 1}`, []string{`[1]`}, []string{`[]`}},
+		{"maxItems", `{"maxItems": 1}`, []string{`[1]`}, []string{`[1, 2]`}},
+		{"minLength", `{"minLength": 2}`, []string{`"ab"`}, []string{`"a"`}},
+		{"maxLength", `{"maxLength": 2}`, []string{`"äö"`}, []string{`"abc"`}},
+		{"pattern", `{"pattern": "^[a-z]+$"}`, []string{`"abc"`, `1`}, []string{`"ABC"`}},
+		{"minimum", `{"minimum": 1}`, []string{`1`}, []string{`0.5`}},
+		{"maximum", `{"maximum": 1}`, []string{`1`}, []string{`1.5`}},
+		{"true schema", `{"properties": {"a": true}}`, []string{`{"a": 1}`}, nil},
+		{"false schema", `{"properties": {"a": false}}`, []string{`{}`}, []string{`{"a": 1}`}},
+		{"annotations", `{"$schema": "http://json-schema.org/draft-07/schema#", "title": "t", "description": "d", "default": 1, "examples": [1], "$comment": "c"}`, []string{`1`}, nil},
+	}
+	for _, c := range cases {
+		schema, err := notification.CompileSchema([]byte(c.schema))
+		if !assert.Nil(t, err, c.keyword) {
+			continue
+		}
+		for _, payload := range c.valid {
+			assert.Nil(t, schema.Validate([]byte(payload)), "%s: %s", c.keyword, payload)
+		}
+		for _, payload := range c.invalid {
+			err := schema.Validate([]byte(payload))
+			assert.True(t, errors.Is(err, notification.ErrSchemaViolation), "%s: %s", c.keyword, payload)
+		}
+	}
+}
+
+func TestCompileSchemaRejectsUnsupported(t *testing.T) {
+	for _, schema := range []string{
+		`{"$ref": "#/definitions/a"}`,
+		`{"oneOf": [{"type": "string"}]}`,
+		`{"allOf": [{"type": "string"}]}`,
+		`{"anyOf": [{"type": "string"}]}`,
+		`{"not": {"type": "string"}}`,
+		`{"format": "email"}`,
+		`{"exclusiveMinimum": 1}`,
+		`{"properties": {"a": {"format": "date-time"}}}`,
+		`{"items": [{"type": "string"}]}`,
+		`{"minLength": "1"}`,
+		`{"minItems": -1}`,
+		`{"maximum": "1"}`,
+		`{"required": "a"}`,
+		`{"pattern": "("}`,
+		`"string"`,
+	} {
+		_, err := notification.CompileSchema([]byte(schema))
+		assert.True(t, errors.Is(err, notification.ErrInvalidSchema), schema)
+	}
+	_, err := notification.CompileSchema([]byte(`{`))
+	assert.NotNil(t, err)
+}
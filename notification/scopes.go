@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Topic scopes
+const (
+	ScopePublic  = "public"
+	ScopePrivate = "private"
+	// AnyScope allows subscribers of all organizations
+	AnyScope = "*"
+)
+
+// AllowedScopes builds the allowedScopes of a topic from the organization IDs of the
+// subscribers it fans out to. Empty and duplicate IDs are dropped
+func AllowedScopes(organizationIDs ...string) []string {
+	seen := make(map[string]bool, len(organizationIDs))
+	scopes := make([]string, 0, len(organizationIDs))
+	for _, id := range organizationIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		scopes = append(scopes, id)
+	}
+	return scopes
+}
+
+// ValidateScopes checks the scope and allowedScopes of the topic. A private topic must list
+// the organizations allowed to subscribe, either as organization IDs or AnyScope on its own
+func ValidateScopes(topic Topic) error {
+	switch topic.Scope {
+	case ScopePublic, ScopePrivate:
+	default:
+		return fmt.Errorf("scope %q: %w", topic.Scope, ErrInvalidScope)
+	}
+	if topic.Scope == ScopePrivate && len(topic.AllowedScopes) == 0 {
+		return fmt.Errorf("private topic without allowedScopes: %w", ErrInvalidScope)
+	}
+	for _, scope := range topic.AllowedScopes {
+		if scope == AnyScope {
+			if len(topic.AllowedScopes) > 1 {
+				return fmt.Errorf("%q combined with other allowedScopes: %w", AnyScope, ErrInvalidScope)
+			}
+			continue
+		}
+		if _, err := uuid.Parse(scope); err != nil {
+			return fmt.Errorf("allowedScope %q: %w", scope, ErrInvalidScope)
+		}
+	}
+	return nil
+}
+
+// VisibleTo reports whether a subscriber of the given organization may subscribe to the topic
+func (t Topic) VisibleTo(organizationID string) bool {
+	if t.Scope == ScopePublic {
+		return true
+	}
+	for _, scope := range t.AllowedScopes {
+		if scope == AnyScope || scope == organizationID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTopicsVisibleTo returns all topics matching opt a subscriber of the given organization may subscribe to
+func (p *TopicService) GetTopicsVisibleTo(organizationID string, opt *GetOptions, options ...OptionFunc) ([]Topic, *Response, error) {
+	it := p.IterateTopics(opt, options...)
+	var topics []Topic
+	for it.Next() {
+		if topic := it.Item(); topic.VisibleTo(organizationID) {
+			topics = append(topics, topic)
+		}
+	}
+	return topics, it.Response(), it.Err()
+}
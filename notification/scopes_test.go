@@ -0,0 +1,72 @@
+package notification_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/philips-software/go-hsdp-api/notification"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateScopes(t *testing.T) {
+	orgA := "48a0183d-a588-41c2-9979-737d15e9e860"
+	orgB := "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"
+
+	assert.Equal(t, []string{orgA, orgB}, notification.AllowedScopes(orgA, "", orgB, orgA))
+
+	valid := []notification.Topic{
+		{Scope: notification.ScopePublic},
+		{Scope: notification.ScopePrivate, AllowedScopes: notification.AllowedScopes(orgA, orgB)},
+		{Scope: notification.ScopePrivate, AllowedScopes: []string{notification.AnyScope}},
+	}
+	for _, topic := range valid {
+		assert.Nil(t, notification.ValidateScopes(topic))
+	}
+	invalid := []notification.Topic{
+		{Scope: "bogus"},
+		{Scope: notification.ScopePrivate},
+		{Scope: notification.ScopePrivate, AllowedScopes: []string{notification.AnyScope, orgA}},
+		{Scope: notification.ScopePrivate, AllowedScopes: []string{"not-an-org"}},
+	}
+	for _, topic := range invalid {
+		assert.True(t, errors.Is(notification.ValidateScopes(topic), notification.ErrInvalidScope))
+	}
+}
+
+func TestGetTopicsVisibleTo(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	orgA := "48a0183d-a588-41c2-9979-737d15e9e860"
+	orgB := "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"
+
+	muxNotification.HandleFunc("/core/notification/Topic", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, "GET", r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{
+  "resourceType": "Bundle",
+  "type": "searchset",
+  "total": 4,
+  "entry": [
+    {"_id": "t1", "scope": "public"},
+    {"_id": "t2", "scope": "private", "allowedScopes": ["`+orgA+`"]},
+    {"_id": "t3", "scope": "private", "allowedScopes": ["`+orgB+`"]},
+    {"_id": "t4", "scope": "private", "allowedScopes": ["*"]}
+  ]
+}`)
+	})
+
+	topics, _, err := notificationClient.Topic.GetTopicsVisibleTo(orgA, nil)
+	if !assert.Nil(t, err) || !assert.Len(t, topics, 3) {
+		return
+	}
+	assert.Equal(t, "t1", topics[0].ID)
+	assert.Equal(t, "t2", topics[1].ID)
+	assert.Equal(t, "t4", topics[2].ID)
+}
@@ -5,16 +5,20 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 )
 
+// SubscriberService manages the subscribers of the Notification service
 type SubscriberService struct {
 	client *Client
 
 	validate *validator.Validate
 }
 
+// Subscriber is a service receiving notifications of the topics it subscribes to
 type Subscriber struct {
 	ID                            string `json:"_id,omitempty"`
 	ResourceType                  string `json:"resourceType,omitempty"`
@@ -26,13 +30,76 @@ type Subscriber struct {
 	SubscriberServiceBaseURL      string `json:"subscriberServiceBaseUrl" validate:"required"`
 	SubscriberServicePathURL      string `json:"subscriberServicePathUrl" validate:"required"`
 	Description                   string `json:"description,omitempty"`
+	// DataAccessURL is where the subscriber can fetch the resource a notification refers to
+	DataAccessURL string `json:"dataAccessUrl,omitempty"`
 }
 
-func (p *SubscriberService) CreateSubscriber(subscriber Subscriber) (*Subscriber, *Response, error) {
+// SubscriberOptions holds the optional fields of NewSubscriber
+type SubscriberOptions struct {
+	InstanceName string
+	Description  string
+	// DataAccessURL defaults to the notification endpoint of the subscriber
+	DataAccessURL string
+}
+
+// NewSubscriber builds a Subscriber of the organization from the product and service names
+// and the endpoint URL receiving notifications, e.g. "https://billing.example.com/notification".
+// The endpoint is split into the base and path URL of the subscriber
+func NewSubscriber(organizationID, productName, serviceName, endpoint string, opts *SubscriberOptions) (*Subscriber, error) {
+	if _, err := uuid.Parse(organizationID); err != nil {
+		return nil, fmt.Errorf("organization ID %q: %w", organizationID, err)
+	}
+	if productName == "" || serviceName == "" {
+		return nil, fmt.Errorf("product and service name are required: %w", ErrMissingName)
+	}
+	base, path, err := splitEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	subscriber := &Subscriber{
+		ManagingOrganizationID:   organizationID,
+		SubscriberProductName:    productName,
+		SubscriberServicename:    serviceName,
+		SubscriberServiceBaseURL: base,
+		SubscriberServicePathURL: path,
+		DataAccessURL:            base + path,
+	}
+	if opts != nil {
+		subscriber.SubscriberServiceinstanceName = opts.InstanceName
+		subscriber.Description = opts.Description
+		if opts.DataAccessURL != "" {
+			if _, _, err := splitEndpoint(opts.DataAccessURL); err != nil {
+				return nil, err
+			}
+			subscriber.DataAccessURL = opts.DataAccessURL
+		}
+	}
+	return subscriber, nil
+}
+
+// splitEndpoint checks that endpoint is an absolute HTTPS URL without credentials,
+// query or fragment and returns its base and path
+func splitEndpoint(endpoint string) (string, string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", "", fmt.Errorf("URL %q: %w", endpoint, ErrInvalidURL)
+	}
+	if u.Scheme != "https" || u.Host == "" || u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return "", "", fmt.Errorf("URL %q: %w", endpoint, ErrInvalidURL)
+	}
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	return u.Scheme + "://" + u.Host, path, nil
+}
+
+// CreateSubscriber registers a new subscriber
+func (p *SubscriberService) CreateSubscriber(subscriber Subscriber, options ...OptionFunc) (*Subscriber, *Response, error) {
 	if err := p.validate.Struct(subscriber); err != nil {
 		return nil, nil, err
 	}
-	req, err := p.client.newNotificationRequest("POST", "core/notification/Subscriber", subscriber, nil)
+	req, err := p.client.newNotificationRequest("POST", "core/notification/Subscriber", subscriber, options...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -47,6 +114,7 @@ func (p *SubscriberService) CreateSubscriber(subscriber Subscriber) (*Subscriber
 	return &createdSubscriber, resp, nil
 }
 
+// GetSubscribers searches for subscribers matching opt
 func (p *SubscriberService) GetSubscribers(opt *GetOptions, options ...OptionFunc) ([]Subscriber, *Response, error) {
 	var subscribers []Subscriber
 
@@ -76,8 +144,9 @@ func (p *SubscriberService) GetSubscribers(opt *GetOptions, options ...OptionFun
 	return subscribers, resp, err
 }
 
-func (p *SubscriberService) GetSubscriber(id string) (*Subscriber, *Response, error) {
-	subscribers, resp, err := p.GetSubscribers(&GetOptions{ID: &id})
+// GetSubscriber returns the subscriber with the given id
+func (p *SubscriberService) GetSubscriber(id string, options ...OptionFunc) (*Subscriber, *Response, error) {
+	subscribers, resp, err := p.GetSubscribers(&GetOptions{ID: &id}, options...)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -87,8 +156,9 @@ func (p *SubscriberService) GetSubscriber(id string) (*Subscriber, *Response, er
 	return &subscribers[0], resp, nil
 }
 
-func (p *SubscriberService) DeleteSubscriber(subscriber Subscriber) (bool, *Response, error) {
-	req, err := p.client.newNotificationRequest("DELETE", "core/notification/Subscriber/"+subscriber.ID, nil, nil)
+// DeleteSubscriber removes the subscriber
+func (p *SubscriberService) DeleteSubscriber(subscriber Subscriber, options ...OptionFunc) (bool, *Response, error) {
+	req, err := p.client.newNotificationRequest("DELETE", "core/notification/Subscriber/"+subscriber.ID, nil, options...)
 	if err != nil {
 		return false, nil, err
 	}
@@ -97,7 +167,10 @@ func (p *SubscriberService) DeleteSubscriber(subscriber Subscriber) (bool, *Resp
 	var deleteResponse bytes.Buffer
 
 	resp, err := p.client.do(req, &deleteResponse)
-	if resp == nil || resp.StatusCode != http.StatusNoContent {
+	if resp == nil {
+		return false, nil, err
+	}
+	if resp.StatusCode != http.StatusNoContent {
 		return false, resp, fmt.Errorf("DeleteSubscriber: HTTP %d", resp.StatusCode)
 	}
 	return true, resp, err
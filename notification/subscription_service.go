@@ -7,14 +7,17 @@ import (
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/go-playground/validator/v10"
+	"github.com/philips-software/go-hsdp-api/internal"
 )
 
+// SubscriptionService manages the subscriptions of subscribers to topics
 type SubscriptionService struct {
 	client *Client
 
 	validate *validator.Validate
 }
 
+// Subscription connects a Subscriber to a Topic
 type Subscription struct {
 	ID                   string `json:"_id,omitempty"`
 	ResourceType         string `json:"resourceType,omitempty"`
@@ -24,6 +27,7 @@ type Subscription struct {
 	SubscriptionARN      string `json:"subscriptionArn,omitempty"`
 }
 
+// ConfirmRequest confirms a subscription using the token of the SubscriptionConfirmation event
 type ConfirmRequest struct {
 	AuthenticateOnUnsubscribe string `json:"authenticateOnUnsubscribe,omitempty"`
 	Token                     string `json:"token" validate:"required"`
@@ -31,11 +35,12 @@ type ConfirmRequest struct {
 	Endpoint                  string `json:"endpoint" validate:"required"`
 }
 
-func (p *SubscriptionService) CreateSubscription(subscription Subscription) (*Subscription, *Response, error) {
+// CreateSubscription subscribes the subscriber to the topic
+func (p *SubscriptionService) CreateSubscription(subscription Subscription, options ...OptionFunc) (*Subscription, *Response, error) {
 	if err := p.validate.Struct(subscription); err != nil {
 		return nil, nil, err
 	}
-	req, err := p.client.newNotificationRequest("POST", "core/notification/Subscription", subscription, nil)
+	req, err := p.client.newNotificationRequest("POST", "core/notification/Subscription", subscription, options...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -50,6 +55,7 @@ func (p *SubscriptionService) CreateSubscription(subscription Subscription) (*Su
 	return &createdSubscription, resp, nil
 }
 
+// GetSubscriptions searches for subscriptions, e.g. by TopicID or SubscriberID of opt
 func (p *SubscriptionService) GetSubscriptions(opt *GetOptions, options ...OptionFunc) ([]Subscription, *Response, error) {
 	var subscriptions []Subscription
 
@@ -79,19 +85,21 @@ func (p *SubscriptionService) GetSubscriptions(opt *GetOptions, options ...Optio
 	return subscriptions, resp, err
 }
 
-func (p *SubscriptionService) GetSubscription(id string) (*Subscription, *Response, error) {
-	subscriptions, resp, err := p.GetSubscriptions(&GetOptions{ID: &id})
+// GetSubscription returns the subscription with the given id
+func (p *SubscriptionService) GetSubscription(id string, options ...OptionFunc) (*Subscription, *Response, error) {
+	subscriptions, resp, err := p.GetSubscriptions(&GetOptions{ID: &id}, options...)
 	if err != nil {
 		return nil, resp, err
 	}
 	if subscriptions == nil || len(subscriptions) != 1 {
-		return nil, resp, fmt.Errorf("GetSubscriber: not found")
+		return nil, resp, fmt.Errorf("GetSubscription: not found")
 	}
 	return &subscriptions[0], resp, nil
 }
 
-func (p *SubscriptionService) DeleteSubscription(subscription Subscription) (bool, *Response, error) {
-	req, err := p.client.newNotificationRequest("DELETE", "core/notification/Subscription/"+subscription.ID, nil, nil)
+// DeleteSubscription unsubscribes the subscriber from the topic
+func (p *SubscriptionService) DeleteSubscription(subscription Subscription, options ...OptionFunc) (bool, *Response, error) {
+	req, err := p.client.newNotificationRequest("DELETE", "core/notification/Subscription/"+subscription.ID, nil, options...)
 	if err != nil {
 		return false, nil, err
 	}
@@ -107,7 +115,8 @@ func (p *SubscriptionService) DeleteSubscription(subscription Subscription) (boo
 	return true, resp, err
 }
 
-func (p *SubscriptionService) ConfirmSubscription(confirm ConfirmRequest) (*Subscription, *Response, error) {
+// ConfirmSubscription confirms a pending subscription, retrying while the service is not ready yet
+func (p *SubscriptionService) ConfirmSubscription(confirm ConfirmRequest, options ...OptionFunc) (*Subscription, *Response, error) {
 	var confirmResponse Subscription
 	var resp *Response
 
@@ -115,11 +124,13 @@ func (p *SubscriptionService) ConfirmSubscription(confirm ConfirmRequest) (*Subs
 		return nil, nil, err
 	}
 	operation := func() error {
-		req, err := p.client.newNotificationRequest("POST", "core/notification/Subscription/_confirm", confirm, nil)
+		req, err := p.client.newNotificationRequest("POST", "core/notification/Subscription/_confirm", confirm, options...)
 		if err != nil {
 			return err
 		}
 		req.Header.Set("api-version", APIVersion)
+		// This loop retries the confirmation, so the client does not retry on top of it
+		req = internal.WithoutRetry(req)
 
 		resp, err = p.client.do(req, &confirmResponse)
 		if err != nil {
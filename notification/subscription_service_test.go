@@ -49,6 +49,10 @@ func TestSubscriptionCRD(t *testing.T) {
 			}
 			_, _ = io.WriteString(w, string(resp))
 		case "GET":
+			if topicID := r.URL.Query().Get("topicId"); topicID != "" {
+				assert.Equal(t, "some-topic-id", topicID)
+				assert.Equal(t, "some-subscriber-id", r.URL.Query().Get("subscriberId"))
+			}
 			w.WriteHeader(http.StatusOK)
 			_, _ = io.WriteString(w, `{
   "resourceType": "Bundle",
@@ -155,6 +159,17 @@ func TestSubscriptionCRD(t *testing.T) {
 	}
 	assert.Equal(t, storeID, item.ID)
 
+	topicID := "some-topic-id"
+	subscriberID := "some-subscriber-id"
+	subscriptions, _, err := notificationClient.Subscription.GetSubscriptions(&notification.GetOptions{
+		TopicID:      &topicID,
+		SubscriberID: &subscriberID,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, subscriptions, 1)
+
 	item, resp, err = notificationClient.Subscription.ConfirmSubscription(notification.ConfirmRequest{
 		Token:    "some-token",
 		TopicARN: "arn:thing",
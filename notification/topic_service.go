@@ -9,12 +9,15 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
+// TopicService manages the topics producers publish to
 type TopicService struct {
 	client *Client
 
 	validate *validator.Validate
+	cache    *ttlCache[Topic]
 }
 
+// Topic is a named channel of a producer. AllowedScopes limits which subscribers may subscribe
 type Topic struct {
 	ID            string   `json:"_id,omitempty"`
 	ResourceType  string   `json:"resourceType,omitempty"`
@@ -26,11 +29,12 @@ type Topic struct {
 	Description   string   `json:"description,omitempty"`
 }
 
-func (p *TopicService) CreateTopic(topic Topic) (*Topic, *Response, error) {
+// CreateTopic creates a new topic
+func (p *TopicService) CreateTopic(topic Topic, options ...OptionFunc) (*Topic, *Response, error) {
 	if err := p.validate.Struct(topic); err != nil {
 		return nil, nil, err
 	}
-	req, err := p.client.newNotificationRequest("POST", "core/notification/Topic", topic, nil)
+	req, err := p.client.newNotificationRequest("POST", "core/notification/Topic", topic, options...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -45,16 +49,22 @@ func (p *TopicService) CreateTopic(topic Topic) (*Topic, *Response, error) {
 	return &createdTopic, resp, nil
 }
 
-func (p *TopicService) UpdateTopic(topic Topic) (*Topic, *Response, error) {
+// UpdateTopic updates the description and allowed scopes of the topic and returns the updated topic
+func (p *TopicService) UpdateTopic(topic Topic, options ...OptionFunc) (*Topic, *Response, error) {
+	if topic.ID == "" {
+		return nil, nil, ErrMissingID
+	}
 	if err := p.validate.Struct(topic); err != nil {
 		return nil, nil, err
 	}
-	req, err := p.client.newNotificationRequest("PUT", "core/notification/Topic/"+topic.ID, topic, nil)
+	req, err := p.client.newNotificationRequest("PUT", "core/notification/Topic/"+topic.ID, topic, options...)
 	if err != nil {
 		return nil, nil, err
 	}
 	var updateResponse bytes.Buffer
 	resp, err := p.client.do(req, &updateResponse)
+	// Only invalidate now, a concurrent GetTopicByID could otherwise cache the old topic again
+	p.cache.delete(topic.ID)
 	if (err != nil && err != io.EOF) || resp == nil {
 		if resp == nil && err != nil {
 			err = fmt.Errorf("UpdateTopic: %w", ErrEmptyResult)
@@ -66,16 +76,18 @@ func (p *TopicService) UpdateTopic(topic Topic) (*Topic, *Response, error) {
 	}
 	updated, resp, err := p.GetTopics(&GetOptions{
 		ID: &topic.ID,
-	})
+	}, options...)
 	if err != nil {
 		return nil, resp, err
 	}
 	if len(updated) != 1 {
 		return nil, resp, fmt.Errorf("failed to retrieve updated Topic %s", topic.ID)
 	}
+	p.cache.set(topic.ID, updated[0])
 	return &updated[0], resp, nil
 }
 
+// GetTopics searches for topics, e.g. by Name, Scope or ProducerID of opt
 func (p *TopicService) GetTopics(opt *GetOptions, options ...OptionFunc) ([]Topic, *Response, error) {
 	var topics []Topic
 
@@ -106,8 +118,9 @@ func (p *TopicService) GetTopics(opt *GetOptions, options ...OptionFunc) ([]Topi
 	return topics, resp, err
 }
 
-func (p *TopicService) GetTopic(id string) (*Topic, *Response, error) {
-	topics, resp, err := p.GetTopics(&GetOptions{ID: &id})
+// GetTopic returns the topic with the given id
+func (p *TopicService) GetTopic(id string, options ...OptionFunc) (*Topic, *Response, error) {
+	topics, resp, err := p.GetTopics(&GetOptions{ID: &id}, options...)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -117,8 +130,10 @@ func (p *TopicService) GetTopic(id string) (*Topic, *Response, error) {
 	return &topics[0], resp, nil
 }
 
-func (p *TopicService) DeleteTopic(topic Topic) (bool, *Response, error) {
-	req, err := p.client.newNotificationRequest("DELETE", "core/notification/Topic/"+topic.ID, nil, nil)
+// DeleteTopic removes the topic
+func (p *TopicService) DeleteTopic(topic Topic, options ...OptionFunc) (bool, *Response, error) {
+	p.cache.delete(topic.ID)
+	req, err := p.client.newNotificationRequest("DELETE", "core/notification/Topic/"+topic.ID, nil, options...)
 	if err != nil {
 		return false, nil, err
 	}
@@ -127,7 +142,10 @@ func (p *TopicService) DeleteTopic(topic Topic) (bool, *Response, error) {
 	var deleteResponse bytes.Buffer
 
 	resp, err := p.client.do(req, &deleteResponse)
-	if resp == nil || resp.StatusCode != http.StatusNoContent {
+	if resp == nil {
+		return false, nil, err
+	}
+	if resp.StatusCode != http.StatusNoContent {
 		return false, resp, fmt.Errorf("DeleteTopic: HTTP %d", resp.StatusCode)
 	}
 	return true, resp, err
@@ -52,6 +52,10 @@ func TestTopicCRUD(t *testing.T) {
 			}
 			_, _ = io.WriteString(w, string(resp))
 		case "GET":
+			if name := r.URL.Query().Get("name"); name != "" {
+				assert.Equal(t, "Topic1", name)
+				assert.Equal(t, "public", r.URL.Query().Get("scope"))
+			}
 			w.WriteHeader(http.StatusOK)
 			_, _ = io.WriteString(w, `{
   "resourceType": "Bundle",
@@ -87,6 +91,11 @@ func TestTopicCRUD(t *testing.T) {
 		switch r.Method {
 		case "DELETE":
 			w.WriteHeader(http.StatusNoContent)
+		case "PUT":
+			var received notification.Topic
+			_ = json.NewDecoder(r.Body).Decode(&received)
+			assert.Equal(t, "Updated description", received.Description)
+			w.WriteHeader(http.StatusNoContent)
 		default:
 			w.WriteHeader(http.StatusMethodNotAllowed)
 		}
@@ -124,4 +133,23 @@ func TestTopicCRUD(t *testing.T) {
 		return
 	}
 	assert.Equal(t, storeID, item.ID)
+
+	name := "Topic1"
+	scope := "public"
+	topics, _, err := notificationClient.Topic.GetTopics(&notification.GetOptions{Name: &name, Scope: &scope})
+	if !assert.Nil(t, err) || !assert.Len(t, topics, 1) {
+		return
+	}
+
+	update := topics[0]
+	update.Description = "Updated description"
+	updated, _, err := notificationClient.Topic.UpdateTopic(update)
+	if !assert.Nil(t, err) || !assert.NotNil(t, updated) {
+		return
+	}
+	assert.Equal(t, storeID, updated.ID)
+
+	update.ID = ""
+	_, _, err = notificationClient.Topic.UpdateTopic(update)
+	assert.Equal(t, notification.ErrMissingID, err)
 }
@@ -3,6 +3,8 @@ package tdr
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/philips-software/go-hsdp-api/internal"
@@ -54,9 +56,27 @@ func (c *ContractsService) GetContract(opt *GetContractOptions, options ...Optio
 	return contracts, resp, err
 }
 
+// SearchContracts returns the contracts of the organization, optionally limited to dataType
+func (c *ContractsService) SearchContracts(organization string, dataType *DataType, options ...OptionFunc) ([]*Contract, *Response, error) {
+	if organization == "" {
+		return nil, nil, ErrMissingOrganization
+	}
+	opt := &GetContractOptions{Organization: &organization}
+	if dataType != nil {
+		opt.DataType = String(dataType.System + "|" + dataType.Code)
+	}
+	return c.GetContract(opt, options...)
+}
+
 // CreateContract creates a new contract in TDR
-func (c *ContractsService) CreateContract(contract Contract) (bool, *Response, error) {
-	req, err := c.client.newTDRRequest("POST", "store/tdr/Contract", &contract, nil)
+func (c *ContractsService) CreateContract(contract Contract, options ...OptionFunc) (bool, *Response, error) {
+	if contract.Organization == "" {
+		return false, nil, ErrMissingOrganization
+	}
+	if contract.DataType.System == "" || contract.DataType.Code == "" {
+		return false, nil, ErrMissingDataType
+	}
+	req, err := c.client.newTDRRequest("POST", "store/tdr/Contract", &contract, options)
 	if err != nil {
 		return false, nil, err
 	}
@@ -75,3 +95,18 @@ func (c *ContractsService) CreateContract(contract Contract) (bool, *Response, e
 	}
 	return true, resp, nil
 }
+
+// CreateContractWithSchema creates a new contract using the JSON schema read from schema,
+// e.g. a schema file. The schema must be a JSON object
+func (c *ContractsService) CreateContractWithSchema(contract Contract, schema io.Reader, options ...OptionFunc) (bool, *Response, error) {
+	data, err := io.ReadAll(schema)
+	if err != nil {
+		return false, nil, err
+	}
+	var document map[string]interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return false, nil, fmt.Errorf("%v: %w", err, ErrInvalidSchema)
+	}
+	contract.Schema = json.RawMessage(data)
+	return c.CreateContract(contract, options...)
+}
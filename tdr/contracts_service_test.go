@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -187,3 +188,51 @@ func TestCreateContract(t *testing.T) {
 	assert.Equal(t, http.StatusCreated, resp.StatusCode)
 	assert.Equal(t, true, ok, "expected contract creation to succeed")
 }
+
+func TestCreateContractWithSchema(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	muxTDR.HandleFunc("/store/tdr/Contract", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			var contract Contract
+			_ = json.NewDecoder(r.Body).Decode(&contract)
+			assert.JSONEq(t, `{"type":"object"}`, string(contract.Schema))
+			w.Header().Set("Location", "https://golang-testurl.com/store/tdr/Contract?dataType=TestGo%7CTestGoContract")
+			w.WriteHeader(http.StatusCreated)
+		case "GET":
+			assert.Equal(t, "DevOrg", r.URL.Query().Get("organization"))
+			assert.Equal(t, "TestGo|TestGoContract", r.URL.Query().Get("dataType"))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{
+  "type": "searchset",
+  "total": 1,
+  "entry": [{"resource": {"id": "TestGo|TestGoContract", "organization": "DevOrg", "dataType": {"system": "TestGo", "code": "TestGoContract"}}}]
+}`)
+		}
+	})
+
+	contract := Contract{
+		Organization: "DevOrg",
+		DataType:     DataType{System: "TestGo", Code: "TestGoContract"},
+	}
+	ok, _, err := tdrClient.Contracts.CreateContractWithSchema(contract, strings.NewReader(`{"type":"object"}`))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	_, _, err = tdrClient.Contracts.CreateContractWithSchema(contract, strings.NewReader(`[1]`))
+	assert.ErrorIs(t, err, ErrInvalidSchema)
+	_, _, err = tdrClient.Contracts.CreateContract(Contract{DataType: contract.DataType})
+	assert.ErrorIs(t, err, ErrMissingOrganization)
+	_, _, err = tdrClient.Contracts.CreateContract(Contract{Organization: "DevOrg"})
+	assert.ErrorIs(t, err, ErrMissingDataType)
+
+	contracts, _, err := tdrClient.Contracts.SearchContracts("DevOrg", &contract.DataType)
+	if assert.Nil(t, err) && assert.Len(t, contracts, 1) {
+		assert.Equal(t, "TestGo|TestGoContract", contracts[0].ID)
+	}
+	_, _, err = tdrClient.Contracts.SearchContracts("", nil)
+	assert.ErrorIs(t, err, ErrMissingOrganization)
+}
@@ -0,0 +1,205 @@
+package tdr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+const (
+	// MaxBatchSize is the maximum number of data items TDR accepts in a single batch
+	MaxBatchSize = 100
+
+	defaultBatchRetries    = 3
+	defaultBatchRetryDelay = time.Second
+)
+
+// StoreOptions configures StoreDataItems
+type StoreOptions struct {
+	// BatchSize is the number of items per request, at most and defaulting to MaxBatchSize
+	BatchSize int
+	// MaxRetries is the number of times throttled or unavailable items are retried, defaults to 3.
+	// Use a negative value to disable retries
+	MaxRetries int
+	// RetryDelay is the initial delay between retries, defaults to 1 second
+	RetryDelay time.Duration
+}
+
+// DataItemResult is the outcome of storing a single data item
+type DataItemResult struct {
+	// Index is the position of the item in the slice passed to StoreDataItems
+	Index    int
+	Status   int
+	Location string
+	// Attempts is the number of times the item was sent
+	Attempts int
+	Err      error
+}
+
+type batchBundle struct {
+	ResourceType string       `json:"resourceType"`
+	Type         string       `json:"type"`
+	Total        int          `json:"total"`
+	Entry        []batchEntry `json:"entry"`
+}
+
+type batchEntry struct {
+	Resource *DataItem `json:"resource,omitempty"`
+	Response *struct {
+		Status   string          `json:"status"`
+		Location string          `json:"location,omitempty"`
+		Outcome  json.RawMessage `json:"outcome,omitempty"`
+	} `json:"response,omitempty"`
+}
+
+// StoreDataItems stores the data items in batches of at most MaxBatchSize. Items that
+// are throttled or fail with a server error in the batch response, as well as batches
+// that are throttled as a whole, are retried with backoff. A batch failing as a whole
+// otherwise is not retried, as TDR may have stored some of its items already and
+// storing is not idempotent. Waiting for a retry stops when the request context, see
+// WithContext, is done. A result is returned for every item, in order. When items could
+// not be stored the returned error wraps ErrBatchIncomplete
+func (d *DataItemsService) StoreDataItems(items []DataItem, opts *StoreOptions, options ...OptionFunc) ([]DataItemResult, error) {
+	var config StoreOptions
+	if opts != nil {
+		config = *opts
+	}
+	if config.BatchSize <= 0 || config.BatchSize > MaxBatchSize {
+		config.BatchSize = MaxBatchSize
+	}
+	if config.MaxRetries == 0 {
+		config.MaxRetries = defaultBatchRetries
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = defaultBatchRetryDelay
+	}
+	results := make([]DataItemResult, len(items))
+	for i := range results {
+		results[i].Index = i
+	}
+batches:
+	for start := 0; start < len(items); start += config.BatchSize {
+		end := start + config.BatchSize
+		if end > len(items) {
+			end = len(items)
+		}
+		pending := make([]int, 0, end-start)
+		for i := start; i < end; i++ {
+			pending = append(pending, i)
+		}
+		b := backoff.NewExponentialBackOff()
+		b.InitialInterval = config.RetryDelay
+		b.MaxElapsedTime = 0
+		for attempt := 0; len(pending) > 0; attempt++ {
+			var ctx context.Context
+			pending, ctx = d.storeBatch(items, pending, results, options)
+			if len(pending) == 0 || attempt >= config.MaxRetries {
+				break
+			}
+			timer := time.NewTimer(b.NextBackOff())
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				for _, i := range pending {
+					results[i].Err = ctx.Err()
+				}
+				for i := end; i < len(items); i++ {
+					results[i].Err = ctx.Err()
+				}
+				break batches
+			case <-timer.C:
+			}
+		}
+	}
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return results, fmt.Errorf("%d of %d items: %w", failed, len(items), ErrBatchIncomplete)
+	}
+	return results, nil
+}
+
+// storeBatch sends the items at the pending indexes and records their results.
+// It returns the indexes worth retrying and the context of the request
+func (d *DataItemsService) storeBatch(items []DataItem, pending []int, results []DataItemResult, options []OptionFunc) ([]int, context.Context) {
+	bundle := batchBundle{
+		ResourceType: "Bundle",
+		Type:         "batch",
+		Total:        len(pending),
+		Entry:        make([]batchEntry, 0, len(pending)),
+	}
+	for _, i := range pending {
+		item := items[i]
+		bundle.Entry = append(bundle.Entry, batchEntry{Resource: &item})
+		results[i].Attempts++
+	}
+	var retry []int
+	req, err := d.client.newTDRRequest("POST", "store/tdr/DataItem", &bundle, options)
+	if err != nil {
+		for _, i := range pending {
+			results[i].Err = err
+		}
+		return nil, context.Background()
+	}
+	req.Header.Set("Api-Version", APIVersion)
+	var response batchBundle
+	resp, err := d.client.Do(req, &response)
+	if err != nil {
+		// Only a throttled batch is known not to have been processed
+		throttled := resp != nil && resp.StatusCode == http.StatusTooManyRequests
+		for _, i := range pending {
+			results[i].Err = err
+			if resp != nil {
+				results[i].Status = resp.StatusCode
+			}
+			if throttled {
+				retry = append(retry, i)
+			}
+		}
+		return retry, req.Context()
+	}
+	for n, i := range pending {
+		if n >= len(response.Entry) || response.Entry[n].Response == nil {
+			results[i].Status = resp.StatusCode
+			results[i].Err = fmt.Errorf("no response for item %d: %w", i, ErrEmptyResult)
+			continue
+		}
+		entry := response.Entry[n].Response
+		status := parseBatchStatus(entry.Status)
+		results[i].Status = status
+		results[i].Location = entry.Location
+		results[i].Err = nil
+		if status >= 200 && status < 300 {
+			continue
+		}
+		results[i].Err = fmt.Errorf("item %d: HTTP %d: %s", i, status, string(entry.Outcome))
+		if isTransientStatus(status) {
+			retry = append(retry, i)
+		}
+	}
+	return retry, req.Context()
+}
+
+// parseBatchStatus parses a batch entry status such as "201" or "201 Created"
+func parseBatchStatus(status string) int {
+	fields := strings.Fields(status)
+	if len(fields) == 0 {
+		return 0
+	}
+	code, _ := strconv.Atoi(fields[0])
+	return code
+}
+
+func isTransientStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
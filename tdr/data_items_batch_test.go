@@ -0,0 +1,132 @@
+package tdr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreDataItems(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	var batches []int
+	throttled := map[int]bool{}
+	muxTDR.HandleFunc("/store/tdr/DataItem", func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, "POST", r.Method) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		var bundle batchBundle
+		if err := json.NewDecoder(r.Body).Decode(&bundle); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		batches = append(batches, len(bundle.Entry))
+		if len(batches) == 2 {
+			// The whole second batch is throttled once
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		var entries []string
+		for _, entry := range bundle.Entry {
+			sequence := entry.Resource.SequenceNumber
+			status := "201 Created"
+			switch {
+			case sequence == 3 && !throttled[sequence]:
+				throttled[sequence] = true
+				status = "503"
+			case sequence == 4:
+				status = "400"
+			}
+			entries = append(entries, fmt.Sprintf(`{"response": {"status": %q, "location": "DataItem/%d"}}`, status, sequence))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, `{"resourceType": "Bundle", "type": "batch-response", "entry": [%s]}`, strings.Join(entries, ","))
+	})
+
+	items := make([]DataItem, 6)
+	for i := range items {
+		items[i] = DataItem{SequenceNumber: i, Organization: "TDROrg"}
+	}
+	results, err := tdrClient.DataItems.StoreDataItems(items, &StoreOptions{
+		BatchSize:  3,
+		RetryDelay: time.Millisecond,
+	})
+	assert.True(t, errors.Is(err, ErrBatchIncomplete))
+	if !assert.Len(t, results, 6) {
+		return
+	}
+	// Items 0-2 are stored at once, the batch of items 3-5 is throttled and resent,
+	// after which only the unavailable item 3 is retried
+	assert.Equal(t, []int{3, 3, 3, 1}, batches)
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+		if i == 4 {
+			assert.Equal(t, http.StatusBadRequest, result.Status)
+			assert.NotNil(t, result.Err)
+			continue
+		}
+		assert.Nil(t, result.Err, "item %d", i)
+		assert.Equal(t, http.StatusCreated, result.Status)
+		assert.Equal(t, fmt.Sprintf("DataItem/%d", i), result.Location)
+	}
+	assert.Equal(t, 3, results[3].Attempts)
+	assert.Equal(t, 2, results[4].Attempts)
+	assert.Equal(t, 1, results[0].Attempts)
+}
+
+func TestStoreDataItemsNoBatchRetry(t *testing.T) {
+	teardown := setup(t)
+	defer teardown()
+
+	requests := 0
+	muxTDR.HandleFunc("/store/tdr/DataItem", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"resourceType": "Bundle", "type": "batch-response", "entry": [{"response": {"status": "503"}}]}`)
+	})
+
+	items := []DataItem{{SequenceNumber: 1, Organization: "TDROrg"}}
+	// A batch failing as a whole may be partially stored and is not resent
+	results, err := tdrClient.DataItems.StoreDataItems(items, &StoreOptions{RetryDelay: time.Millisecond})
+	assert.True(t, errors.Is(err, ErrBatchIncomplete))
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, http.StatusServiceUnavailable, results[0].Status)
+		assert.Equal(t, 1, results[0].Attempts)
+	}
+	assert.Equal(t, 1, requests)
+
+	// Waiting for the retry of an unavailable item stops when the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	items = append(items, DataItem{SequenceNumber: 2, Organization: "TDROrg"})
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	results, err = tdrClient.DataItems.StoreDataItems(items, &StoreOptions{
+		BatchSize:  1,
+		RetryDelay: time.Hour,
+	}, WithContext(ctx))
+	assert.True(t, errors.Is(err, ErrBatchIncomplete))
+	if assert.Len(t, results, 2) {
+		assert.ErrorIs(t, results[0].Err, context.Canceled)
+		assert.ErrorIs(t, results[1].Err, context.Canceled)
+		assert.Equal(t, 0, results[1].Attempts)
+	}
+}
@@ -11,4 +11,8 @@ var (
 	ErrEmptyResult                    = errors.New("empty result")
 	ErrCouldNoReadResourceAfterCreate = errors.New("could not read resource after create")
 	ErrEmptyResults                   = errors.New("empty results")
+	ErrMissingOrganization            = errors.New("missing organization")
+	ErrMissingDataType                = errors.New("missing data type system or code")
+	ErrInvalidSchema                  = errors.New("schema must be a JSON object")
+	ErrBatchIncomplete                = errors.New("not all data items could be stored")
 )